@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/777genius/claude-notifications/internal/config"
+)
+
+// runConfig dispatches the config subcommands
+func runConfig(args []string) {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications config validate [--json] [path]")
+		fmt.Fprintln(os.Stderr, "       claude-notifications config show [--json] [--cwd DIR]")
+		fmt.Fprintln(os.Stderr, "       claude-notifications config schema")
+		os.Exit(1)
+	}
+	if len(args) == 0 {
+		usage()
+	}
+
+	switch args[0] {
+	case "validate":
+		runConfigValidate(args[1:])
+	case "show":
+		runConfigShow(args[1:])
+	case "schema":
+		data, err := config.SchemaJSON()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(data)
+	default:
+		usage()
+	}
+}
+
+// runConfigValidate reports every problem in a config file; exits 1 if any is an error
+func runConfigValidate(args []string) {
+	fs := flag.NewFlagSet("config validate", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print problems as a JSON array")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications config validate [--json] [path]")
+		fmt.Fprintln(os.Stderr, "Validates path, or the config file the plugin loads if no path is given.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	path := fs.Arg(0)
+	if path == "" {
+		path = config.ActiveConfigPath(getPluginRoot())
+	}
+
+	problems, err := config.ValidateFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *asJSON {
+		if problems == nil {
+			problems = []config.Problem{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(problems)
+	} else {
+		for _, p := range problems {
+			fmt.Printf("%s:%s\n", path, p)
+		}
+		if len(problems) == 0 {
+			fmt.Printf("%s: OK\n", path)
+		}
+	}
+
+	if config.HasErrors(problems) {
+		os.Exit(1)
+	}
+}
+
+// runConfigShow prints every effective config value and where it came from
+func runConfigShow(args []string) {
+	cwd, _ := os.Getwd()
+	fs := flag.NewFlagSet("config show", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print fields as a JSON array")
+	dir := fs.String("cwd", cwd, "Apply the project config (.claude-notifications.json) for this directory; empty to skip")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications config show [--json] [--cwd DIR]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadFromPluginRoot(getPluginRoot())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if projectCfg, _, err := cfg.WithProjectConfig(*dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring project config: %v\n", err)
+	} else {
+		cfg = projectCfg
+	}
+
+	fields := cfg.Fields()
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(fields)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tVALUE\tSOURCE")
+	for _, f := range fields {
+		value, _ := json.Marshal(f.Value)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Path, value, f.Source)
+	}
+	_ = w.Flush()
+}
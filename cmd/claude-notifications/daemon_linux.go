@@ -14,6 +14,18 @@ func runDaemon() {
 	log.Println("[INFO] Starting notification daemon...")
 
 	cfg := daemon.DefaultServerConfig()
+
+	// Under the systemd user service the socket is inherited and the daemon stays up
+	listener, err := daemon.SystemdListener()
+	if err != nil {
+		log.Fatalf("[ERROR] %v", err)
+	}
+	if listener != nil {
+		log.Println("[INFO] Socket-activated by systemd, idle timeout disabled")
+		cfg.Listener = listener
+		cfg.IdleTimeout = 0
+	}
+
 	server, err := daemon.NewServer(cfg)
 	if err != nil {
 		log.Fatalf("[ERROR] Failed to create daemon server: %v", err)
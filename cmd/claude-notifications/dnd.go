@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/quiethours"
+)
+
+// runDND switches do-not-disturb on or off, overriding the quietHours schedule
+func runDND(args []string) {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications dnd [status|on|off|until HH:MM]")
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadFromPluginRoot(getPluginRoot())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load config, using defaults: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+	path, err := cfg.GetDNDPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	action := "status"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "status":
+		if len(args) > 1 {
+			usage()
+		}
+	case "on":
+		if len(args) > 1 {
+			usage()
+		}
+		saveDND(path, quiethours.Override{Quiet: true, SetAt: now})
+	case "until":
+		if len(args) != 2 {
+			usage()
+		}
+		hour, minute, err := config.ParseClock(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		until := quiethours.NextClock(now, hour, minute, cfg.GetQuietHoursLocation())
+		saveDND(path, quiethours.Override{Quiet: true, Until: until, SetAt: now})
+	case "off":
+		if len(args) > 1 {
+			usage()
+		}
+		// Inside a scheduled window, stay off until it ends; otherwise just drop the override
+		if end, ok := quiethours.ScheduledEnd(cfg, now); ok && cfg.Notifications.QuietHours.Enabled {
+			saveDND(path, quiethours.Override{Quiet: false, Until: end, SetAt: now})
+		} else if err := quiethours.ClearOverride(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		usage()
+	}
+
+	printDNDStatus(cfg, path, now)
+}
+
+func saveDND(path string, o quiethours.Override) {
+	if err := quiethours.SaveOverride(path, o); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printDNDStatus prints whether notifications are quiet right now and why
+func printDNDStatus(cfg *config.Config, path string, now time.Time) {
+	override, err := quiethours.LoadOverride(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		override = nil
+	}
+
+	state := quiethours.Evaluate(cfg, override, now)
+	switch {
+	case state.Quiet:
+		fmt.Printf("Do not disturb: on (%s)\n", state.Reason())
+		fmt.Printf("Mode: %s", cfg.GetQuietHoursMode())
+		if len(cfg.Notifications.QuietHours.BreakThrough) > 0 {
+			fmt.Printf(", break through: %v", cfg.Notifications.QuietHours.BreakThrough)
+		}
+		fmt.Println()
+	case state.Manual:
+		fmt.Printf("Do not disturb: off (manual override until %s)\n", state.Until.Format("15:04"))
+	default:
+		fmt.Println("Do not disturb: off")
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/777genius/claude-notifications/internal/doctor"
+)
+
+// runDoctor checks every notification path and prints pass/fail with hints; exits 1 if any check failed
+func runDoctor(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print checks as a JSON array")
+	webhookTest := fs.Bool("webhook-test", false, "Send a test notification to the configured webhook")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications doctor [--json] [--webhook-test]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	checks := doctor.Run(doctor.Options{PluginRoot: getPluginRoot(), WebhookTest: *webhookTest})
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(checks)
+	} else {
+		width := 0
+		for _, c := range checks {
+			if len(c.Name) > width {
+				width = len(c.Name)
+			}
+		}
+		for _, c := range checks {
+			fmt.Printf("[%s] %-*s  %s\n", strings.ToUpper(c.Status), width, c.Name, c.Detail)
+			if c.Hint != "" {
+				fmt.Printf("       %s  -> %s\n", strings.Repeat(" ", width), c.Hint)
+			}
+		}
+	}
+
+	if doctor.Failed(checks) {
+		os.Exit(1)
+	}
+}
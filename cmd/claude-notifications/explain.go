@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/summary"
+	"github.com/777genius/claude-notifications/pkg/jsonl"
+)
+
+// explainTextSnippet is the max length of message text shown per window entry
+const explainTextSnippet = 80
+
+// runExplain prints how a transcript is classified by the Stop/SubagentStop analyzer
+func runExplain(args []string) {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	transcriptPath := fs.String("transcript", "", "Path to the transcript JSONL file (required)")
+	event := fs.String("event", "Stop", "Hook event to simulate: Stop or SubagentStop")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications explain --transcript <file.jsonl> [--event Stop|SubagentStop]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *transcriptPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: --transcript is required")
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *event != "Stop" && *event != "SubagentStop" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported event %q (expected Stop or SubagentStop)\n", *event)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadFromPluginRoot(getPluginRoot())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load config, using defaults: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+
+	decision, err := analyzer.AnalyzeTranscriptDecision(*transcriptPath, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to analyze transcript: %v\n", err)
+		os.Exit(1)
+	}
+
+	printExplanation(os.Stdout, *transcriptPath, *event, decision, cfg)
+}
+
+// printExplanation writes a human-readable report of the analyzer decision
+func printExplanation(w io.Writer, transcriptPath, event string, d analyzer.Decision, cfg *config.Config) {
+	fmt.Fprintf(w, "Transcript: %s\n", transcriptPath)
+	fmt.Fprintf(w, "Event:      %s\n", event)
+	fmt.Fprintf(w, "Messages:   %d\n", d.MessageCount)
+	fmt.Fprintln(w)
+
+	lastUser := d.LastUserTimestamp
+	if lastUser == "" {
+		lastUser = "(none)"
+	}
+	fmt.Fprintf(w, "Last user message: %s\n", lastUser)
+	fmt.Fprintf(w, "Assistant messages after it: %d (window: %d)\n", d.FilteredCount, len(d.Window))
+	fmt.Fprintln(w)
+
+	if len(d.Window) > 0 {
+		fmt.Fprintln(w, "Window:")
+		for i, msg := range d.Window {
+			fmt.Fprintf(w, "  %2d. %s %s", i, msg.Timestamp, msg.Type)
+			if names := toolNames(msg); len(names) > 0 {
+				fmt.Fprintf(w, " tools=[%s]", strings.Join(names, ", "))
+			}
+			if msg.IsApiErrorMessage {
+				fmt.Fprint(w, " api_error")
+			}
+			if text := messageText(msg); text != "" {
+				fmt.Fprintf(w, " %q", text)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "Tools:")
+	if len(d.Tools) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, tool := range d.Tools {
+		fmt.Fprintf(w, "  window[%d] %s\n", tool.Position, tool.Name)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "Rule:   %s\n", d.Rule)
+	fmt.Fprintf(w, "Status: %s\n", d.Status)
+	fmt.Fprintln(w, "Reasons:")
+	for _, reason := range d.Reasons {
+		fmt.Fprintf(w, "  - %s\n", reason)
+	}
+	fmt.Fprintln(w)
+
+	if event == "SubagentStop" && !cfg.Notifications.NotifyOnSubagentStop {
+		fmt.Fprintln(w, "Note: notifyOnSubagentStop is false, SubagentStop would not notify")
+	}
+
+	if d.Status == analyzer.StatusUnknown {
+		fmt.Fprintln(w, "Summary: (none, status unknown: no notification would be sent)")
+		return
+	}
+	fmt.Fprintf(w, "Summary: %s\n", summary.GenerateFromTranscript(transcriptPath, d.Status, cfg))
+}
+
+// toolNames returns the names of tool_use blocks in a message
+func toolNames(msg jsonl.Message) []string {
+	var names []string
+	for _, c := range msg.Message.Content {
+		if c.Type == "tool_use" {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// messageText returns a single-line snippet of the message text
+func messageText(msg jsonl.Message) string {
+	var parts []string
+	for _, c := range msg.Message.Content {
+		if c.Type == "text" && c.Text != "" {
+			parts = append(parts, c.Text)
+		}
+	}
+	text := strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+	if runes := []rune(text); len(runes) > explainTextSnippet {
+		text = string(runes[:explainTextSnippet-3]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/history"
+)
+
+// historyTextWidth is the max length of the message/reason column in table output
+const historyTextWidth = 60
+
+// runHistory prints the notification history, filtered by the given flags
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	session := fs.String("session", "", "Only entries of this session (ID or ID prefix)")
+	project := fs.String("project", "", "Only entries of this project (folder name or part of the path)")
+	status := fs.String("status", "", "Only entries with this status (e.g. task_complete, question)")
+	outcome := fs.String("outcome", "", "Only entries with this outcome: sent, queued, suppressed or deduplicated")
+	since := fs.String("since", "", "Only entries at or after this time: a duration ago (30m, 2h, 7d) or a date (2006-01-02, 2006-01-02T15:04)")
+	until := fs.String("until", "", "Only entries before this time (same formats as --since)")
+	limit := fs.Int("limit", 50, "Show at most this many of the newest matching entries (0 = all)")
+	asJSON := fs.Bool("json", false, "Print entries as a JSON array instead of a table")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications history [--session ID] [--project NAME] [--status STATUS] [--outcome OUTCOME] [--since TIME] [--until TIME] [--limit N] [--json]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	now := time.Now()
+	filter := history.Filter{SessionID: *session, Project: *project, Status: *status, Outcome: *outcome}
+	var err error
+	if filter.Since, err = parseHistoryTime(*since, now); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --since: %v\n", err)
+		os.Exit(1)
+	}
+	if filter.Until, err = parseHistoryTime(*until, now); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --until: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadFromPluginRoot(getPluginRoot())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load config, using defaults: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+	path, err := cfg.GetHistoryPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	entries, err := history.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load history: %v\n", err)
+		os.Exit(1)
+	}
+
+	entries = filter.Apply(entries)
+	if *limit > 0 && len(entries) > *limit {
+		entries = entries[len(entries)-*limit:]
+	}
+
+	if *asJSON {
+		if err := printHistoryJSON(os.Stdout, entries); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No matching notifications in %s\n", path)
+		return
+	}
+	printHistoryTable(os.Stdout, entries)
+}
+
+// parseHistoryTime parses a --since/--until value relative to now.
+// Accepts Go durations plus a "d" (days) suffix, RFC 3339, and local dates with optional time.
+func parseHistoryTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is neither a duration (30m, 2h, 7d) nor a date (2006-01-02, 2006-01-02T15:04)", value)
+}
+
+// printHistoryJSON writes entries as an indented JSON array
+func printHistoryJSON(w io.Writer, entries []history.Entry) error {
+	if entries == nil {
+		entries = []history.Entry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// printHistoryTable writes entries as an aligned table, oldest first
+func printHistoryTable(w io.Writer, entries []history.Entry) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tSTATUS\tOUTCOME\tPROJECT\tSESSION\tCHANNELS\tMESSAGE")
+
+	for _, e := range entries {
+		text := e.Message
+		if e.Outcome != history.OutcomeSent && e.Outcome != history.OutcomeQueued {
+			text = e.Reason
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"),
+			e.Status,
+			e.Outcome,
+			orDash(e.Project()),
+			orDash(shortSessionID(e.SessionID)),
+			orDash(formatChannels(e.Channels)),
+			truncateText(text, historyTextWidth))
+	}
+	tw.Flush()
+}
+
+// formatChannels renders channel outcomes compactly, e.g. "desktop:sent webhook:failed".
+// Disabled channels are left out.
+func formatChannels(channels []history.Channel) string {
+	var parts []string
+	for _, c := range channels {
+		if c.Outcome == history.ChannelDisabled {
+			continue
+		}
+		parts = append(parts, c.Name+":"+c.Outcome)
+	}
+	return strings.Join(parts, " ")
+}
+
+// shortSessionID keeps the first segment of a UUID session ID
+func shortSessionID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// truncateText collapses newlines and shortens s to max runes
+func truncateText(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) > max {
+		return string(runes[:max-1]) + "…"
+	}
+	return s
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -38,6 +38,24 @@ func main() {
 			os.Exit(1)
 		}
 		handleHook(os.Args[2])
+	case "remind":
+		handleReminder()
+	case "deliver":
+		handleDeliver()
+	case "explain":
+		runExplain(os.Args[2:])
+	case "replay":
+		runReplay(os.Args[2:])
+	case "history":
+		runHistory(os.Args[2:])
+	case "watch":
+		runWatch(os.Args[2:])
+	case "dnd":
+		runDND(os.Args[2:])
+	case "config":
+		runConfig(os.Args[2:])
+	case "doctor":
+		runDoctor(os.Args[2:])
 	case "focus-window":
 		if len(os.Args) < 4 {
 			fmt.Fprintf(os.Stderr, "Error: focus-window requires bundleID and cwd arguments\n")
@@ -49,6 +67,8 @@ func main() {
 		}
 	case "daemon", "--daemon":
 		runDaemon()
+	case "service":
+		runService(os.Args[2:])
 	case "version", "--version", "-v":
 		fmt.Printf("claude-notifications v%s\n", version)
 	case "help", "--help", "-h":
@@ -74,6 +94,9 @@ func handleHook(hookEvent string) {
 	}
 	defer logging.Close()
 
+	// Keep the systemd service pointed at this binary after plugin updates
+	refreshServiceBinary()
+
 	// Create handler
 	handler, err := hooks.NewHandler(pluginRoot)
 	if err != nil {
@@ -88,6 +111,54 @@ func handleHook(hookEvent string) {
 	}
 }
 
+// handleReminder delivers a reminder for an unanswered prompt (invoked by the daemon)
+func handleReminder() {
+	defer errorhandler.HandlePanic()
+
+	pluginRoot := getPluginRoot()
+
+	if _, err := logging.InitLogger(pluginRoot); err != nil {
+		errorhandler.HandleCriticalError(err, "Failed to initialize logger")
+		os.Exit(1)
+	}
+	defer logging.Close()
+
+	handler, err := hooks.NewHandler(pluginRoot)
+	if err != nil {
+		errorhandler.HandleCriticalError(err, "Failed to create handler")
+		os.Exit(1)
+	}
+
+	if err := handler.HandleReminder(os.Stdin); err != nil {
+		errorhandler.HandleCriticalError(err, "Failed to handle reminder")
+		os.Exit(1)
+	}
+}
+
+// handleDeliver sends the notifications of a closed aggregation window (invoked by the daemon)
+func handleDeliver() {
+	defer errorhandler.HandlePanic()
+
+	pluginRoot := getPluginRoot()
+
+	if _, err := logging.InitLogger(pluginRoot); err != nil {
+		errorhandler.HandleCriticalError(err, "Failed to initialize logger")
+		os.Exit(1)
+	}
+	defer logging.Close()
+
+	handler, err := hooks.NewHandler(pluginRoot)
+	if err != nil {
+		errorhandler.HandleCriticalError(err, "Failed to create handler")
+		os.Exit(1)
+	}
+
+	if err := handler.HandleAggregate(os.Stdin); err != nil {
+		errorhandler.HandleCriticalError(err, "Failed to deliver aggregated notifications")
+		os.Exit(1)
+	}
+}
+
 func getPluginRoot() string {
 	// Try CLAUDE_PLUGIN_ROOT environment variable first
 	if root := os.Getenv("CLAUDE_PLUGIN_ROOT"); root != "" {
@@ -121,17 +192,40 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  claude-notifications handle-hook <HookName>")
+	fmt.Println("  claude-notifications explain --transcript <file.jsonl> [--event Stop|SubagentStop]")
+	fmt.Println("  claude-notifications replay <journal-dir|entry.json>")
+	fmt.Println("  claude-notifications history [--session ID] [--project NAME] [--status STATUS] [--since TIME] [--json]")
+	fmt.Println("  claude-notifications watch [--json]")
+	fmt.Println("  claude-notifications dnd [status|on|off|until HH:MM]")
+	fmt.Println("  claude-notifications config validate [--json] [path]")
+	fmt.Println("  claude-notifications config show [--json] [--cwd DIR]")
+	fmt.Println("  claude-notifications config schema")
+	fmt.Println("  claude-notifications doctor [--json] [--webhook-test]")
 	fmt.Println("  claude-notifications daemon")
+	fmt.Println("  claude-notifications service install|uninstall|status")
 	fmt.Println("  claude-notifications version")
 	fmt.Println("  claude-notifications help")
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  handle-hook <HookName>  Handle a Claude Code hook event")
-	fmt.Println("                          HookName: PreToolUse, Stop, SubagentStop, Notification")
+	fmt.Println("                          HookName: PreToolUse, PostToolUse, Stop, SubagentStop, Notification,")
+	fmt.Println("                                    SessionStart, SessionEnd, UserPromptSubmit, PreCompact")
+	fmt.Println("  explain                 Show how a transcript is classified (rule, tools, summary)")
+	fmt.Println("  replay                  Replay journaled hook payloads and report what would be sent")
+	fmt.Println("  history                 Show sent and suppressed notifications (table or --json)")
+	fmt.Println("  watch                   Stream notifications as they are sent, suppressed, clicked or closed (Linux only)")
+	fmt.Println("  dnd                     Show or override do-not-disturb (quietHours)")
+	fmt.Println("  config validate         Check a config file: unknown keys, types, deprecated fields (with line:col)")
+	fmt.Println("  config show             Show every effective config value and its source (file, project, env)")
+	fmt.Println("  config schema           Print the JSON Schema of config.json")
+	fmt.Println("  doctor                  Check config, sounds, audio device, daemon, focus tools and webhook")
 	fmt.Println("  daemon                  Run the notification daemon (Linux only)")
 	fmt.Println("                          For click-to-focus support on desktop notifications")
+	fmt.Println("  service                 Run the daemon as a socket-activated systemd user service (Linux only)")
 	fmt.Println("  focus-window <bundleID> <cwd>")
 	fmt.Println("                          Focus specific VS Code window (internal, used by click-to-focus)")
+	fmt.Println("  remind                  Send a reminder for an unanswered prompt (internal, used by the daemon)")
+	fmt.Println("  deliver                 Send notifications merged by the aggregation window (internal, used by the daemon)")
 	fmt.Println("  version                 Show version information")
 	fmt.Println("  help                    Show this help message")
 	fmt.Println()
@@ -142,10 +236,28 @@ func printUsage() {
 	fmt.Println("  # Handle Stop hook")
 	fmt.Println("  echo '{\"session_id\":\"test\",\"transcript_path\":\"/path/to/transcript.jsonl\"}' | claude-notifications handle-hook Stop")
 	fmt.Println()
+	fmt.Println("  # Debug why a transcript produced a given notification")
+	fmt.Println("  claude-notifications explain --transcript ~/.claude/projects/<project>/<session>.jsonl")
+	fmt.Println()
+	fmt.Println("  # Notifications of the last 2 hours in one project")
+	fmt.Println("  claude-notifications history --project my-app --since 2h")
+	fmt.Println()
+	fmt.Println("  # Mute notifications until 14:00")
+	fmt.Println("  claude-notifications dnd until 14:00")
+	fmt.Println()
+	fmt.Println("  # Check the active config file for typos and invalid values")
+	fmt.Println("  claude-notifications config validate")
+	fmt.Println()
+	fmt.Println("  # Find out why notifications don't arrive, including a test webhook message")
+	fmt.Println("  claude-notifications doctor --webhook-test")
+	fmt.Println()
 	fmt.Println("  # Run notification daemon (Linux only, started automatically)")
 	fmt.Println("  claude-notifications daemon")
 	fmt.Println()
 	fmt.Println("Environment Variables:")
 	fmt.Println("  CLAUDE_PLUGIN_ROOT  Plugin root directory (auto-detected if not set)")
+	fmt.Println("  CLAUDE_NOTIFICATIONS_<FIELD>")
+	fmt.Println("                      Override a config field, e.g. CLAUDE_NOTIFICATIONS_WEBHOOK_URL,")
+	fmt.Println("                      CLAUDE_NOTIFICATIONS_DESKTOP_ENABLED=false (see `config show`)")
 	fmt.Println()
 }
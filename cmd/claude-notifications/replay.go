@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/hooks"
+	"github.com/777genius/claude-notifications/internal/journal"
+)
+
+// runReplay feeds journaled hook payloads through the pipeline and reports what would have happened
+func runReplay(args []string) {
+	if len(args) < 1 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications replay <journal-dir|entry.json>")
+		os.Exit(1)
+	}
+
+	entries, err := journal.Load(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load journal: %v\n", err)
+		os.Exit(1)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no journal entries in %s\n", args[0])
+		os.Exit(1)
+	}
+
+	pluginRoot := getPluginRoot()
+	cfg, err := config.LoadFromPluginRoot(pluginRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid config: %v\n", err)
+		os.Exit(1)
+	}
+
+	results, err := hooks.Replay(cfg, pluginRoot, entries)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: replay failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	printReplayResults(os.Stdout, results)
+}
+
+// printReplayResults writes one block per replayed entry and a summary line
+func printReplayResults(w io.Writer, results []hooks.ReplayResult) {
+	counts := make(map[hooks.OutcomeKind]int)
+	errors := 0
+
+	for i, r := range results {
+		fmt.Fprintf(w, "[%d/%d] %s %s", i+1, len(results), r.Entry.Time.Local().Format("2006-01-02 15:04:05"), r.Entry.Event)
+		if r.Entry.TranscriptTruncated {
+			fmt.Fprint(w, " (transcript truncated)")
+		}
+		fmt.Fprintln(w)
+
+		if r.Err != nil {
+			errors++
+			fmt.Fprintf(w, "  error: %v\n", r.Err)
+			continue
+		}
+
+		counts[r.Outcome.Kind]++
+		fmt.Fprintf(w, "  → %s", r.Outcome.Kind)
+		if r.Outcome.Status != "" {
+			fmt.Fprintf(w, " (%s)", r.Outcome.Status)
+		}
+		if r.Outcome.Reason != "" {
+			fmt.Fprintf(w, ": %s", r.Outcome.Reason)
+		}
+		fmt.Fprintln(w)
+
+		for _, n := range r.Desktop {
+			fmt.Fprintf(w, "  desktop: %s: %s\n", n.Title, n.Message)
+		}
+		for _, n := range r.Webhook {
+			fmt.Fprintf(w, "  webhook: %s\n", n.Message)
+		}
+		for _, n := range r.Exec {
+			fmt.Fprintf(w, "  exec: %s\n", n.Message)
+		}
+		if r.Reminders > 0 {
+			fmt.Fprintln(w, "  reminders: scheduled")
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d sent, %d queued, %d suppressed, %d deduplicated, %d skipped, %d errors\n",
+		counts[hooks.OutcomeSent], counts[hooks.OutcomeQueued], counts[hooks.OutcomeSuppressed],
+		counts[hooks.OutcomeDeduplicated], counts[hooks.OutcomeSkipped], errors)
+}
@@ -0,0 +1,105 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/777genius/claude-notifications/internal/daemon"
+	"github.com/777genius/claude-notifications/internal/logging"
+)
+
+// runService installs, removes or reports the systemd user service that runs the daemon
+func runService(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications service install|uninstall|status")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "install":
+		binary, err := currentBinary()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot locate the claude-notifications binary: %v\n", err)
+			os.Exit(1)
+		}
+		if err := daemon.InstallService(binary); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		link, _ := daemon.ServiceBinaryLink()
+		fmt.Printf("Installed %s and %s (daemon: %s -> %s)\n", daemon.SocketUnitName, daemon.ServiceUnitName, link, binary)
+		fmt.Println("The daemon starts with the next notification and stays running.")
+	case "uninstall":
+		if err := daemon.UninstallService(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Removed the systemd user service; hooks start the daemon on demand again.")
+	case "status":
+		printServiceStatus()
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown service command: %s\n", args[0])
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications service install|uninstall|status")
+		os.Exit(1)
+	}
+}
+
+// currentBinary returns the resolved path of the running binary
+func currentBinary() (string, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(binary)
+}
+
+// refreshServiceBinary points the service's binary link at this binary, which moves
+// with every plugin update. Hooks call it so the socket-activated service keeps starting.
+func refreshServiceBinary() {
+	binary, err := currentBinary()
+	if err == nil {
+		err = daemon.RefreshServiceBinary(binary)
+	}
+	if err != nil {
+		logging.Warn("Failed to update the service binary link: %v", err)
+	}
+}
+
+func printServiceStatus() {
+	status, err := daemon.GetServiceStatus()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if status.Installed {
+		fmt.Printf("Units:   installed in %s\n", status.UnitDir)
+	} else {
+		fmt.Printf("Units:   not installed (run `claude-notifications service install`)\n")
+	}
+	if status.BinaryError != "" {
+		fmt.Printf("Binary:  %s\n", status.BinaryError)
+		fmt.Println("         run `claude-notifications service install` again")
+	} else if status.Binary != "" {
+		fmt.Printf("Binary:  %s\n", status.Binary)
+	}
+	fmt.Printf("Socket:  %s, %s\n", status.SocketEnabled, status.SocketActive)
+	fmt.Printf("Service: %s\n", status.ServiceActive)
+
+	client, err := daemon.NewClient()
+	if err == nil {
+		var ping *daemon.PingResponse
+		if ping, err = client.Ping(); err == nil {
+			mode := "started on demand"
+			if ping.Systemd {
+				mode = "socket-activated"
+			}
+			fmt.Printf("Daemon:  running (version %s, up %ds, %s)\n", ping.Version, ping.Uptime, mode)
+			return
+		}
+	}
+	fmt.Printf("Daemon:  not running (%v)\n", err)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// runService is a stub for non-Linux platforms
+func runService(args []string) {
+	fmt.Fprintln(os.Stderr, "Error: the systemd user service is only available on Linux")
+	os.Exit(1)
+}
+
+// refreshServiceBinary is a no-op without the systemd user service
+func refreshServiceBinary() {}
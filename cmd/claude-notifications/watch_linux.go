@@ -0,0 +1,111 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/daemon"
+	"github.com/777genius/claude-notifications/internal/history"
+)
+
+// watchReconnectDelay is the pause before reconnecting after the daemon went away
+const watchReconnectDelay = 2 * time.Second
+
+// runWatch streams notification events from the daemon until interrupted
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print each event as one JSON object per line")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: claude-notifications watch [--json]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	emit := func(ev daemon.Event) error {
+		printWatchEvent(os.Stdout, ev)
+		return nil
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		emit = func(ev daemon.Event) error {
+			return enc.Encode(ev)
+		}
+	}
+
+	// Keep watching across daemon restarts (idle exit, systemd restart, upgrades)
+	for attempt := 0; ; attempt++ {
+		if !daemon.StartDaemonOnDemand() {
+			if attempt == 0 {
+				fmt.Fprintln(os.Stderr, "Error: notification daemon is not available (see `claude-notifications doctor`)")
+				os.Exit(1)
+			}
+			time.Sleep(watchReconnectDelay)
+			continue
+		}
+
+		client, err := daemon.NewClient()
+		if err == nil {
+			if attempt == 0 && !*asJSON {
+				fmt.Fprintln(os.Stderr, "Watching notifications (Ctrl+C to stop)...")
+			}
+			err = client.Subscribe(emit)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Connection to daemon lost: %v; reconnecting\n", err)
+		}
+		time.Sleep(watchReconnectDelay)
+	}
+}
+
+// printWatchEvent writes one event as a line: time, type, status, project, session and details
+func printWatchEvent(w io.Writer, ev daemon.Event) {
+	details := ev.Message
+	switch ev.Type {
+	case daemon.EventSuppressed, daemon.EventClosed:
+		details = ev.Reason
+	case daemon.EventClicked:
+		details = ""
+	}
+	if channels := formatEventChannels(ev.Channels); channels != "" {
+		details += " [" + channels + "]"
+	}
+
+	project := ""
+	if ev.CWD != "" {
+		project = filepath.Base(ev.CWD)
+	}
+
+	fmt.Fprintf(w, "%s  %-10s  %-22s  %-16s  %-8s  %s\n",
+		ev.Time.Local().Format("15:04:05"),
+		ev.Type,
+		orDash(ev.Status),
+		orDash(project),
+		orDash(shortSessionID(ev.SessionID)),
+		truncateText(strings.TrimSpace(details), historyTextWidth))
+}
+
+// formatEventChannels renders channel outcomes like formatChannels, sorted by name
+func formatEventChannels(channels map[string]string) string {
+	var parts []string
+	for name, outcome := range channels {
+		if outcome == history.ChannelDisabled {
+			continue
+		}
+		parts = append(parts, name+":"+outcome)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
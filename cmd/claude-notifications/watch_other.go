@@ -0,0 +1,15 @@
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// runWatch is a stub for non-Linux platforms
+func runWatch(args []string) {
+	fmt.Fprintln(os.Stderr, "Error: watch needs the notification daemon, which is only available on Linux")
+	fmt.Fprintln(os.Stderr, "Use `claude-notifications history` to see past notifications.")
+	os.Exit(1)
+}
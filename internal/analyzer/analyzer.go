@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/777genius/claude-notifications/internal/config"
@@ -39,63 +40,139 @@ const (
 	StatusSessionLimitReached Status = "session_limit_reached"
 	StatusAPIError            Status = "api_error"
 	StatusAPIErrorOverloaded  Status = "api_error_overloaded"
+	StatusContextCompacting   Status = "context_compacting"
+	StatusPermissionRequest   Status = "permission_request"
+	StatusIdlePrompt          Status = "idle_prompt"
 	StatusUnknown             Status = "unknown"
 )
 
+// Rule identifies which analyzer rule decided the status
+type Rule string
+
+const (
+	RuleSessionLimit         Rule = "session_limit"            // Priority check 1: "Session limit reached" text
+	RuleAPIError             Rule = "api_error"                // Priority check 2: isApiErrorMessage flag
+	RuleNoMessages           Rule = "no_messages"              // No assistant messages after the last user message
+	RuleExitPlanMode         Rule = "1a"                       // Last tool is ExitPlanMode
+	RuleAskUserQuestion      Rule = "1b"                       // Last tool is AskUserQuestion
+	RulePlanExecuted         Rule = "1c"                       // Tools used after ExitPlanMode
+	RuleReview               Rule = "1d"                       // Only read-like tools plus long text
+	RuleActiveTool           Rule = "1e"                       // Last tool is an active tool
+	RuleAnyTool              Rule = "1f"                       // Any other tool usage
+	RuleTextResponse         Rule = "2"                        // No tools, notifyOnTextResponse enabled
+	RuleTextResponseDisabled Rule = "2_text_response_disabled" // No tools, notifyOnTextResponse disabled
+)
+
+// maxWindowMessages is the temporal window: only the last N assistant messages of the current response are analyzed
+const maxWindowMessages = 15
+
+// Decision is the structured result of transcript analysis.
+// It records the inputs the state machine looked at and why it picked the status.
+type Decision struct {
+	Status            Status
+	Rule              Rule
+	Reasons           []string        // Human-readable trace, in evaluation order
+	MessageCount      int             // Messages parsed from the transcript
+	LastUserTimestamp string          // Timestamp of the last user text message ("" = none)
+	FilteredCount     int             // Assistant messages after the last user message
+	Window            []jsonl.Message // Messages analyzed (last 15 of the filtered set)
+	Tools             []jsonl.ToolUse // Tools extracted from the window, positions relative to Window
+	LastTool          string
+}
+
+// addReason appends a formatted line to the decision trace
+func (d *Decision) addReason(format string, args ...interface{}) {
+	d.Reasons = append(d.Reasons, fmt.Sprintf(format, args...))
+}
+
+// decide sets the final status and rule
+func (d *Decision) decide(status Status, rule Rule, format string, args ...interface{}) Decision {
+	d.Status = status
+	d.Rule = rule
+	d.addReason(format, args...)
+	return *d
+}
+
 // AnalyzeTranscript analyzes a transcript file and determines the current status
 func AnalyzeTranscript(transcriptPath string, cfg *config.Config) (Status, error) {
+	decision, err := AnalyzeTranscriptDecision(transcriptPath, cfg)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	return decision.Status, nil
+}
+
+// AnalyzeTranscriptDecision analyzes a transcript file and returns the full decision trace
+func AnalyzeTranscriptDecision(transcriptPath string, cfg *config.Config) (Decision, error) {
 	// Parse JSONL file
 	messages, err := jsonl.ParseFile(transcriptPath)
 	if err != nil {
-		return StatusUnknown, err
+		return Decision{Status: StatusUnknown}, err
 	}
 
+	return AnalyzeMessages(messages, cfg), nil
+}
+
+// AnalyzeMessages runs the status state machine on parsed transcript messages
+func AnalyzeMessages(messages []jsonl.Message, cfg *config.Config) Decision {
+	d := &Decision{Status: StatusUnknown, MessageCount: len(messages)}
+
 	// PRIORITY CHECK 1: Session limit reached
 	// This takes precedence over all other status detection
 	if detectSessionLimitReached(messages) {
-		return StatusSessionLimitReached, nil
+		return d.decide(StatusSessionLimitReached, RuleSessionLimit,
+			"session limit text found in the last 3 assistant messages")
 	}
+	d.addReason("session limit: not detected")
 
 	// PRIORITY CHECK 2: API errors (uses isApiErrorMessage flag from JSONL)
 	if apiStatus := detectAPIErrors(messages); apiStatus != StatusUnknown {
-		return apiStatus, nil
+		return d.decide(apiStatus, RuleAPIError,
+			"API error message (isApiErrorMessage) after the last user message → %s", apiStatus)
 	}
+	d.addReason("API error: not detected")
 
 	// Find last user message timestamp
 	// This ensures we only analyze tools from the CURRENT response,
 	// not from previous user requests (avoids "ghost" ExitPlanMode problem)
 	userTS := jsonl.GetLastUserTimestamp(messages)
+	d.LastUserTimestamp = userTS
 
 	// Filter assistant messages AFTER last user message
 	filteredMessages := jsonl.FilterMessagesAfterTimestamp(messages, userTS)
+	d.FilteredCount = len(filteredMessages)
 
 	if len(filteredMessages) == 0 {
-		return StatusUnknown, nil
+		return d.decide(StatusUnknown, RuleNoMessages, "no assistant messages after the last user message")
 	}
 
 	// Take last 15 messages (temporal window) from filtered set
 	recentMessages := filteredMessages
-	if len(filteredMessages) > 15 {
-		recentMessages = filteredMessages[len(filteredMessages)-15:]
+	if len(filteredMessages) > maxWindowMessages {
+		recentMessages = filteredMessages[len(filteredMessages)-maxWindowMessages:]
 	}
+	d.Window = recentMessages
+	d.addReason("window: %d of %d assistant messages after the last user message", len(recentMessages), len(filteredMessages))
 
 	// Extract tools with positions
 	tools := jsonl.ExtractTools(recentMessages)
+	d.Tools = tools
 
 	// STATE MACHINE LOGIC - tool-based detection only
 
 	// 1. If we have tools, analyze them
 	if len(tools) > 0 {
 		lastTool := jsonl.GetLastTool(tools)
+		d.LastTool = lastTool
 
 		// 1a. Last tool is ExitPlanMode → plan just created
 		if lastTool == "ExitPlanMode" {
-			return StatusPlanReady, nil
+			return d.decide(StatusPlanReady, RuleExitPlanMode, "last tool is ExitPlanMode")
 		}
 
 		// 1b. Last tool is AskUserQuestion → waiting for user
 		if lastTool == "AskUserQuestion" {
-			return StatusQuestion, nil
+			return d.decide(StatusQuestion, RuleAskUserQuestion, "last tool is AskUserQuestion")
 		}
 
 		// 1c. ExitPlanMode exists AND tools after it → plan executed
@@ -103,7 +180,8 @@ func AnalyzeTranscript(transcriptPath string, cfg *config.Config) (Status, error
 		if exitPlanPos >= 0 {
 			toolsAfter := jsonl.CountToolsAfterPosition(tools, exitPlanPos)
 			if toolsAfter > 0 {
-				return StatusTaskComplete, nil
+				return d.decide(StatusTaskComplete, RulePlanExecuted,
+					"ExitPlanMode in window message %d followed by %d tool(s)", exitPlanPos, toolsAfter)
 			}
 		}
 
@@ -120,28 +198,30 @@ func AnalyzeTranscript(transcriptPath string, cfg *config.Config) (Status, error
 			recentText := jsonl.ExtractRecentText(recentMessages, 5)
 
 			if len(recentText) > 200 {
-				return StatusReviewComplete, nil
+				return d.decide(StatusReviewComplete, RuleReview,
+					"%d read-like tool(s), no active tools, %d chars of text (>200)", readLikeCount, len(recentText))
 			}
+			d.addReason("review: %d read-like tool(s) but only %d chars of text (needs >200)", readLikeCount, len(recentText))
 		}
 
 		// 1e. Last tool is active (Write/Edit/Bash) → work completed
 		if contains(ActiveTools, lastTool) {
-			return StatusTaskComplete, nil
+			return d.decide(StatusTaskComplete, RuleActiveTool, "last tool %s is an active tool", lastTool)
 		}
 
 		// 1f. Any tool usage at all → likely task completed
 		// (matches bash version: toolCount >= 1 → task_complete)
-		return StatusTaskComplete, nil
+		return d.decide(StatusTaskComplete, RuleAnyTool, "%d tool(s) used, last tool %s", len(tools), lastTool)
 	}
 
 	// 2. No tools found
 	// If notifyOnTextResponse is enabled (default: true), treat as task_complete
 	// This handles cases like extended thinking where Claude responds with text only
 	if cfg.ShouldNotifyOnTextResponse() {
-		return StatusTaskComplete, nil
+		return d.decide(StatusTaskComplete, RuleTextResponse, "no tools in window, text-only response (notifyOnTextResponse: true)")
 	}
 
-	return StatusUnknown, nil
+	return d.decide(StatusUnknown, RuleTextResponseDisabled, "no tools in window, text-only response (notifyOnTextResponse: false)")
 }
 
 // contains checks if a slice contains a string
@@ -166,6 +246,26 @@ func GetStatusForPreToolUse(toolName string) Status {
 	return StatusUnknown
 }
 
+// GetStatusForNotification determines status for Notification hook
+// notification_type is preferred; older Claude Code versions only send the message text
+func GetStatusForNotification(notificationType, message string) Status {
+	switch notificationType {
+	case "permission_prompt":
+		return StatusPermissionRequest
+	case "idle_prompt":
+		return StatusIdlePrompt
+	case "":
+		lower := strings.ToLower(message)
+		if strings.Contains(lower, "needs your permission") {
+			return StatusPermissionRequest
+		}
+		if strings.Contains(lower, "waiting for your input") {
+			return StatusIdlePrompt
+		}
+	}
+	return StatusQuestion
+}
+
 // detectSessionLimitReached checks if the last assistant messages contain "Session limit reached"
 func detectSessionLimitReached(messages []jsonl.Message) bool {
 	// Check last 3 assistant messages for the session limit text
@@ -619,6 +619,32 @@ func TestGetStatusForPreToolUse(t *testing.T) {
 	}
 }
 
+func TestGetStatusForNotification(t *testing.T) {
+	tests := []struct {
+		name             string
+		notificationType string
+		message          string
+		expected         Status
+	}{
+		{"permission_type", "permission_prompt", "Claude needs your permission to use Bash", StatusPermissionRequest},
+		{"idle_type", "idle_prompt", "Claude is waiting for your input", StatusIdlePrompt},
+		{"other_type", "elicitation_dialog", "Claude needs your permission to use Bash", StatusQuestion},
+		{"legacy_permission_message", "", "Claude needs your permission to use Bash", StatusPermissionRequest},
+		{"legacy_idle_message", "", "Claude is waiting for your input", StatusIdlePrompt},
+		{"legacy_unknown_message", "", "Something else", StatusQuestion},
+		{"empty", "", "", StatusQuestion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := GetStatusForNotification(tt.notificationType, tt.message)
+			if status != tt.expected {
+				t.Errorf("got %v, want %v", status, tt.expected)
+			}
+		})
+	}
+}
+
 func TestAnalyzeTranscript_SessionLimitReached(t *testing.T) {
 	cfg := &config.Config{}
 
@@ -870,6 +896,110 @@ func TestAnalyzeTranscript_APIError(t *testing.T) {
 	})
 }
 
+func TestAnalyzeMessages_Rules(t *testing.T) {
+	notifyOnText := false
+	noTextCfg := &config.Config{
+		Notifications: config.NotificationsConfig{
+			NotifyOnTextResponse: &notifyOnText,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		messages   []jsonl.Message
+		cfg        *config.Config
+		wantStatus Status
+		wantRule   Rule
+	}{
+		{"no_messages", []jsonl.Message{buildUserMessage("Hi")}, &config.Config{}, StatusUnknown, RuleNoMessages},
+		{"1a_exit_plan_mode", buildTestMessages([]string{"Read", "ExitPlanMode"}, 10), &config.Config{}, StatusPlanReady, RuleExitPlanMode},
+		{"1b_ask_user_question", buildTestMessages([]string{"AskUserQuestion"}, 10), &config.Config{}, StatusQuestion, RuleAskUserQuestion},
+		{"1c_plan_executed", []jsonl.Message{
+			buildUserMessage("Plan it"),
+			buildAssistantWithTools([]string{"ExitPlanMode"}, "plan"),
+			buildAssistantWithTools([]string{"Read"}, "done"),
+		}, &config.Config{}, StatusTaskComplete, RulePlanExecuted},
+		{"1d_review", buildTestMessages([]string{"Read", "Grep"}, 300), &config.Config{}, StatusReviewComplete, RuleReview},
+		{"1e_active_tool", buildTestMessages([]string{"Read", "Edit"}, 10), &config.Config{}, StatusTaskComplete, RuleActiveTool},
+		{"1f_any_tool", buildTestMessages([]string{"Read"}, 10), &config.Config{}, StatusTaskComplete, RuleAnyTool},
+		{"2_text_response", buildTestMessages(nil, 10), &config.Config{}, StatusTaskComplete, RuleTextResponse},
+		{"2_text_response_disabled", buildTestMessages(nil, 10), noTextCfg, StatusUnknown, RuleTextResponseDisabled},
+		{"session_limit", []jsonl.Message{
+			buildUserMessage("Hi"),
+			buildAssistantWithTools(nil, "Session limit reached ∙ resets 5pm"),
+		}, &config.Config{}, StatusSessionLimitReached, RuleSessionLimit},
+		{"api_error", []jsonl.Message{
+			buildUserMessage("Hi"),
+			buildApiErrorMessage("API Error: 401 authentication_error", "authentication_failed"),
+		}, &config.Config{}, StatusAPIError, RuleAPIError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := AnalyzeMessages(tt.messages, tt.cfg)
+
+			if d.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", d.Status, tt.wantStatus)
+			}
+			if d.Rule != tt.wantRule {
+				t.Errorf("Rule = %q, want %q", d.Rule, tt.wantRule)
+			}
+			if len(d.Reasons) == 0 {
+				t.Error("expected at least one reason")
+			}
+			if d.MessageCount != len(tt.messages) {
+				t.Errorf("MessageCount = %d, want %d", d.MessageCount, len(tt.messages))
+			}
+		})
+	}
+}
+
+func TestAnalyzeMessages_DecisionInputs(t *testing.T) {
+	messages := buildTestMessages([]string{"Read", "Edit"}, 10)
+
+	d := AnalyzeMessages(messages, &config.Config{})
+
+	if d.LastUserTimestamp != "2025-01-01T12:00:00Z" {
+		t.Errorf("LastUserTimestamp = %q, want 2025-01-01T12:00:00Z", d.LastUserTimestamp)
+	}
+	if d.FilteredCount != 1 || len(d.Window) != 1 {
+		t.Errorf("FilteredCount = %d, len(Window) = %d, want 1 and 1", d.FilteredCount, len(d.Window))
+	}
+	if len(d.Tools) != 2 || d.Tools[0].Name != "Read" || d.Tools[1].Name != "Edit" {
+		t.Errorf("Tools = %+v, want [Read Edit]", d.Tools)
+	}
+	if d.LastTool != "Edit" {
+		t.Errorf("LastTool = %q, want Edit", d.LastTool)
+	}
+}
+
+func TestAnalyzeMessages_WindowLimit(t *testing.T) {
+	messages := []jsonl.Message{buildUserMessage("Go")}
+	for i := 0; i < 20; i++ {
+		messages = append(messages, buildAssistantWithTools([]string{"Bash"}, "step"))
+	}
+
+	d := AnalyzeMessages(messages, &config.Config{})
+
+	if d.FilteredCount != 20 {
+		t.Errorf("FilteredCount = %d, want 20", d.FilteredCount)
+	}
+	if len(d.Window) != maxWindowMessages {
+		t.Errorf("len(Window) = %d, want %d", len(d.Window), maxWindowMessages)
+	}
+}
+
+func TestAnalyzeTranscriptDecision_MissingFile(t *testing.T) {
+	d, err := AnalyzeTranscriptDecision(filepath.Join(t.TempDir(), "missing.jsonl"), &config.Config{})
+
+	if err == nil {
+		t.Fatal("expected error for missing transcript")
+	}
+	if d.Status != StatusUnknown {
+		t.Errorf("Status = %v, want StatusUnknown", d.Status)
+	}
+}
+
 func TestContains(t *testing.T) {
 	slice := []string{"apple", "banana", "cherry"}
 
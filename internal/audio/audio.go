@@ -28,15 +28,29 @@ type DeviceInfo struct {
 	IsDefault bool
 }
 
+// maxCachedSounds bounds the decoded sounds a Player keeps (notification sounds are a
+// few hundred KB of PCM each)
+const maxCachedSounds = 16
+
 // Player plays audio on a specific device
 type Player struct {
 	ctx        *malgo.AllocatedContext
 	deviceID   *malgo.DeviceID // Stored copy of device ID (nil = default device)
 	deviceName string
 	volume     float64
+	cache      map[string]*decodedSound // Decoded files by path, for long-lived players (daemon)
 	mu         sync.Mutex
 }
 
+// decodedSound is a decoded sound file at full volume, valid while the file is unchanged
+type decodedSound struct {
+	modTime    time.Time
+	size       int64
+	samples    []int16
+	sampleRate uint32
+	channels   int
+}
+
 // ListDevices returns all available audio output devices
 func ListDevices() ([]DeviceInfo, error) {
 	ctx, err := malgo.InitContext(nil, malgo.ContextConfig{}, nil)
@@ -109,8 +123,14 @@ func NewPlayer(deviceName string, volume float64) (*Player, error) {
 	return player, nil
 }
 
-// Play plays an audio file
+// Play plays an audio file at the player's volume
 func (p *Player) Play(soundPath string) error {
+	return p.PlayWithVolume(soundPath, p.volume)
+}
+
+// PlayWithVolume plays an audio file at volume (0.0-1.0) and returns when it has finished.
+// Sounds of one player play one after another.
+func (p *Player) PlayWithVolume(soundPath string, volume float64) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -120,21 +140,27 @@ func (p *Player) Play(soundPath string) error {
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(soundPath); os.IsNotExist(err) {
+	info, err := os.Stat(soundPath)
+	if os.IsNotExist(err) {
 		return fmt.Errorf("sound file not found: %s", soundPath)
+	} else if err != nil {
+		return err
 	}
 
-	// Decode audio file
-	samples, sampleRate, channels, err := p.decodeAudio(soundPath)
+	// Decode audio file (or reuse the samples decoded for an earlier play)
+	sound, err := p.decoded(soundPath, info)
 	if err != nil {
-		return fmt.Errorf("failed to decode audio: %w", err)
+		return err
 	}
+	samples, sampleRate, channels := sound.samples, sound.sampleRate, sound.channels
 
-	// Apply volume
-	if p.volume < 1.0 {
+	// Apply volume to a copy, the cached samples stay at full volume
+	if volume < 1.0 {
+		scaled := make([]int16, len(samples))
 		for i := range samples {
-			samples[i] = int16(float64(samples[i]) * p.volume)
+			scaled[i] = int16(float64(samples[i]) * volume)
 		}
+		samples = scaled
 	}
 
 	// Convert to bytes
@@ -212,6 +238,39 @@ func (p *Player) Play(soundPath string) error {
 	return nil
 }
 
+// decoded returns the samples of soundPath, from the cache while the file is unchanged.
+// Must be called with p.mu held.
+func (p *Player) decoded(soundPath string, info os.FileInfo) (*decodedSound, error) {
+	if sound, ok := p.cache[soundPath]; ok && sound.modTime.Equal(info.ModTime()) && sound.size == info.Size() {
+		return sound, nil
+	}
+
+	samples, sampleRate, channels, err := p.decodeAudio(soundPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audio: %w", err)
+	}
+	sound := &decodedSound{
+		modTime:    info.ModTime(),
+		size:       info.Size(),
+		samples:    samples,
+		sampleRate: sampleRate,
+		channels:   channels,
+	}
+
+	if p.cache == nil {
+		p.cache = make(map[string]*decodedSound)
+	}
+	if len(p.cache) >= maxCachedSounds {
+		// Drop any entry; the configured sounds are far fewer than the limit
+		for path := range p.cache {
+			delete(p.cache, path)
+			break
+		}
+	}
+	p.cache[soundPath] = sound
+	return sound, nil
+}
+
 // Close releases resources
 func (p *Player) Close() error {
 	p.mu.Lock()
@@ -222,9 +281,24 @@ func (p *Player) Close() error {
 		p.ctx.Free()
 		p.ctx = nil
 	}
+	p.cache = nil
 	return nil
 }
 
+// CheckFile decodes a sound file the way Play does and returns its duration, so a missing,
+// broken or unsupported file can be reported without playing it
+func CheckFile(soundPath string) (time.Duration, error) {
+	samples, sampleRate, channels, err := (&Player{}).decodeAudio(soundPath)
+	if err != nil {
+		return 0, err
+	}
+	if sampleRate == 0 || channels == 0 {
+		return 0, fmt.Errorf("no audio data in %s", soundPath)
+	}
+	frames := len(samples) / channels
+	return time.Duration(frames) * time.Second / time.Duration(sampleRate), nil
+}
+
 // decodeAudio decodes an audio file and returns samples, sample rate, and channel count
 func (p *Player) decodeAudio(soundPath string) ([]int16, uint32, int, error) {
 	f, err := os.Open(soundPath)
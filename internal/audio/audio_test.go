@@ -4,8 +4,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-audio/aiff"
 	"github.com/go-audio/audio"
 )
 
@@ -441,3 +444,63 @@ func findSoundsDirectory() string {
 
 	return ""
 }
+
+func TestCheckFile_Errors(t *testing.T) {
+	if _, err := CheckFile("/nonexistent/path/to/audio.mp3"); err == nil {
+		t.Error("CheckFile() expected error for non-existent file, got nil")
+	}
+
+	tmpFile, err := os.CreateTemp("", "test*.xyz")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	if _, err := CheckFile(tmpFile.Name()); err == nil || !strings.Contains(err.Error(), "unsupported audio format") {
+		t.Errorf("CheckFile() error = %v, want unsupported audio format", err)
+	}
+}
+
+func TestPlayer_DecodedCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sound.aiff")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := aiff.NewEncoder(f, 8000, 16, 1)
+	buf := &audio.IntBuffer{Format: &audio.Format{NumChannels: 1, SampleRate: 8000}, Data: []int{0, 1000, -1000, 0}, SourceBitDepth: 16}
+	if err := enc.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	p := &Player{}
+	info, _ := os.Stat(path)
+	first, err := p.decoded(path, info)
+	if err != nil {
+		t.Fatalf("decoded() error: %v", err)
+	}
+	if len(first.samples) != 4 || first.samples[1] != 1000 {
+		t.Errorf("samples = %v", first.samples)
+	}
+	if again, _ := p.decoded(path, info); again != first {
+		t.Error("unchanged file should come from the cache")
+	}
+
+	// A changed file is decoded again
+	later := info.ModTime().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	info, _ = os.Stat(path)
+	if changed, _ := p.decoded(path, info); changed == first {
+		t.Error("changed file should be decoded again")
+	}
+	if len(p.cache) != 1 {
+		t.Errorf("cache has %d entries, want 1", len(p.cache))
+	}
+}
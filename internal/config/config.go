@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/777genius/claude-notifications/internal/logging"
 	"github.com/777genius/claude-notifications/internal/platform"
@@ -14,19 +17,157 @@ import (
 type Config struct {
 	Notifications NotificationsConfig   `json:"notifications"`
 	Statuses      map[string]StatusInfo `json:"statuses"`
+
+	env     []EnvOverride     // Applied CLAUDE_NOTIFICATIONS_* variables
+	sources map[string]string // JSON path -> where the value came from (see Source)
 }
 
 // NotificationsConfig represents notification settings
 type NotificationsConfig struct {
 	Desktop                                     DesktopConfig    `json:"desktop"`
 	Webhook                                     WebhookConfig    `json:"webhook"`
+	Webhooks                                    WebhookTargets   `json:"webhooks,omitempty"` // More webhook targets, each with a name and its own settings
+	Exec                                        ExecConfig       `json:"exec"`
 	SuppressQuestionAfterTaskCompleteSeconds    *int             `json:"suppressQuestionAfterTaskCompleteSeconds"`
 	SuppressQuestionAfterAnyNotificationSeconds *int             `json:"suppressQuestionAfterAnyNotificationSeconds"`
-	NotifyOnSubagentStop                        bool             `json:"notifyOnSubagentStop"`      // Send notifications when subagents (Task tool) complete, default: false
-	SuppressForSubagents                        *bool            `json:"suppressForSubagents"`      // Suppress notifications when transcript_path contains /subagents/, default: true
-	NotifyOnTextResponse                        *bool            `json:"notifyOnTextResponse"`      // Send notifications for text-only responses (no tools), default: true
-	RespectJudgeMode                            *bool            `json:"respectJudgeMode"`          // Honor CLAUDE_HOOK_JUDGE_MODE=true env var to suppress notifications, default: true
-	SuppressFilters                             []SuppressFilter `json:"suppressFilters,omitempty"` // Rules for suppressing notifications by status/branch/folder
+	NotifyOnSubagentStop                        bool             `json:"notifyOnSubagentStop"`          // Send notifications when subagents (Task tool) complete, default: false
+	NotifyOnPreCompact                          bool             `json:"notifyOnPreCompact"`            // Send notifications when Claude Code compacts the context (PreCompact hook), default: false
+	SuppressForSubagents                        *bool            `json:"suppressForSubagents"`          // Suppress notifications when transcript_path contains /subagents/, default: true
+	NotifyOnTextResponse                        *bool            `json:"notifyOnTextResponse"`          // Send notifications for text-only responses (no tools), default: true
+	RespectJudgeMode                            *bool            `json:"respectJudgeMode"`              // Honor CLAUDE_HOOK_JUDGE_MODE=true env var to suppress notifications, default: true
+	SuppressFilters                             []SuppressFilter `json:"suppressFilters,omitempty"`     // Rules for suppressing notifications by status/branch/folder
+	MinTurnDurationSeconds                      *int             `json:"minTurnDurationSeconds"`        // Suppress notifications for turns shorter than N seconds, default: 0 (disabled)
+	MinTurnDurationExemptStatuses               []string         `json:"minTurnDurationExemptStatuses"` // Statuses never gated by minTurnDurationSeconds, default: questions, prompts and errors
+	Reminders                                   RemindersConfig  `json:"reminders"`                     // Re-notify when a prompt stays unanswered (Linux daemon)
+	Aggregate                                   AggregateConfig  `json:"aggregate"`                     // Merge notifications arriving close together (Linux daemon)
+	Journal                                     JournalConfig    `json:"journal"`                       // Record raw hook payloads for `claude-notifications replay`
+	History                                     HistoryConfig    `json:"history"`                       // Persistent JSONL log of sent/suppressed notifications
+	QuietHours                                  QuietHoursConfig `json:"quietHours"`                    // Scheduled do-not-disturb windows
+	Routes                                      []RouteRule      `json:"routes,omitempty"`              // Ordered rules choosing channels per notification
+	Templates                                   TemplatesConfig  `json:"templates"`                     // Message layout per channel (text/template)
+	Daemon                                      DaemonConfig     `json:"daemon"`                        // Linux notification daemon settings
+}
+
+// Quiet hours modes
+const (
+	QuietModeMute     = "mute"     // Deliver without sound, bell or time-sensitive flag
+	QuietModeSuppress = "suppress" // Drop the notification
+)
+
+// QuietHoursConfig represents the do-not-disturb schedule
+type QuietHoursConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Timezone     string        `json:"timezone"`     // IANA name (e.g. "Europe/Berlin"), default: local time
+	Windows      []QuietWindow `json:"windows"`      // Quiet periods; quiet if any window matches
+	Mode         string        `json:"mode"`         // "mute" (default) or "suppress"
+	BreakThrough []string      `json:"breakThrough"` // Statuses delivered normally during quiet hours (e.g. "api_error")
+}
+
+// QuietWindow is one recurring quiet period
+type QuietWindow struct {
+	Days  []string `json:"days"`  // Days the window starts on: mon, tue, wed, thu, fri, sat, sun. Default: every day
+	Start string   `json:"start"` // "22:00"
+	End   string   `json:"end"`   // "07:00"; an end at or before start means the window crosses midnight
+}
+
+// weekdays maps quiet hours day names to weekdays
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// HasDay returns true if the window starts on day (windows without days start every day)
+func (w QuietWindow) HasDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if d, ok := weekdays[strings.ToLower(name)]; ok && d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// EndAfter returns the end of the occurrence of the window that contains now, in now's
+// location. ok is false if now is outside the window or start/end are invalid.
+func (w QuietWindow) EndAfter(now time.Time) (end time.Time, ok bool) {
+	startHour, startMinute, err := ParseClock(w.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	endHour, endMinute, err := ParseClock(w.End)
+	if err != nil {
+		return time.Time{}, false
+	}
+	wraps := endHour*60+endMinute <= startHour*60+startMinute
+	loc := now.Location()
+
+	// A window that crosses midnight may have started yesterday
+	for _, offset := range []int{0, -1} {
+		day := time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, loc)
+		if !w.HasDay(day.Weekday()) {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, loc)
+		endDay := day.Day()
+		if wraps {
+			endDay++
+		}
+		end := time.Date(day.Year(), day.Month(), endDay, endHour, endMinute, 0, 0, loc)
+		if !now.Before(start) && now.Before(end) {
+			return end, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ParseClock parses a "15:04" time of day
+func ParseClock(value string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q (expected HH:MM)", value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// HistoryConfig represents settings for the notification history file
+type HistoryConfig struct {
+	Enabled   *bool  `json:"enabled"`   // default: true
+	Path      string `json:"path"`      // default: $XDG_STATE_HOME/claude-notifications/history.jsonl
+	MaxSizeMB int    `json:"maxSizeMB"` // Rotate to history.jsonl.1 beyond this size, default: 10
+}
+
+// JournalConfig represents settings for the hook journal
+type JournalConfig struct {
+	Enabled    bool   `json:"enabled"`    // default: false
+	Dir        string `json:"dir"`        // Journal directory, default: ~/.claude/claude-notifications-go/journal
+	MaxEntries int    `json:"maxEntries"` // Oldest entries are removed beyond this count, default: 200
+}
+
+// DaemonConfig represents settings for the Linux notification daemon
+type DaemonConfig struct {
+	StateInMemory bool `json:"stateInMemory"` // Keep dedup and cooldown state in the daemon instead of temp files, default: false
+}
+
+// RemindersConfig represents settings for re-notifying unanswered prompts
+type RemindersConfig struct {
+	Enabled                bool     `json:"enabled"`                // default: false
+	Statuses               []string `json:"statuses,omitempty"`     // Statuses that get reminders, default: question, plan_ready, permission_request
+	Backoff                []string `json:"backoff,omitempty"`      // Delay before each reminder, e.g. ["5m", "15m", "60m"]
+	EscalateToWebhookAfter int      `json:"escalateToWebhookAfter"` // From this reminder on (1-based) also send via webhook, 0 = never
+}
+
+// AggregateConfig represents settings for merging notifications that arrive close together
+type AggregateConfig struct {
+	Enabled  bool     `json:"enabled"`            // default: false
+	Window   string   `json:"window"`             // Collect notifications for this long after the first one, default: "10s"
+	Statuses []string `json:"statuses,omitempty"` // Statuses that wait for the window (empty = all)
 }
 
 // DesktopConfig represents desktop notification settings
@@ -41,17 +182,30 @@ type DesktopConfig struct {
 	TerminalBundleID string  `json:"terminalBundleId"` // macOS: override auto-detected terminal bundle ID (empty = auto)
 }
 
-// WebhookConfig represents webhook settings
+// WebhookConfig represents webhook settings, of notifications.webhook or one of notifications.webhooks
 type WebhookConfig struct {
+	Name           string               `json:"name,omitempty"` // Target name for routes; notifications.webhook is always "default"
 	Enabled        bool                 `json:"enabled"`
 	Preset         string               `json:"preset"`
 	URL            string               `json:"url"`
 	ChatID         string               `json:"chat_id"`
+	RoomID         string               `json:"room_id,omitempty"` // Matrix room ID, e.g. "!abc123:matrix.org"
+	Token          string               `json:"token,omitempty"`   // Gotify application token, ntfy or Matrix access token
 	Format         string               `json:"format"`
 	Headers        map[string]string    `json:"headers"`
 	Retry          RetryConfig          `json:"retry"`
 	CircuitBreaker CircuitBreakerConfig `json:"circuitBreaker"`
 	RateLimit      RateLimitConfig      `json:"rateLimit"`
+	Statuses       []string             `json:"statuses,omitempty"` // Statuses sent to this target (empty = all enabled statuses)
+}
+
+// ExecConfig represents settings for the exec channel, which runs a user command per notification
+type ExecConfig struct {
+	Enabled       bool     `json:"enabled"`
+	Command       []string `json:"command"`            // Program and arguments (no shell), e.g. ["/usr/local/bin/blink", "--color"]
+	Timeout       string   `json:"timeout"`            // Kill the command after this long, default: "10s"
+	MaxConcurrent int      `json:"maxConcurrent"`      // Commands running at once across all hook processes, default: 2
+	Statuses      []string `json:"statuses,omitempty"` // Statuses sent to the command (empty = all enabled statuses)
 }
 
 // RetryConfig represents retry settings
@@ -78,38 +232,126 @@ type RateLimitConfig struct {
 
 // StatusInfo represents configuration for a specific status
 type StatusInfo struct {
-	Enabled *bool  `json:"enabled,omitempty"` // nil = true (default for backward compatibility)
-	Title   string `json:"title"`
-	Sound   string `json:"sound"`
+	Enabled                *bool            `json:"enabled,omitempty"` // nil = true (default for backward compatibility)
+	Title                  string           `json:"title"`
+	Sound                  string           `json:"sound"`
+	MinTurnDurationSeconds *int             `json:"minTurnDurationSeconds,omitempty"` // Overrides notifications.minTurnDurationSeconds for this status (nil = use global)
+	Templates              *TemplatesConfig `json:"templates,omitempty"`              // Overrides notifications.templates for this status
+}
+
+// validStatuses lists the status names accepted in config rules
+var validStatuses = map[string]bool{
+	"task_complete":         true,
+	"review_complete":       true,
+	"question":              true,
+	"plan_ready":            true,
+	"session_limit_reached": true,
+	"api_error":             true,
+	"api_error_overloaded":  true,
+	"context_compacting":    true,
+	"permission_request":    true,
+	"idle_prompt":           true,
+}
+
+// defaultReminderStatuses are the statuses that wait on the user and get reminders by default
+var defaultReminderStatuses = []string{"question", "plan_ready", "permission_request"}
+
+// defaultReminderBackoff is the delay before each reminder, measured from the previous notification
+var defaultReminderBackoff = []string{"5m", "15m", "60m"}
+
+// defaultAggregateWindow applies when aggregate.window is unset; maxAggregateWindow keeps
+// notifications from waiting longer than a user would
+const (
+	defaultAggregateWindow = 10 * time.Second
+	maxAggregateWindow     = time.Minute
+)
+
+// defaultExecTimeout and defaultExecMaxConcurrent apply when the exec channel leaves them unset
+const (
+	defaultExecTimeout       = 10 * time.Second
+	defaultExecMaxConcurrent = 2
+)
+
+// defaultHistoryMaxSizeMB is the history file size that triggers rotation
+const defaultHistoryMaxSizeMB = 10
+
+// defaultJournalMaxEntries is the number of hook payloads kept in the journal
+const defaultJournalMaxEntries = 200
+
+// defaultMinTurnDurationExemptStatuses are statuses that need attention no matter how short the turn was
+var defaultMinTurnDurationExemptStatuses = []string{
+	"question",
+	"plan_ready",
+	"permission_request",
+	"idle_prompt",
+	"session_limit_reached",
+	"api_error",
+	"api_error_overloaded",
 }
 
 // SuppressFilter defines conditions for suppressing notifications.
 // All specified (non-nil) fields must match for the filter to suppress.
-// Omitted fields act as wildcards (match any value).
+// Omitted fields act as wildcards (match any value). String fields are patterns
+// (see MatchPattern): exact values, globs like "dependabot/*", "re:" regexes, "!" to negate.
 type SuppressFilter struct {
-	Name      string  `json:"name,omitempty"`
-	Status    *string `json:"status,omitempty"`
-	GitBranch *string `json:"gitBranch"` // no omitempty — nil means "any", "" means "no branch"
-	Folder    *string `json:"folder,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	Status    *string      `json:"status,omitempty"`
+	GitBranch *string      `json:"gitBranch"` // no omitempty — nil means "any", "" means "no branch"
+	Folder    *string      `json:"folder,omitempty"`
+	CWD       *string      `json:"cwd,omitempty"`       // Full working directory path, e.g. "/home/me/tmp/*"
+	Message   *string      `json:"message,omitempty"`   // Generated message text (before the [session|branch folder] prefix)
+	TimeOfDay *QuietWindow `json:"timeOfDay,omitempty"` // Only between start and end (on days), in quietHours.timezone
 }
 
-// Matches returns true if all specified fields match the given values.
-func (f *SuppressFilter) Matches(status, gitBranch, folder string) bool {
-	if f.Status != nil && *f.Status != status {
-		return false
-	}
-	if f.GitBranch != nil && *f.GitBranch != gitBranch {
-		return false
+// MatchInput is the notification context suppress filters and routes are matched against
+type MatchInput struct {
+	Status      string
+	GitBranch   string
+	Folder      string // Base name of CWD
+	CWD         string
+	Message     string
+	SessionID   string
+	SessionName string    // Friendly session label shown in notifications, e.g. "bold-cat"
+	Subagent    bool      // SubagentStop event or a subagent transcript
+	Time        time.Time // In the location timeOfDay is evaluated in
+}
+
+// patternCondition is one optional pattern field of a filter or route and the value it is matched against
+type patternCondition struct {
+	pattern *string
+	value   string
+}
+
+// matchConditions reports whether every set pattern matches and now is inside window (if set)
+func matchConditions(conditions []patternCondition, window *QuietWindow, now time.Time) bool {
+	for _, c := range conditions {
+		if c.pattern != nil && !MatchPattern(*c.pattern, c.value) {
+			return false
+		}
 	}
-	if f.Folder != nil && *f.Folder != folder {
-		return false
+	if window != nil {
+		if _, ok := window.EndAfter(now); !ok {
+			return false
+		}
 	}
 	return true
 }
 
+// Matches returns true if all specified fields match the given values.
+func (f *SuppressFilter) Matches(in MatchInput) bool {
+	return matchConditions([]patternCondition{
+		{f.Status, in.Status},
+		{f.GitBranch, in.GitBranch},
+		{f.Folder, in.Folder},
+		{f.CWD, in.CWD},
+		{f.Message, in.Message},
+	}, f.TimeOfDay, in.Time)
+}
+
 // HasConditions returns true if the filter has at least one condition field set.
 func (f *SuppressFilter) HasConditions() bool {
-	return f.Status != nil || f.GitBranch != nil || f.Folder != nil
+	return f.Status != nil || f.GitBranch != nil || f.Folder != nil ||
+		f.CWD != nil || f.Message != nil || f.TimeOfDay != nil
 }
 
 // intPtr returns a pointer to the given int value
@@ -122,6 +364,35 @@ func stringPtr(v string) *string {
 	return &v
 }
 
+// defaultWebhookConfig returns the defaults of notifications.webhook; targets in
+// notifications.webhooks start from the same values, but enabled
+func defaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Enabled: false,
+		Preset:  "custom",
+		URL:     "",
+		ChatID:  "",
+		Format:  "json",
+		Headers: make(map[string]string),
+		Retry: RetryConfig{
+			Enabled:        true,
+			MaxAttempts:    3,
+			InitialBackoff: "1s",
+			MaxBackoff:     "10s",
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 5,
+			Timeout:          "30s",
+			SuccessThreshold: 2,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           true,
+			RequestsPerMinute: 10,
+		},
+	}
+}
+
 // DefaultConfig returns a config with sensible defaults
 func DefaultConfig() *Config {
 	// Get plugin root from environment, fallback to current directory
@@ -140,31 +411,8 @@ func DefaultConfig() *Config {
 				ClickToFocus: true, // macOS: activate terminal on click (default: enabled)
 				// TerminalBundleID: "" - empty means auto-detect
 			},
-			Webhook: WebhookConfig{
-				Enabled: false,
-				Preset:  "custom",
-				URL:     "",
-				ChatID:  "",
-				Format:  "json",
-				Headers: make(map[string]string),
-				Retry: RetryConfig{
-					Enabled:        true,
-					MaxAttempts:    3,
-					InitialBackoff: "1s",
-					MaxBackoff:     "10s",
-				},
-				CircuitBreaker: CircuitBreakerConfig{
-					Enabled:          true,
-					FailureThreshold: 5,
-					Timeout:          "30s",
-					SuccessThreshold: 2,
-				},
-				RateLimit: RateLimitConfig{
-					Enabled:           true,
-					RequestsPerMinute: 10,
-				},
-			},
-			SuppressQuestionAfterTaskCompleteSeconds:    intPtr(12),
+			Webhook:                                  defaultWebhookConfig(),
+			SuppressQuestionAfterTaskCompleteSeconds: intPtr(12),
 			SuppressQuestionAfterAnyNotificationSeconds: intPtr(0),
 		},
 		Statuses: map[string]StatusInfo{
@@ -196,6 +444,18 @@ func DefaultConfig() *Config {
 				Title: "🔴 API Error",
 				Sound: filepath.Join(pluginRoot, "sounds", "error.mp3"),
 			},
+			"context_compacting": {
+				Title: "🗜️ Compacting Context",
+				Sound: filepath.Join(pluginRoot, "sounds", "review-complete.mp3"),
+			},
+			"permission_request": {
+				Title: "🔐 Permission Required",
+				Sound: filepath.Join(pluginRoot, "sounds", "question.mp3"),
+			},
+			"idle_prompt": {
+				Title: "💤 Waiting for Input",
+				Sound: filepath.Join(pluginRoot, "sounds", "review-complete.mp3"),
+			},
 		},
 	}
 }
@@ -217,16 +477,10 @@ func Load(path string) (*Config, error) {
 	if err := json.Unmarshal(data, config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	config.recordSources(data, "file: "+path)
 
 	// Expand environment variables in paths
-	config.Notifications.Desktop.AppIcon = platform.ExpandEnv(config.Notifications.Desktop.AppIcon)
-	config.Notifications.Webhook.URL = platform.ExpandEnv(config.Notifications.Webhook.URL)
-
-	// Expand environment variables in sound paths
-	for status, info := range config.Statuses {
-		info.Sound = platform.ExpandEnv(info.Sound)
-		config.Statuses[status] = info
-	}
+	config.expandEnv()
 
 	// Apply defaults for missing fields
 	config.ApplyDefaults()
@@ -234,6 +488,28 @@ func Load(path string) (*Config, error) {
 	return config, nil
 }
 
+// expandEnv expands environment variables in paths, URLs and commands
+func (c *Config) expandEnv() {
+	c.Notifications.Desktop.AppIcon = platform.ExpandEnv(c.Notifications.Desktop.AppIcon)
+	c.Notifications.Webhook.URL = platform.ExpandEnv(c.Notifications.Webhook.URL)
+	c.Notifications.Webhook.Token = platform.ExpandEnv(c.Notifications.Webhook.Token)
+	for i := range c.Notifications.Webhooks {
+		c.Notifications.Webhooks[i].URL = platform.ExpandEnv(c.Notifications.Webhooks[i].URL)
+		c.Notifications.Webhooks[i].Token = platform.ExpandEnv(c.Notifications.Webhooks[i].Token)
+	}
+	c.Notifications.Journal.Dir = platform.ExpandEnv(c.Notifications.Journal.Dir)
+	c.Notifications.History.Path = platform.ExpandEnv(c.Notifications.History.Path)
+	for i, arg := range c.Notifications.Exec.Command {
+		c.Notifications.Exec.Command[i] = platform.ExpandEnv(arg)
+	}
+
+	// Expand environment variables in sound paths
+	for status, info := range c.Statuses {
+		info.Sound = platform.ExpandEnv(info.Sound)
+		c.Statuses[status] = info
+	}
+}
+
 // GetStableConfigDir returns the stable config directory outside the plugin cache.
 // This directory survives plugin updates (bootstrap.sh rm -rf of cache).
 func GetStableConfigDir() (string, error) {
@@ -253,14 +529,40 @@ func GetStableConfigPath() (string, error) {
 	return filepath.Join(dir, "config.json"), nil
 }
 
+// ActiveConfigPath returns the config file LoadFromPluginRoot reads: the stable path if it
+// exists, else pluginRoot/config/config.json (which may not exist either)
+func ActiveConfigPath(pluginRoot string) string {
+	if stablePath, err := GetStableConfigPath(); err == nil && platform.FileExists(stablePath) {
+		return stablePath
+	}
+	return filepath.Join(pluginRoot, "config", "config.json")
+}
+
 // LoadFromPluginRoot loads configuration with a resilient fallback chain:
 // 1. Stable path (~/.claude/claude-notifications-go/config.json) — preferred
 // 2. Old path (pluginRoot/config/config.json) — fallback, auto-migrates to stable
 // 3. Default config — if neither path has valid config
 //
 // Corrupted config files are non-fatal: a warning is printed to stderr and
-// logged, then the next source in the chain is tried.
+// logged, then the next source in the chain is tried. CLAUDE_NOTIFICATIONS_*
+// environment variables are applied last (see ApplyEnv); callers validate the result.
 func LoadFromPluginRoot(pluginRoot string) (*Config, error) {
+	cfg, err := loadConfigFile(pluginRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// CLAUDE_NOTIFICATIONS_* variables override the file (and the defaults it left in place)
+	for _, envErr := range cfg.ApplyEnv(os.Environ()) {
+		msg := fmt.Sprintf("warning: ignoring environment override %v", envErr)
+		fmt.Fprintln(os.Stderr, msg)
+		logging.Warn("%s", msg)
+	}
+	return cfg, nil
+}
+
+// loadConfigFile loads the stable config, else the legacy one in pluginRoot, else defaults
+func loadConfigFile(pluginRoot string) (*Config, error) {
 	// 1. Try stable path
 	stablePath, stableErr := GetStableConfigPath()
 	if stableErr != nil {
@@ -357,14 +659,9 @@ func (c *Config) ApplyDefaults() {
 	// AppIcon: Keep empty if not set (no default)
 
 	// Webhook defaults
-	if c.Notifications.Webhook.Preset == "" {
-		c.Notifications.Webhook.Preset = "custom"
-	}
-	if c.Notifications.Webhook.Format == "" {
-		c.Notifications.Webhook.Format = "json"
-	}
-	if c.Notifications.Webhook.Headers == nil {
-		c.Notifications.Webhook.Headers = make(map[string]string)
+	c.Notifications.Webhook.applyDefaults()
+	for i := range c.Notifications.Webhooks {
+		c.Notifications.Webhooks[i].applyDefaults()
 	}
 
 	// Cooldown defaults (nil = not set in config, apply defaults)
@@ -389,72 +686,208 @@ func (c *Config) ApplyDefaults() {
 	}
 }
 
-// Validate validates the configuration
+// FieldError is a validation problem with the JSON path of the offending field
+type FieldError struct {
+	Path    string // e.g. "notifications.exec.timeout"
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// Validate validates the configuration and returns the first problem found
 func (c *Config) Validate() error {
+	if errs := c.ValidateAll(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateAll validates the configuration and returns every problem found
+func (c *Config) ValidateAll() []FieldError {
+	var errs []FieldError
+	add := func(path, format string, args ...interface{}) {
+		errs = append(errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
 	// Validate volume
 	if c.Notifications.Desktop.Volume < 0.0 || c.Notifications.Desktop.Volume > 1.0 {
-		return fmt.Errorf("desktop volume must be between 0.0 and 1.0 (got %.2f)", c.Notifications.Desktop.Volume)
+		add("notifications.desktop.volume", "desktop volume must be between 0.0 and 1.0 (got %.2f)", c.Notifications.Desktop.Volume)
+	}
+
+	// Validate webhooks (only if enabled)
+	c.validateWebhooks(add)
+
+	// Validate exec channel (only if enabled)
+	if c.Notifications.Exec.Enabled {
+		if len(c.Notifications.Exec.Command) == 0 || c.Notifications.Exec.Command[0] == "" {
+			add("notifications.exec.command", "exec.command is required when the exec channel is enabled")
+		}
+		if c.Notifications.Exec.Timeout != "" {
+			d, err := time.ParseDuration(c.Notifications.Exec.Timeout)
+			if err != nil {
+				add("notifications.exec.timeout", "exec.timeout: invalid duration %q", c.Notifications.Exec.Timeout)
+			} else if d <= 0 {
+				add("notifications.exec.timeout", "exec.timeout: duration must be positive (got %q)", c.Notifications.Exec.Timeout)
+			}
+		}
+		if c.Notifications.Exec.MaxConcurrent < 0 {
+			add("notifications.exec.maxConcurrent", "exec.maxConcurrent must be >= 0")
+		}
+		for i, s := range c.Notifications.Exec.Statuses {
+			if !validStatuses[s] {
+				add(fmt.Sprintf("notifications.exec.statuses[%d]", i), "exec.statuses[%d]: invalid status %q", i, s)
+			}
+		}
 	}
 
-	// Validate webhook preset (only if webhooks are enabled)
-	validPresets := map[string]bool{
-		"slack":    true,
-		"discord":  true,
-		"telegram": true,
-		"lark":     true,
-		"custom":   true,
+	// Validate cooldowns (both fields, if explicitly set)
+	if c.Notifications.SuppressQuestionAfterTaskCompleteSeconds != nil && *c.Notifications.SuppressQuestionAfterTaskCompleteSeconds < 0 {
+		add("notifications.suppressQuestionAfterTaskCompleteSeconds", "suppressQuestionAfterTaskCompleteSeconds must be >= 0")
 	}
-	if c.Notifications.Webhook.Enabled && !validPresets[c.Notifications.Webhook.Preset] {
-		return fmt.Errorf("invalid webhook preset: %s (must be one of: slack, discord, telegram, lark, custom)", c.Notifications.Webhook.Preset)
+	if c.Notifications.SuppressQuestionAfterAnyNotificationSeconds != nil && *c.Notifications.SuppressQuestionAfterAnyNotificationSeconds < 0 {
+		add("notifications.suppressQuestionAfterAnyNotificationSeconds", "suppressQuestionAfterAnyNotificationSeconds must be >= 0")
 	}
 
-	// Validate webhook format (only if webhooks are enabled)
-	validFormats := map[string]bool{
-		"json": true,
-		"text": true,
+	// Validate minimum turn duration (global, per-status and exemptions)
+	if c.Notifications.MinTurnDurationSeconds != nil && *c.Notifications.MinTurnDurationSeconds < 0 {
+		add("notifications.minTurnDurationSeconds", "minTurnDurationSeconds must be >= 0")
+	}
+	statusNames := make([]string, 0, len(c.Statuses))
+	for name := range c.Statuses {
+		statusNames = append(statusNames, name)
+	}
+	sort.Strings(statusNames)
+	for _, name := range statusNames {
+		if info := c.Statuses[name]; info.MinTurnDurationSeconds != nil && *info.MinTurnDurationSeconds < 0 {
+			add("statuses."+name+".minTurnDurationSeconds", "statuses.%s.minTurnDurationSeconds must be >= 0", name)
+		}
+	}
+	for i, s := range c.Notifications.MinTurnDurationExemptStatuses {
+		if !validStatuses[s] {
+			add(fmt.Sprintf("notifications.minTurnDurationExemptStatuses[%d]", i), "minTurnDurationExemptStatuses[%d]: invalid status %q", i, s)
+		}
+	}
+
+	// Validate reminders
+	if c.Notifications.Reminders.Enabled {
+		for i, s := range c.Notifications.Reminders.Statuses {
+			if !validStatuses[s] {
+				add(fmt.Sprintf("notifications.reminders.statuses[%d]", i), "reminders.statuses[%d]: invalid status %q", i, s)
+			}
+		}
+		for i, b := range c.Notifications.Reminders.Backoff {
+			d, err := time.ParseDuration(b)
+			if err != nil {
+				add(fmt.Sprintf("notifications.reminders.backoff[%d]", i), "reminders.backoff[%d]: invalid duration %q", i, b)
+			} else if d <= 0 {
+				add(fmt.Sprintf("notifications.reminders.backoff[%d]", i), "reminders.backoff[%d]: duration must be positive (got %q)", i, b)
+			}
+		}
+		if c.Notifications.Reminders.EscalateToWebhookAfter < 0 {
+			add("notifications.reminders.escalateToWebhookAfter", "reminders.escalateToWebhookAfter must be >= 0")
+		}
 	}
-	if c.Notifications.Webhook.Enabled && !validFormats[c.Notifications.Webhook.Format] {
-		return fmt.Errorf("invalid webhook format: %s (must be one of: json, text)", c.Notifications.Webhook.Format)
+
+	// Validate aggregation
+	if c.Notifications.Aggregate.Enabled {
+		if c.Notifications.Aggregate.Window != "" {
+			d, err := time.ParseDuration(c.Notifications.Aggregate.Window)
+			if err != nil {
+				add("notifications.aggregate.window", "aggregate.window: invalid duration %q", c.Notifications.Aggregate.Window)
+			} else if d <= 0 || d > maxAggregateWindow {
+				add("notifications.aggregate.window", "aggregate.window must be between 0s and %v (got %q)", maxAggregateWindow, c.Notifications.Aggregate.Window)
+			}
+		}
+		for i, s := range c.Notifications.Aggregate.Statuses {
+			if !validStatuses[s] {
+				add(fmt.Sprintf("notifications.aggregate.statuses[%d]", i), "aggregate.statuses[%d]: invalid status %q", i, s)
+			}
+		}
 	}
 
-	// Validate webhook URL if enabled
-	if c.Notifications.Webhook.Enabled && c.Notifications.Webhook.URL == "" {
-		return fmt.Errorf("webhook URL is required when webhooks are enabled")
+	// Validate journal
+	if c.Notifications.Journal.MaxEntries < 0 {
+		add("notifications.journal.maxEntries", "journal.maxEntries must be >= 0")
 	}
 
-	// Validate Telegram chat_id if Telegram preset is used
-	if c.Notifications.Webhook.Enabled && c.Notifications.Webhook.Preset == "telegram" && c.Notifications.Webhook.ChatID == "" {
-		return fmt.Errorf("chat_id is required for Telegram webhook")
+	// Validate history
+	if c.Notifications.History.MaxSizeMB < 0 {
+		add("notifications.history.maxSizeMB", "history.maxSizeMB must be >= 0")
 	}
 
-	// Validate cooldowns (both fields, if explicitly set)
-	if c.Notifications.SuppressQuestionAfterTaskCompleteSeconds != nil && *c.Notifications.SuppressQuestionAfterTaskCompleteSeconds < 0 {
-		return fmt.Errorf("suppressQuestionAfterTaskCompleteSeconds must be >= 0")
+	// Validate quiet hours (breakThrough and mode also apply to a manual `dnd`)
+	quiet := c.Notifications.QuietHours
+	if quiet.Timezone != "" {
+		if _, err := time.LoadLocation(quiet.Timezone); err != nil {
+			add("notifications.quietHours.timezone", "quietHours.timezone: unknown timezone %q", quiet.Timezone)
+		}
 	}
-	if c.Notifications.SuppressQuestionAfterAnyNotificationSeconds != nil && *c.Notifications.SuppressQuestionAfterAnyNotificationSeconds < 0 {
-		return fmt.Errorf("suppressQuestionAfterAnyNotificationSeconds must be >= 0")
+	if quiet.Mode != "" && quiet.Mode != QuietModeMute && quiet.Mode != QuietModeSuppress {
+		add("notifications.quietHours.mode", "quietHours.mode: invalid mode %q (must be 'mute' or 'suppress')", quiet.Mode)
+	}
+	for i, w := range quiet.Windows {
+		validateWindow(w, fmt.Sprintf("quietHours.windows[%d]", i), add)
+	}
+	for i, s := range quiet.BreakThrough {
+		if !validStatuses[s] {
+			add(fmt.Sprintf("notifications.quietHours.breakThrough[%d]", i), "quietHours.breakThrough[%d]: invalid status %q", i, s)
+		}
 	}
 
 	// Validate suppress-filters
-	validStatuses := map[string]bool{
-		"task_complete":         true,
-		"review_complete":       true,
-		"question":              true,
-		"plan_ready":            true,
-		"session_limit_reached": true,
-		"api_error":             true,
-		"api_error_overloaded":  true,
-	}
 	for i, f := range c.Notifications.SuppressFilters {
+		path := fmt.Sprintf("notifications.suppressFilters[%d]", i)
 		if !f.HasConditions() {
-			return fmt.Errorf("suppressFilters[%d]: must have at least one condition (status, gitBranch, or folder)", i)
+			add(path, "suppressFilters[%d]: must have at least one condition (status, gitBranch, folder, cwd, message or timeOfDay)", i)
+		}
+		if f.Status != nil && !IsPattern(*f.Status) && !validStatuses[*f.Status] {
+			add(path+".status", "suppressFilters[%d]: invalid status %q", i, *f.Status)
 		}
-		if f.Status != nil && !validStatuses[*f.Status] {
-			return fmt.Errorf("suppressFilters[%d]: invalid status %q", i, *f.Status)
+		validatePatterns(fmt.Sprintf("suppressFilters[%d]", i), map[string]*string{
+			"status": f.Status, "gitBranch": f.GitBranch, "folder": f.Folder, "cwd": f.CWD, "message": f.Message,
+		}, add)
+		if f.TimeOfDay != nil {
+			validateWindow(*f.TimeOfDay, fmt.Sprintf("suppressFilters[%d].timeOfDay", i), add)
 		}
 	}
 
-	return nil
+	c.validateRoutes(add)
+	c.validateTemplates(add)
+
+	return errs
+}
+
+// validatePatterns reports invalid patterns among the set fields of notifications.<field>, in key order
+func validatePatterns(field string, patterns map[string]*string, add func(path, format string, args ...interface{})) {
+	keys := make([]string, 0, len(patterns))
+	for key := range patterns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if p := patterns[key]; p != nil {
+			if err := ValidatePattern(*p); err != nil {
+				add("notifications."+field+"."+key, "%s.%s: %v", field, key, err)
+			}
+		}
+	}
+}
+
+// validateWindow reports invalid start/end times and days of a window at notifications.<field>
+func validateWindow(w QuietWindow, field string, add func(path, format string, args ...interface{})) {
+	if _, _, err := ParseClock(w.Start); err != nil {
+		add("notifications."+field+".start", "%s.start: %v", field, err)
+	}
+	if _, _, err := ParseClock(w.End); err != nil {
+		add("notifications."+field+".end", "%s.end: %v", field, err)
+	}
+	for j, day := range w.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			add(fmt.Sprintf("notifications.%s.days[%d]", field, j), "%s.days[%d]: invalid day %q (use mon, tue, wed, thu, fri, sat, sun)", field, j, day)
+		}
+	}
 }
 
 // GetStatusInfo returns status information for a given status
@@ -468,14 +901,19 @@ func (c *Config) IsDesktopEnabled() bool {
 	return c.Notifications.Desktop.Enabled
 }
 
-// IsWebhookEnabled returns true if webhook notifications are enabled
+// IsWebhookEnabled returns true if any webhook target is enabled
 func (c *Config) IsWebhookEnabled() bool {
-	return c.Notifications.Webhook.Enabled
+	return len(c.WebhookTargets()) > 0
+}
+
+// IsExecEnabled returns true if the exec channel is enabled
+func (c *Config) IsExecEnabled() bool {
+	return c.Notifications.Exec.Enabled
 }
 
 // IsAnyNotificationEnabled returns true if at least one notification method is enabled
 func (c *Config) IsAnyNotificationEnabled() bool {
-	return c.IsDesktopEnabled() || c.IsWebhookEnabled()
+	return c.IsDesktopEnabled() || c.IsWebhookEnabled() || c.IsExecEnabled()
 }
 
 // GetSuppressQuestionAfterTaskCompleteSeconds returns the cooldown in seconds
@@ -496,6 +934,189 @@ func (c *Config) GetSuppressQuestionAfterAnyNotificationSeconds() int {
 	return *c.Notifications.SuppressQuestionAfterAnyNotificationSeconds
 }
 
+// GetMinTurnDurationSeconds returns the minimum turn length (in seconds) required
+// to notify for the given status (0 = no minimum).
+// A per-status minTurnDurationSeconds always wins; otherwise exempt statuses get 0
+// and all other statuses use the global notifications.minTurnDurationSeconds.
+func (c *Config) GetMinTurnDurationSeconds(status string) int {
+	if info, exists := c.Statuses[status]; exists && info.MinTurnDurationSeconds != nil {
+		return *info.MinTurnDurationSeconds
+	}
+
+	exempt := c.Notifications.MinTurnDurationExemptStatuses
+	if exempt == nil {
+		exempt = defaultMinTurnDurationExemptStatuses
+	}
+	for _, s := range exempt {
+		if s == status {
+			return 0
+		}
+	}
+
+	if c.Notifications.MinTurnDurationSeconds == nil {
+		return 0 // Disabled by default
+	}
+	return *c.Notifications.MinTurnDurationSeconds
+}
+
+// IsReminderEnabledFor returns true if unanswered notifications of this status should be repeated
+func (c *Config) IsReminderEnabledFor(status string) bool {
+	if !c.Notifications.Reminders.Enabled {
+		return false
+	}
+	statuses := c.Notifications.Reminders.Statuses
+	if len(statuses) == 0 {
+		statuses = defaultReminderStatuses
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAggregateEnabledFor returns true if notifications of this status wait for the aggregation window
+func (c *Config) IsAggregateEnabledFor(status string) bool {
+	if !c.Notifications.Aggregate.Enabled {
+		return false
+	}
+	if len(c.Notifications.Aggregate.Statuses) == 0 {
+		return true
+	}
+	for _, s := range c.Notifications.Aggregate.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAggregateWindow returns how long notifications are collected after the first one (default: 10s)
+func (c *Config) GetAggregateWindow() time.Duration {
+	d, err := time.ParseDuration(c.Notifications.Aggregate.Window)
+	if err != nil || d <= 0 || d > maxAggregateWindow {
+		return defaultAggregateWindow
+	}
+	return d
+}
+
+// GetReminderBackoff returns the delay before each reminder (default: 5m, 15m, 60m).
+// Invalid entries are skipped; Validate reports them.
+func (c *Config) GetReminderBackoff() []time.Duration {
+	backoff := c.Notifications.Reminders.Backoff
+	if len(backoff) == 0 {
+		backoff = defaultReminderBackoff
+	}
+	result := make([]time.Duration, 0, len(backoff))
+	for _, b := range backoff {
+		if d, err := time.ParseDuration(b); err == nil && d > 0 {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// GetJournalDir returns the hook journal directory (default: journal/ in the stable config dir)
+func (c *Config) GetJournalDir() (string, error) {
+	if c.Notifications.Journal.Dir != "" {
+		return c.Notifications.Journal.Dir, nil
+	}
+	dir, err := GetStableConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "journal"), nil
+}
+
+// GetJournalMaxEntries returns how many hook payloads the journal keeps (0 = default)
+func (c *Config) GetJournalMaxEntries() int {
+	if c.Notifications.Journal.MaxEntries <= 0 {
+		return defaultJournalMaxEntries
+	}
+	return c.Notifications.Journal.MaxEntries
+}
+
+// IsHistoryEnabled returns true if notifications are appended to the history file (default: true)
+func (c *Config) IsHistoryEnabled() bool {
+	if c.Notifications.History.Enabled == nil {
+		return true
+	}
+	return *c.Notifications.History.Enabled
+}
+
+// GetHistoryPath returns the history file path.
+// Default follows the XDG base directory spec: $XDG_STATE_HOME (or ~/.local/state)/claude-notifications/history.jsonl
+func (c *Config) GetHistoryPath() (string, error) {
+	if c.Notifications.History.Path != "" {
+		return c.Notifications.History.Path, nil
+	}
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// GetDNDPath returns the file holding the manual do-not-disturb override (`claude-notifications dnd`)
+func (c *Config) GetDNDPath() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "dnd.json"), nil
+}
+
+// stateDir returns the plugin's XDG state directory: $XDG_STATE_HOME (or ~/.local/state)/claude-notifications
+func stateDir() (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateHome, "claude-notifications"), nil
+}
+
+// GetQuietHoursLocation returns the timezone of the quiet hours schedule (default: local time)
+func (c *Config) GetQuietHoursLocation() *time.Location {
+	if tz := c.Notifications.QuietHours.Timezone; tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// GetQuietHoursMode returns what quiet hours do to notifications (default: mute)
+func (c *Config) GetQuietHoursMode() string {
+	if c.Notifications.QuietHours.Mode == "" {
+		return QuietModeMute
+	}
+	return c.Notifications.QuietHours.Mode
+}
+
+// IsQuietHoursBreakThrough returns true if status is delivered normally during quiet hours
+func (c *Config) IsQuietHoursBreakThrough(status string) bool {
+	for _, s := range c.Notifications.QuietHours.BreakThrough {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHistoryMaxBytes returns the history file size that triggers rotation (default: 10 MB)
+func (c *Config) GetHistoryMaxBytes() int64 {
+	mb := c.Notifications.History.MaxSizeMB
+	if mb <= 0 {
+		mb = defaultHistoryMaxSizeMB
+	}
+	return int64(mb) << 20
+}
+
 // ShouldNotifyOnTextResponse returns true if notifications should be sent for text-only responses (default: true)
 func (c *Config) ShouldNotifyOnTextResponse() bool {
 	if c.Notifications.NotifyOnTextResponse == nil {
@@ -549,21 +1170,62 @@ func (c *Config) IsStatusDesktopEnabled(status string) bool {
 }
 
 // IsStatusWebhookEnabled returns true if webhook notifications for this status are enabled
-// Considers both global webhook.enabled and per-status enabled
+// Considers per-status enabled and whether an enabled target sends this status
 func (c *Config) IsStatusWebhookEnabled(status string) bool {
-	return c.IsWebhookEnabled() && c.IsStatusEnabled(status)
+	return c.IsStatusEnabled(status) && len(c.WebhookTargetsFor(status, nil)) > 0
 }
 
-// ShouldFilter returns true if any suppress-filter rule matches the given context.
-// When true, the notification should be suppressed entirely (both desktop and webhook).
-func (c *Config) ShouldFilter(status, gitBranch, folder string) bool {
+// IsStatusExecEnabled returns true if the exec command should run for this status
+// Considers global exec.enabled, per-status enabled and the exec.statuses list
+func (c *Config) IsStatusExecEnabled(status string) bool {
+	if !c.IsExecEnabled() || !c.IsStatusEnabled(status) {
+		return false
+	}
+	if len(c.Notifications.Exec.Statuses) == 0 {
+		return true
+	}
+	for _, s := range c.Notifications.Exec.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// GetExecTimeout returns the exec command timeout (default: 10s)
+func (c *Config) GetExecTimeout() time.Duration {
+	d, err := time.ParseDuration(c.Notifications.Exec.Timeout)
+	if err != nil || d <= 0 {
+		return defaultExecTimeout
+	}
+	return d
+}
+
+// GetExecMaxConcurrent returns how many exec commands may run at once (default: 2)
+func (c *Config) GetExecMaxConcurrent() int {
+	if c.Notifications.Exec.MaxConcurrent <= 0 {
+		return defaultExecMaxConcurrent
+	}
+	return c.Notifications.Exec.MaxConcurrent
+}
+
+// MatchingFilter returns the first suppress-filter rule that matches in, or nil.
+// When non-nil, the notification should be suppressed entirely (both desktop and webhook).
+// in.Time is converted to quietHours.timezone for timeOfDay conditions.
+func (c *Config) MatchingFilter(in MatchInput) *SuppressFilter {
+	in.Time = in.Time.In(c.GetQuietHoursLocation())
 	for i := range c.Notifications.SuppressFilters {
 		if !c.Notifications.SuppressFilters[i].HasConditions() {
 			continue
 		}
-		if c.Notifications.SuppressFilters[i].Matches(status, gitBranch, folder) {
-			return true
+		if c.Notifications.SuppressFilters[i].Matches(in) {
+			return &c.Notifications.SuppressFilters[i]
 		}
 	}
-	return false
+	return nil
+}
+
+// ShouldFilter returns true if any suppress-filter rule matches the given context.
+func (c *Config) ShouldFilter(in MatchInput) bool {
+	return c.MatchingFilter(in) != nil
 }
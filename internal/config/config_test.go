@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1291,7 +1292,7 @@ func TestSuppressFilter_Matches(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.filter.Matches(tt.status, tt.gitBranch, tt.folder)
+			got := tt.filter.Matches(MatchInput{Status: tt.status, GitBranch: tt.gitBranch, Folder: tt.folder})
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -1316,20 +1317,20 @@ func TestConfig_ShouldFilter(t *testing.T) {
 	}
 
 	// Rule 1 matches
-	assert.True(t, cfg.ShouldFilter("task_complete", "", "ClaudeProbe"))
+	assert.True(t, cfg.ShouldFilter(MatchInput{Status: "task_complete", GitBranch: "", Folder: "ClaudeProbe"}))
 	// Rule 2 matches
-	assert.True(t, cfg.ShouldFilter("question", "dev", "scratch"))
+	assert.True(t, cfg.ShouldFilter(MatchInput{Status: "question", GitBranch: "dev", Folder: "scratch"}))
 	// Neither matches
-	assert.False(t, cfg.ShouldFilter("task_complete", "main", "my-project"))
+	assert.False(t, cfg.ShouldFilter(MatchInput{Status: "task_complete", GitBranch: "main", Folder: "my-project"}))
 	// Partial match on rule 1 (wrong folder)
-	assert.False(t, cfg.ShouldFilter("task_complete", "", "other-project"))
+	assert.False(t, cfg.ShouldFilter(MatchInput{Status: "task_complete", GitBranch: "", Folder: "other-project"}))
 }
 
 func TestConfig_ShouldFilter_EmptyFilters(t *testing.T) {
 	cfg := DefaultConfig()
 	// No filters configured — should never filter
-	assert.False(t, cfg.ShouldFilter("task_complete", "", "ClaudeProbe"))
-	assert.False(t, cfg.ShouldFilter("question", "main", "my-project"))
+	assert.False(t, cfg.ShouldFilter(MatchInput{Status: "task_complete", GitBranch: "", Folder: "ClaudeProbe"}))
+	assert.False(t, cfg.ShouldFilter(MatchInput{Status: "question", GitBranch: "main", Folder: "my-project"}))
 }
 
 func TestConfig_Validate_SuppressFilters(t *testing.T) {
@@ -1437,7 +1438,440 @@ func TestLoadConfig_WithSuppressFilters(t *testing.T) {
 	assert.Equal(t, "scratch", *f1.Folder)
 
 	// Verify filtering works end-to-end
-	assert.True(t, cfg.ShouldFilter("task_complete", "", "ClaudeProbe"))
-	assert.True(t, cfg.ShouldFilter("question", "main", "scratch"))
-	assert.False(t, cfg.ShouldFilter("task_complete", "main", "my-project"))
+	assert.True(t, cfg.ShouldFilter(MatchInput{Status: "task_complete", GitBranch: "", Folder: "ClaudeProbe"}))
+	assert.True(t, cfg.ShouldFilter(MatchInput{Status: "question", GitBranch: "main", Folder: "scratch"}))
+	assert.False(t, cfg.ShouldFilter(MatchInput{Status: "task_complete", GitBranch: "main", Folder: "my-project"}))
+}
+
+func TestSuppressFilter_Patterns(t *testing.T) {
+	in := MatchInput{
+		Status:    "task_complete",
+		GitBranch: "dependabot/npm_and_yarn/lodash-4.17.21",
+		Folder:    "tmp-42",
+		CWD:       "/home/me/tmp-42",
+		Message:   "Bumped lodash from 4.17.20 to 4.17.21",
+	}
+
+	assert.True(t, (&SuppressFilter{GitBranch: stringPtr("dependabot/*")}).Matches(in))
+	assert.True(t, (&SuppressFilter{Folder: stringPtr("tmp-*"), Status: stringPtr("re:^task_")}).Matches(in))
+	assert.True(t, (&SuppressFilter{CWD: stringPtr("/home/me/*")}).Matches(in))
+	assert.False(t, (&SuppressFilter{CWD: stringPtr("/work/*")}).Matches(in))
+	assert.True(t, (&SuppressFilter{Message: stringPtr("re:(?i)^bumped ")}).Matches(in))
+	assert.False(t, (&SuppressFilter{Message: stringPtr("*error*")}).Matches(in))
+	assert.False(t, (&SuppressFilter{GitBranch: stringPtr("!dependabot/*")}).Matches(in))
+	assert.True(t, (&SuppressFilter{Status: stringPtr("!question"), GitBranch: stringPtr("!main")}).Matches(in))
+	assert.True(t, (&SuppressFilter{GitBranch: stringPtr("!")}).Matches(in), `"!" matches any branch`)
+}
+
+func TestSuppressFilter_TimeOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	cfg := DefaultConfig()
+	cfg.Notifications.QuietHours.Timezone = "Europe/Berlin"
+	cfg.Notifications.SuppressFilters = []SuppressFilter{{
+		Name:      "night builds",
+		Folder:    stringPtr("ci-*"),
+		TimeOfDay: &QuietWindow{Start: "22:00", End: "06:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}},
+	}}
+	require.NoError(t, cfg.Validate())
+
+	// Tuesday 2024-06-04, Berlin is UTC+2 in summer
+	at := func(hour, minute int) MatchInput {
+		return MatchInput{Status: "task_complete", Folder: "ci-nightly", Time: time.Date(2024, 6, 4, hour, minute, 0, 0, loc)}
+	}
+	assert.NotNil(t, cfg.MatchingFilter(at(23, 0)))
+	assert.NotNil(t, cfg.MatchingFilter(at(5, 59)), "started Monday night")
+	assert.Nil(t, cfg.MatchingFilter(at(6, 0)))
+	assert.Nil(t, cfg.MatchingFilter(at(12, 0)))
+
+	in := at(23, 0)
+	in.Time = in.Time.UTC() // converted to quietHours.timezone
+	filter := cfg.MatchingFilter(in)
+	require.NotNil(t, filter)
+	assert.Equal(t, "night builds", filter.Name)
+
+	in.Folder = "app"
+	assert.Nil(t, cfg.MatchingFilter(in))
+}
+
+func TestConfig_Validate_SuppressFilterPatterns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Notifications.SuppressFilters = []SuppressFilter{
+		{Status: stringPtr("api_*"), GitBranch: stringPtr("!main")},
+		{Message: stringPtr("re:(broken")},
+		{CWD: stringPtr("/tmp/[x")},
+		{TimeOfDay: &QuietWindow{Start: "25:00", End: "07:00", Days: []string{"funday"}}},
+		{Status: stringPtr("compelte")},
+	}
+
+	errs := cfg.ValidateAll()
+	paths := make([]string, 0, len(errs))
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{
+		"notifications.suppressFilters[1].message",
+		"notifications.suppressFilters[2].cwd",
+		"notifications.suppressFilters[3].timeOfDay.start",
+		"notifications.suppressFilters[3].timeOfDay.days[0]",
+		"notifications.suppressFilters[4].status",
+	}, paths)
+	assert.Contains(t, errs[0].Message, "suppressFilters[1].message: invalid regex")
+	assert.Contains(t, errs[3].Message, `invalid day "funday"`)
+}
+
+func TestConfig_GetMinTurnDurationSeconds(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		cfg := DefaultConfig()
+		assert.Equal(t, 0, cfg.GetMinTurnDurationSeconds("task_complete"))
+	})
+
+	t.Run("global applies to non-exempt statuses", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Notifications.MinTurnDurationSeconds = intPtr(30)
+		assert.Equal(t, 30, cfg.GetMinTurnDurationSeconds("task_complete"))
+		assert.Equal(t, 30, cfg.GetMinTurnDurationSeconds("review_complete"))
+	})
+
+	t.Run("questions and errors exempt by default", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Notifications.MinTurnDurationSeconds = intPtr(30)
+		for _, status := range []string{"question", "permission_request", "api_error", "api_error_overloaded"} {
+			assert.Equal(t, 0, cfg.GetMinTurnDurationSeconds(status), status)
+		}
+	})
+
+	t.Run("custom exempt list replaces defaults", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Notifications.MinTurnDurationSeconds = intPtr(30)
+		cfg.Notifications.MinTurnDurationExemptStatuses = []string{"review_complete"}
+		assert.Equal(t, 0, cfg.GetMinTurnDurationSeconds("review_complete"))
+		assert.Equal(t, 30, cfg.GetMinTurnDurationSeconds("question"))
+	})
+
+	t.Run("per-status value wins", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Notifications.MinTurnDurationSeconds = intPtr(30)
+		info := cfg.Statuses["task_complete"]
+		info.MinTurnDurationSeconds = intPtr(90)
+		cfg.Statuses["task_complete"] = info
+		q := cfg.Statuses["question"]
+		q.MinTurnDurationSeconds = intPtr(5)
+		cfg.Statuses["question"] = q
+
+		assert.Equal(t, 90, cfg.GetMinTurnDurationSeconds("task_complete"))
+		assert.Equal(t, 5, cfg.GetMinTurnDurationSeconds("question"))
+	})
+}
+
+func TestConfig_Validate_MinTurnDuration(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Notifications.MinTurnDurationSeconds = intPtr(-1)
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "minTurnDurationSeconds must be >= 0")
+
+	cfg = DefaultConfig()
+	info := cfg.Statuses["task_complete"]
+	info.MinTurnDurationSeconds = intPtr(-5)
+	cfg.Statuses["task_complete"] = info
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statuses.task_complete.minTurnDurationSeconds")
+
+	cfg = DefaultConfig()
+	cfg.Notifications.MinTurnDurationExemptStatuses = []string{"question", "bogus"}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `minTurnDurationExemptStatuses[1]: invalid status "bogus"`)
+}
+
+func TestConfig_Reminders(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.False(t, cfg.IsReminderEnabledFor("question"), "reminders are disabled by default")
+
+	cfg.Notifications.Reminders.Enabled = true
+	assert.True(t, cfg.IsReminderEnabledFor("question"))
+	assert.True(t, cfg.IsReminderEnabledFor("plan_ready"))
+	assert.True(t, cfg.IsReminderEnabledFor("permission_request"))
+	assert.False(t, cfg.IsReminderEnabledFor("task_complete"))
+	assert.Equal(t, []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour}, cfg.GetReminderBackoff())
+
+	cfg.Notifications.Reminders.Statuses = []string{"plan_ready"}
+	cfg.Notifications.Reminders.Backoff = []string{"2m", "10m"}
+	assert.False(t, cfg.IsReminderEnabledFor("question"))
+	assert.True(t, cfg.IsReminderEnabledFor("plan_ready"))
+	assert.Equal(t, []time.Duration{2 * time.Minute, 10 * time.Minute}, cfg.GetReminderBackoff())
+}
+
+func TestConfig_Validate_Reminders(t *testing.T) {
+	tests := []struct {
+		name      string
+		reminders RemindersConfig
+		wantErr   string
+	}{
+		{
+			name:      "disabled reminders are not validated",
+			reminders: RemindersConfig{Backoff: []string{"bogus"}},
+		},
+		{
+			name:      "valid reminders",
+			reminders: RemindersConfig{Enabled: true, Backoff: []string{"5m", "1h"}, EscalateToWebhookAfter: 2},
+		},
+		{
+			name:      "invalid duration",
+			reminders: RemindersConfig{Enabled: true, Backoff: []string{"5m", "soon"}},
+			wantErr:   `reminders.backoff[1]: invalid duration "soon"`,
+		},
+		{
+			name:      "non-positive duration",
+			reminders: RemindersConfig{Enabled: true, Backoff: []string{"0s"}},
+			wantErr:   "reminders.backoff[0]: duration must be positive",
+		},
+		{
+			name:      "invalid status",
+			reminders: RemindersConfig{Enabled: true, Statuses: []string{"bogus"}},
+			wantErr:   `reminders.statuses[0]: invalid status "bogus"`,
+		},
+		{
+			name:      "negative escalation",
+			reminders: RemindersConfig{Enabled: true, EscalateToWebhookAfter: -1},
+			wantErr:   "reminders.escalateToWebhookAfter must be >= 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Notifications.Reminders = tt.reminders
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Aggregate(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.False(t, cfg.IsAggregateEnabledFor("task_complete"), "aggregation is disabled by default")
+	assert.Equal(t, 10*time.Second, cfg.GetAggregateWindow())
+
+	cfg.Notifications.Aggregate.Enabled = true
+	assert.True(t, cfg.IsAggregateEnabledFor("question"), "all statuses wait without a statuses list")
+
+	cfg.Notifications.Aggregate.Statuses = []string{"task_complete", "review_complete"}
+	cfg.Notifications.Aggregate.Window = "20s"
+	assert.True(t, cfg.IsAggregateEnabledFor("task_complete"))
+	assert.False(t, cfg.IsAggregateEnabledFor("question"))
+	assert.Equal(t, 20*time.Second, cfg.GetAggregateWindow())
+}
+
+func TestConfig_Validate_Aggregate(t *testing.T) {
+	tests := []struct {
+		name      string
+		aggregate AggregateConfig
+		wantErr   string
+	}{
+		{
+			name:      "disabled aggregation is not validated",
+			aggregate: AggregateConfig{Window: "bogus"},
+		},
+		{
+			name:      "valid aggregation",
+			aggregate: AggregateConfig{Enabled: true, Window: "15s", Statuses: []string{"task_complete"}},
+		},
+		{
+			name:      "invalid window",
+			aggregate: AggregateConfig{Enabled: true, Window: "soon"},
+			wantErr:   `aggregate.window: invalid duration "soon"`,
+		},
+		{
+			name:      "window too long",
+			aggregate: AggregateConfig{Enabled: true, Window: "5m"},
+			wantErr:   "aggregate.window must be between 0s and 1m0s",
+		},
+		{
+			name:      "invalid status",
+			aggregate: AggregateConfig{Enabled: true, Statuses: []string{"bogus"}},
+			wantErr:   `aggregate.statuses[0]: invalid status "bogus"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Notifications.Aggregate = tt.aggregate
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Journal(t *testing.T) {
+	home := t.TempDir()
+	setTestHome(t, home)
+
+	cfg := DefaultConfig()
+	assert.False(t, cfg.Notifications.Journal.Enabled, "journal is disabled by default")
+	assert.Equal(t, 200, cfg.GetJournalMaxEntries())
+
+	dir, err := cfg.GetJournalDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".claude", "claude-notifications-go", "journal"), dir)
+
+	cfg.Notifications.Journal.Dir = "/tmp/my-journal"
+	cfg.Notifications.Journal.MaxEntries = 5
+	dir, err = cfg.GetJournalDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/my-journal", dir)
+	assert.Equal(t, 5, cfg.GetJournalMaxEntries())
+
+	cfg.Notifications.Journal.MaxEntries = -1
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "journal.maxEntries must be >= 0")
+}
+
+func TestConfig_Exec(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.False(t, cfg.IsStatusExecEnabled("task_complete"), "exec is disabled by default")
+	assert.Equal(t, 10*time.Second, cfg.GetExecTimeout())
+	assert.Equal(t, 2, cfg.GetExecMaxConcurrent())
+
+	cfg.Notifications.Desktop.Enabled = false
+	cfg.Notifications.Exec = ExecConfig{Enabled: true, Command: []string{"notify-led"}, Timeout: "3s", MaxConcurrent: 4}
+	assert.True(t, cfg.IsAnyNotificationEnabled())
+	assert.True(t, cfg.IsStatusExecEnabled("task_complete"))
+	assert.Equal(t, 3*time.Second, cfg.GetExecTimeout())
+	assert.Equal(t, 4, cfg.GetExecMaxConcurrent())
+
+	// Per-status enabled flag applies to exec like desktop/webhook
+	disabled := false
+	info := cfg.Statuses["task_complete"]
+	info.Enabled = &disabled
+	cfg.Statuses["task_complete"] = info
+	assert.False(t, cfg.IsStatusExecEnabled("task_complete"))
+
+	// exec.statuses narrows the channel
+	cfg.Notifications.Exec.Statuses = []string{"question"}
+	assert.True(t, cfg.IsStatusExecEnabled("question"))
+	assert.False(t, cfg.IsStatusExecEnabled("plan_ready"))
+}
+
+func TestConfig_Validate_Exec(t *testing.T) {
+	tests := []struct {
+		name    string
+		exec    ExecConfig
+		wantErr string
+	}{
+		{name: "disabled exec is not validated", exec: ExecConfig{Timeout: "bogus"}},
+		{name: "valid exec", exec: ExecConfig{Enabled: true, Command: []string{"led"}, Timeout: "5s", Statuses: []string{"question"}}},
+		{name: "missing command", exec: ExecConfig{Enabled: true}, wantErr: "exec.command is required"},
+		{name: "invalid timeout", exec: ExecConfig{Enabled: true, Command: []string{"led"}, Timeout: "soon"}, wantErr: `exec.timeout: invalid duration "soon"`},
+		{name: "non-positive timeout", exec: ExecConfig{Enabled: true, Command: []string{"led"}, Timeout: "0s"}, wantErr: "exec.timeout: duration must be positive"},
+		{name: "negative concurrency", exec: ExecConfig{Enabled: true, Command: []string{"led"}, MaxConcurrent: -1}, wantErr: "exec.maxConcurrent must be >= 0"},
+		{name: "invalid status", exec: ExecConfig{Enabled: true, Command: []string{"led"}, Statuses: []string{"bogus"}}, wantErr: `exec.statuses[0]: invalid status "bogus"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Notifications.Exec = tt.exec
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_History(t *testing.T) {
+	home := t.TempDir()
+	setTestHome(t, home)
+	t.Setenv("XDG_STATE_HOME", "")
+
+	cfg := DefaultConfig()
+	assert.True(t, cfg.IsHistoryEnabled(), "history is enabled by default")
+	assert.Equal(t, int64(10<<20), cfg.GetHistoryMaxBytes())
+
+	path, err := cfg.GetHistoryPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "state", "claude-notifications", "history.jsonl"), path)
+
+	stateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+	path, err = cfg.GetHistoryPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(stateHome, "claude-notifications", "history.jsonl"), path)
+
+	disabled := false
+	cfg.Notifications.History = HistoryConfig{Enabled: &disabled, Path: "/tmp/h.jsonl", MaxSizeMB: 1}
+	assert.False(t, cfg.IsHistoryEnabled())
+	path, err = cfg.GetHistoryPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/h.jsonl", path)
+	assert.Equal(t, int64(1<<20), cfg.GetHistoryMaxBytes())
+
+	cfg.Notifications.History.MaxSizeMB = -1
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "history.maxSizeMB must be >= 0")
+}
+
+func TestConfig_QuietHours(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, QuietModeMute, cfg.GetQuietHoursMode())
+	assert.Equal(t, time.Local, cfg.GetQuietHoursLocation())
+	assert.False(t, cfg.IsQuietHoursBreakThrough("api_error"))
+
+	cfg.Notifications.QuietHours = QuietHoursConfig{
+		Enabled:      true,
+		Timezone:     "Europe/Berlin",
+		Mode:         QuietModeSuppress,
+		Windows:      []QuietWindow{{Days: []string{"Mon", "fri"}, Start: "22:00", End: "07:00"}},
+		BreakThrough: []string{"api_error"},
+	}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, QuietModeSuppress, cfg.GetQuietHoursMode())
+	assert.Equal(t, "Europe/Berlin", cfg.GetQuietHoursLocation().String())
+	assert.True(t, cfg.IsQuietHoursBreakThrough("api_error"))
+	assert.True(t, cfg.Notifications.QuietHours.Windows[0].HasDay(time.Monday))
+	assert.False(t, cfg.Notifications.QuietHours.Windows[0].HasDay(time.Sunday))
+	assert.True(t, QuietWindow{}.HasDay(time.Sunday), "no days means every day")
+}
+
+func TestConfig_Validate_QuietHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		quiet   QuietHoursConfig
+		wantErr string
+	}{
+		{"unknown timezone", QuietHoursConfig{Timezone: "Mars/Base"}, "quietHours.timezone"},
+		{"invalid mode", QuietHoursConfig{Mode: "silent"}, "quietHours.mode"},
+		{"invalid start", QuietHoursConfig{Windows: []QuietWindow{{Start: "25:00", End: "07:00"}}}, "quietHours.windows[0].start"},
+		{"missing end", QuietHoursConfig{Windows: []QuietWindow{{Start: "22:00"}}}, "quietHours.windows[0].end"},
+		{"invalid day", QuietHoursConfig{Windows: []QuietWindow{{Days: []string{"monday"}, Start: "22:00", End: "07:00"}}}, "quietHours.windows[0].days[0]"},
+		{"invalid break-through status", QuietHoursConfig{BreakThrough: []string{"nope"}}, "quietHours.breakThrough[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Notifications.QuietHours = tt.quiet
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
 }
@@ -0,0 +1,290 @@
+// ABOUTME: CLAUDE_NOTIFICATIONS_* environment variables that override config fields, and where each value came from.
+// ABOUTME: Names follow the JSON path: notifications.webhook.url -> CLAUDE_NOTIFICATIONS_WEBHOOK_URL.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix starts every environment variable that overrides a config field
+const EnvPrefix = "CLAUDE_NOTIFICATIONS_"
+
+// envReserved are CLAUDE_NOTIFICATIONS_* variables with another meaning (wrapper script, debugging)
+var envReserved = map[string]bool{
+	EnvPrefix + "BIN":   true,
+	EnvPrefix + "DEBUG": true,
+}
+
+// SourceDefault is the source of values that neither a file nor the environment set
+const SourceDefault = "default"
+
+// EnvOverride is an environment variable applied to the config
+type EnvOverride struct {
+	Var   string // e.g. CLAUDE_NOTIFICATIONS_WEBHOOK_URL
+	Path  string // e.g. notifications.webhook.url
+	Value string
+}
+
+// EnvVarName returns the environment variable that overrides the field at path. The
+// "notifications." prefix is dropped and camelCase becomes UPPER_SNAKE:
+// statuses.question.sound -> CLAUDE_NOTIFICATIONS_STATUSES_QUESTION_SOUND.
+func EnvVarName(path string) string {
+	path = strings.TrimPrefix(path, "notifications.")
+	segments := strings.Split(path, ".")
+	for i, s := range segments {
+		segments[i] = upperSnake(s)
+	}
+	return EnvPrefix + strings.Join(segments, "_")
+}
+
+// upperSnake converts a JSON key to UPPER_SNAKE: terminalBundleId -> TERMINAL_BUNDLE_ID, maxSizeMB -> MAX_SIZE_MB
+func upperSnake(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// ApplyEnv overrides config fields from the CLAUDE_NOTIFICATIONS_* variables in environ
+// (os.Environ() format). Invalid values and unknown names are returned as errors; all
+// other variables still apply. The overrides are kept, so WithProjectConfig re-applies them.
+func (c *Config) ApplyEnv(environ []string) []error {
+	values := make(map[string]string)
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if ok && strings.HasPrefix(name, EnvPrefix) && !envReserved[name] {
+			values[name] = value
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	var errs []error
+	known := make([]string, 0, 64)
+	c.walkFields(func(path string, v reflect.Value) {
+		name := EnvVarName(path)
+		known = append(known, name)
+		value, ok := values[name]
+		if !ok {
+			return
+		}
+		delete(values, name)
+		if err := setFromString(v, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+			return
+		}
+		c.env = append(c.env, EnvOverride{Var: name, Path: path, Value: value})
+		c.setSource(path, "env: "+name)
+	})
+
+	unknown := make([]string, 0, len(values))
+	for name := range values {
+		unknown = append(unknown, name)
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("%s: unknown config variable%s", name, didYouMean(name, known)))
+	}
+	return errs
+}
+
+// EnvOverrides returns the environment variables applied by ApplyEnv
+func (c *Config) EnvOverrides() []EnvOverride {
+	return c.env
+}
+
+// reapplyEnv applies the overrides of another config, e.g. over a merged project config
+func (c *Config) reapplyEnv(overrides []EnvOverride) {
+	byPath := make(map[string]EnvOverride, len(overrides))
+	for _, o := range overrides {
+		byPath[o.Path] = o
+	}
+	c.walkFields(func(path string, v reflect.Value) {
+		if o, ok := byPath[path]; ok && setFromString(v, o.Value) == nil {
+			c.env = append(c.env, o)
+			c.setSource(path, "env: "+o.Var)
+		}
+	})
+}
+
+// Source returns where the value at path came from: SourceDefault, "file: <path>",
+// "project: <path>" or "env: <variable>". Paths below a list or map report the list's source.
+func (c *Config) Source(path string) string {
+	for path != "" {
+		if source, ok := c.sources[path]; ok {
+			return source
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return SourceDefault
+}
+
+// Field is one effective config value
+type Field struct {
+	Path   string      `json:"path"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// Fields returns every config field with its effective value and source, in a stable order
+func (c *Config) Fields() []Field {
+	var fields []Field
+	c.walkFields(func(path string, v reflect.Value) {
+		fields = append(fields, Field{Path: path, Value: v.Interface(), Source: c.Source(path)})
+	})
+	return fields
+}
+
+func (c *Config) setSource(path, source string) {
+	if c.sources == nil {
+		c.sources = make(map[string]string)
+	}
+	c.sources[path] = source
+}
+
+// recordSources marks every field present in a config file's JSON as coming from source
+func (c *Config) recordSources(data []byte, source string) {
+	var raw map[string]interface{}
+	if json.Unmarshal(data, &raw) != nil {
+		return
+	}
+	var walk func(path string, value interface{}, schema *JSONSchema)
+	walk = func(path string, value interface{}, schema *JSONSchema) {
+		obj, isObject := value.(map[string]interface{})
+		if !isObject || schema == nil {
+			c.setSource(path, source)
+			return
+		}
+		for key, child := range obj {
+			if prop, ok := schema.Properties[key]; ok {
+				walk(joinPath(path, key), child, structSchema(prop))
+			} else if extra := schema.additional(); extra != nil {
+				walk(joinPath(path, key), child, structSchema(extra))
+			}
+		}
+	}
+	walk("", raw, GenerateSchema())
+}
+
+// structSchema returns s if it describes a struct or a map of structs (descended into
+// field by field), nil for leaf fields such as lists and string maps
+func structSchema(s *JSONSchema) *JSONSchema {
+	if len(s.Properties) > 0 {
+		return s
+	}
+	if extra := s.additional(); extra != nil && len(extra.Properties) > 0 {
+		return s
+	}
+	return nil
+}
+
+// walkFields calls fn with the JSON path and settable value of every leaf field: scalars,
+// lists and maps. Status fields are visited for every valid status; fn may set them.
+func (c *Config) walkFields(fn func(path string, v reflect.Value)) {
+	walkStruct(reflect.ValueOf(&c.Notifications).Elem(), "notifications", fn)
+
+	for _, name := range statusNames() {
+		info, exists := c.Statuses[name]
+		before := info
+		walkStruct(reflect.ValueOf(&info).Elem(), "statuses."+name, fn)
+		if exists || !reflect.DeepEqual(before, info) {
+			if c.Statuses == nil {
+				c.Statuses = make(map[string]StatusInfo)
+			}
+			c.Statuses[name] = info
+		}
+	}
+}
+
+func walkStruct(v reflect.Value, path string, fn func(path string, v reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if field.Type.Kind() == reflect.Struct {
+			walkStruct(v.Field(i), joinPath(path, name), fn)
+			continue
+		}
+		fn(joinPath(path, name), v.Field(i))
+	}
+}
+
+// setFromString parses an environment value into v. Lists of strings accept
+// "a,b,c" or a JSON array; other lists and maps take JSON.
+func setFromString(v reflect.Value, raw string) error {
+	if v.Kind() == reflect.Ptr {
+		elem := reflect.New(v.Type().Elem())
+		if err := setFromString(elem.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	default:
+		trimmed := strings.TrimSpace(raw)
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(trimmed, "[") {
+			list := []string{}
+			for _, item := range strings.Split(trimmed, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			v.Set(reflect.ValueOf(list))
+			return nil
+		}
+		target := reflect.New(v.Type())
+		if err := json.Unmarshal([]byte(trimmed), target.Interface()); err != nil {
+			return fmt.Errorf("invalid JSON value: %v", err)
+		}
+		v.Set(target.Elem())
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVarName(t *testing.T) {
+	tests := map[string]string{
+		"notifications.webhook.url":                              "CLAUDE_NOTIFICATIONS_WEBHOOK_URL",
+		"notifications.desktop.enabled":                          "CLAUDE_NOTIFICATIONS_DESKTOP_ENABLED",
+		"notifications.desktop.terminalBundleId":                 "CLAUDE_NOTIFICATIONS_DESKTOP_TERMINAL_BUNDLE_ID",
+		"notifications.webhook.chat_id":                          "CLAUDE_NOTIFICATIONS_WEBHOOK_CHAT_ID",
+		"notifications.history.maxSizeMB":                        "CLAUDE_NOTIFICATIONS_HISTORY_MAX_SIZE_MB",
+		"notifications.suppressQuestionAfterTaskCompleteSeconds": "CLAUDE_NOTIFICATIONS_SUPPRESS_QUESTION_AFTER_TASK_COMPLETE_SECONDS",
+		"statuses.question.sound":                                "CLAUDE_NOTIFICATIONS_STATUSES_QUESTION_SOUND",
+		"statuses.task_complete.enabled":                         "CLAUDE_NOTIFICATIONS_STATUSES_TASK_COMPLETE_ENABLED",
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, EnvVarName(path), path)
+	}
+}
+
+func TestApplyEnv_Types(t *testing.T) {
+	cfg := DefaultConfig()
+	errs := cfg.ApplyEnv([]string{
+		"CLAUDE_NOTIFICATIONS_WEBHOOK_URL=https://hooks.example.com/ci",
+		"CLAUDE_NOTIFICATIONS_DESKTOP_ENABLED=false",
+		"CLAUDE_NOTIFICATIONS_DESKTOP_VOLUME=0.25",
+		"CLAUDE_NOTIFICATIONS_DESKTOP_TERMINAL_BELL=0",
+		"CLAUDE_NOTIFICATIONS_MIN_TURN_DURATION_SECONDS=30",
+		"CLAUDE_NOTIFICATIONS_EXEC_COMMAND=/usr/bin/notify, --urgent",
+		`CLAUDE_NOTIFICATIONS_REMINDERS_BACKOFF=["1m","5m"]`,
+		`CLAUDE_NOTIFICATIONS_WEBHOOK_HEADERS={"Authorization":"Bearer x"}`,
+		`CLAUDE_NOTIFICATIONS_SUPPRESS_FILTERS=[{"status":"question"}]`,
+		"CLAUDE_NOTIFICATIONS_STATUSES_TASK_COMPLETE_SOUND=/sounds/ci.mp3",
+		"CLAUDE_NOTIFICATIONS_DEBUG=1",
+		"OTHER=ignored",
+	})
+	require.Empty(t, errs)
+
+	n := cfg.Notifications
+	assert.Equal(t, "https://hooks.example.com/ci", n.Webhook.URL)
+	assert.False(t, n.Desktop.Enabled)
+	assert.Equal(t, 0.25, n.Desktop.Volume)
+	assert.False(t, cfg.IsTerminalBellEnabled())
+	assert.Equal(t, 30, *n.MinTurnDurationSeconds)
+	assert.Equal(t, []string{"/usr/bin/notify", "--urgent"}, n.Exec.Command)
+	assert.Equal(t, []string{"1m", "5m"}, n.Reminders.Backoff)
+	assert.Equal(t, "Bearer x", n.Webhook.Headers["Authorization"])
+	require.Len(t, n.SuppressFilters, 1)
+	assert.Equal(t, "question", *n.SuppressFilters[0].Status)
+	assert.Equal(t, "/sounds/ci.mp3", cfg.Statuses["task_complete"].Sound)
+	assert.Equal(t, "✅ Completed", cfg.Statuses["task_complete"].Title, "other status fields are kept")
+
+	assert.Len(t, cfg.EnvOverrides(), 10)
+	assert.Equal(t, "env: CLAUDE_NOTIFICATIONS_WEBHOOK_URL", cfg.Source("notifications.webhook.url"))
+	assert.Equal(t, "env: CLAUDE_NOTIFICATIONS_SUPPRESS_FILTERS", cfg.Source("notifications.suppressFilters[0].status"))
+	assert.Equal(t, SourceDefault, cfg.Source("notifications.webhook.preset"))
+}
+
+func TestApplyEnv_Errors(t *testing.T) {
+	cfg := DefaultConfig()
+	errs := cfg.ApplyEnv([]string{
+		"CLAUDE_NOTIFICATIONS_DESKTOP_ENABLED=maybe",
+		"CLAUDE_NOTIFICATIONS_WEBHOOK_UR=https://typo",
+		"CLAUDE_NOTIFICATIONS_DESKTOP_SOUND=false",
+	})
+
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), `CLAUDE_NOTIFICATIONS_DESKTOP_ENABLED: invalid boolean "maybe"`)
+	assert.Contains(t, errs[1].Error(), `unknown config variable (did you mean "CLAUDE_NOTIFICATIONS_WEBHOOK_URL"?)`)
+	assert.True(t, cfg.Notifications.Desktop.Enabled, "invalid values leave the field alone")
+	assert.False(t, cfg.Notifications.Desktop.Sound, "valid variables still apply")
+}
+
+func TestLoadFromPluginRoot_EnvOverridesFile(t *testing.T) {
+	home := t.TempDir()
+	setTestHome(t, home)
+	stablePath, err := GetStableConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(stablePath), 0755))
+	require.NoError(t, os.WriteFile(stablePath, []byte(`{
+		"notifications": {
+			"desktop": {"sound": false},
+			"webhook": {"enabled": true, "preset": "slack", "url": "https://hooks.example.com/file"}
+		}
+	}`), 0644))
+
+	t.Setenv("CLAUDE_NOTIFICATIONS_WEBHOOK_URL", "https://hooks.example.com/env")
+	t.Setenv("CLAUDE_NOTIFICATIONS_WEBHOOK_ENABLED", "false")
+
+	cfg, err := LoadFromPluginRoot(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, "https://hooks.example.com/env", cfg.Notifications.Webhook.URL)
+	assert.False(t, cfg.Notifications.Webhook.Enabled)
+	assert.Equal(t, "env: CLAUDE_NOTIFICATIONS_WEBHOOK_URL", cfg.Source("notifications.webhook.url"))
+	assert.Equal(t, "file: "+stablePath, cfg.Source("notifications.webhook.preset"))
+	assert.Equal(t, "file: "+stablePath, cfg.Source("notifications.desktop.sound"))
+	assert.Equal(t, SourceDefault, cfg.Source("notifications.desktop.volume"))
+}
+
+func TestWithProjectConfig_EnvStillWins(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	projectPath := writeProjectConfig(t, repo, `{
+		"notifications": {"desktop": {"sound": false, "volume": 0.5}},
+		"statuses": {"question": {"title": "Project question"}}
+	}`)
+
+	global := DefaultConfig()
+	require.Empty(t, global.ApplyEnv([]string{"CLAUDE_NOTIFICATIONS_DESKTOP_VOLUME=0.8"}))
+
+	cfg, _, err := global.WithProjectConfig(repo)
+	require.NoError(t, err)
+	assert.Equal(t, 0.8, cfg.Notifications.Desktop.Volume)
+	assert.False(t, cfg.Notifications.Desktop.Sound)
+	assert.Equal(t, "env: CLAUDE_NOTIFICATIONS_DESKTOP_VOLUME", cfg.Source("notifications.desktop.volume"))
+	assert.Equal(t, "project: "+projectPath, cfg.Source("notifications.desktop.sound"))
+	assert.Equal(t, "project: "+projectPath, cfg.Source("statuses.question.title"))
+	assert.Equal(t, SourceDefault, cfg.Source("statuses.question.sound"))
+}
+
+func TestFields_ListsEveryField(t *testing.T) {
+	cfg := DefaultConfig()
+	fields := cfg.Fields()
+
+	paths := make(map[string]Field, len(fields))
+	for _, f := range fields {
+		paths[f.Path] = f
+	}
+	assert.Contains(t, paths, "notifications.webhook.retry.maxAttempts")
+	assert.Contains(t, paths, "notifications.webhook.headers")
+	assert.Contains(t, paths, "statuses.idle_prompt.title")
+	assert.Equal(t, "custom", paths["notifications.webhook.preset"].Value)
+	assert.Equal(t, SourceDefault, paths["notifications.webhook.preset"].Source)
+}
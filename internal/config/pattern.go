@@ -0,0 +1,105 @@
+// ABOUTME: Value patterns used by suppress filters: exact strings, globs ("dependabot/*") and regexes ("re:...").
+// ABOUTME: A leading "!" negates a pattern.
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RegexPrefix marks a pattern as a regular expression
+const RegexPrefix = "re:"
+
+// MatchPattern reports whether value matches pattern. Invalid patterns never match.
+// Pattern syntax, checked in this order:
+//
+//	!<pattern>  matches when <pattern> does not
+//	re:<regex>  Go regular expression, unanchored (use ^ and $ to anchor)
+//	glob        a value containing *, ? or [...] must match as a whole; * also matches "/"
+//	otherwise   exact, case-sensitive comparison, so plain values keep their old meaning
+func MatchPattern(pattern, value string) bool {
+	matched, err := matchPattern(pattern, value)
+	return err == nil && matched
+}
+
+// ValidatePattern returns an error if pattern is an invalid regex or glob
+func ValidatePattern(pattern string) error {
+	_, err := matchPattern(pattern, "")
+	return err
+}
+
+// IsPattern reports whether pattern is more than an exact value (negated, regex or glob)
+func IsPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, "!") || strings.HasPrefix(pattern, RegexPrefix) || isGlob(pattern)
+}
+
+func matchPattern(pattern, value string) (bool, error) {
+	if rest, negated := strings.CutPrefix(pattern, "!"); negated {
+		matched, err := matchPattern(rest, value)
+		return !matched, err
+	}
+	if expr, isRegex := strings.CutPrefix(pattern, RegexPrefix); isRegex {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex %q: %v", expr, err)
+		}
+		return re.MatchString(value), nil
+	}
+	if isGlob(pattern) {
+		re, err := globRegexp(pattern)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(value), nil
+	}
+	return pattern == value, nil
+}
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// globRegexp translates a glob into an anchored regex: * is any run of characters
+// (including "/" and newlines), ? is one character, [abc], [a-z] and [!abc] are classes
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := i + 1
+			if end < len(runes) && (runes[end] == '!' || runes[end] == '^') {
+				end++
+			}
+			if end < len(runes) && runes[end] == ']' {
+				end++ // "]" first in a class is literal
+			}
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("invalid glob %q: unclosed [", glob)
+			}
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %v", glob, err)
+	}
+	return re, nil
+}
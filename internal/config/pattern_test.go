@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern, value string
+		want           bool
+	}{
+		{"main", "main", true},
+		{"main", "main2", false},
+		{"", "", true},
+		{"dependabot/*", "dependabot/npm_and_yarn/lodash-4.17.21", true},
+		{"dependabot/*", "feature/dependabot", false},
+		{"tmp-*", "tmp-123", true},
+		{"tmp-*", "my-tmp-123", false},
+		{"release-?.?", "release-1.2", true},
+		{"release-?.?", "release-1.22", false},
+		{"[abc]-fix", "b-fix", true},
+		{"[!abc]-fix", "b-fix", false},
+		{"*.go", "file.go.bak", false},
+		{"/home/me/scratch/*", "/home/me/scratch/a/b", true},
+		{"*rate limit*", "Hit the API rate limit\nretrying", true},
+		{"re:^(feat|fix)/JIRA-[0-9]+$", "feat/JIRA-42", true},
+		{"re:^(feat|fix)/JIRA-[0-9]+$", "chore/JIRA-42", false},
+		{"re:(?i)wip", "Add WIP tests", true},
+		{"!main", "develop", true},
+		{"!main", "main", false},
+		{"!", "", false},
+		{"!dependabot/*", "dependabot/x", false},
+		{"!re:^release/", "feature/x", true},
+		{"re:(", "(", false},
+		{"!re:(", "x", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchPattern(tt.pattern, tt.value), "%q ~ %q", tt.pattern, tt.value)
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	assert.NoError(t, ValidatePattern("main"))
+	assert.NoError(t, ValidatePattern("feature/*"))
+	assert.NoError(t, ValidatePattern("re:^x$"))
+	assert.ErrorContains(t, ValidatePattern("re:(unclosed"), "invalid regex")
+	assert.ErrorContains(t, ValidatePattern("!re:["), "invalid regex")
+	assert.ErrorContains(t, ValidatePattern("tmp-[0-9"), "unclosed [")
+}
+
+func TestIsPattern(t *testing.T) {
+	assert.False(t, IsPattern("task_complete"))
+	assert.True(t, IsPattern("task_*"))
+	assert.True(t, IsPattern("!question"))
+	assert.True(t, IsPattern("re:^api_"))
+}
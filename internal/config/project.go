@@ -0,0 +1,175 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/777genius/claude-notifications/internal/logging"
+	"github.com/777genius/claude-notifications/internal/platform"
+)
+
+// ProjectConfigFile is the name of the per-project config file
+const ProjectConfigFile = ".claude-notifications.json"
+
+// projectNotificationKeys are the notifications settings a project config may change:
+// which notifications a repository gets and how they look and sound. A cloned repository
+// must not run commands or write files, so exec, journal, history, daemon, aggregate and
+// reminders only come from the user config.
+var projectNotificationKeys = map[string]bool{
+	"desktop":  true,
+	"webhook":  true,
+	"webhooks": true,
+	"suppressQuestionAfterTaskCompleteSeconds":    true,
+	"suppressQuestionAfterAnyNotificationSeconds": true,
+	"notifyOnSubagentStop":                        true,
+	"notifyOnPreCompact":                          true,
+	"suppressForSubagents":                        true,
+	"notifyOnTextResponse":                        true,
+	"suppressFilters":                             true,
+	"minTurnDurationSeconds":                      true,
+	"minTurnDurationExemptStatuses":               true,
+	"quietHours":                                  true,
+	"routes":                                      true,
+	"templates":                                   true,
+}
+
+// FindProjectConfigs returns the project config files that apply to cwd, outermost first.
+// It walks up from cwd to the git root (the directory containing .git), so a monorepo can
+// have a config at the root and more specific ones in subdirectories. Outside a git
+// repository only cwd itself is checked.
+func FindProjectConfigs(cwd string) []string {
+	if cwd == "" {
+		return nil
+	}
+	dir, err := filepath.Abs(cwd)
+	if err != nil {
+		return nil
+	}
+
+	var found []string
+	for {
+		if path := filepath.Join(dir, ProjectConfigFile); platform.FileExists(path) {
+			found = append([]string{path}, found...)
+		}
+		if platform.FileExists(filepath.Join(dir, ".git")) {
+			return found
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	// No git root: only a config directly in cwd applies
+	if path := filepath.Join(cwd, ProjectConfigFile); platform.FileExists(path) {
+		abs, _ := filepath.Abs(path)
+		return []string{abs}
+	}
+	return nil
+}
+
+// WithProjectConfig returns a copy of c with the project config files for cwd deep-merged
+// over it, and the files that were applied. Objects merge key by key (so a project can
+// change one status sound), arrays and scalars replace. Environment overrides applied to c
+// (ApplyEnv) are applied again on top. Settings a project may not change are dropped with
+// a warning (see projectNotificationKeys). Without project files c itself is returned.
+func (c *Config) WithProjectConfig(cwd string) (*Config, []string, error) {
+	paths := FindProjectConfigs(cwd)
+	if len(paths) == 0 {
+		return c, nil, nil
+	}
+
+	base, err := json.Marshal(c)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	files := make([][]byte, len(paths))
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read project config: %w", err)
+		}
+		var overlay map[string]interface{}
+		if err := json.Unmarshal(data, &overlay); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse project config %s: %w", path, err)
+		}
+		if dropped := restrictProjectConfig(overlay); len(dropped) > 0 {
+			logging.Warn("Ignoring settings a project config can't change in %s: %s", path, strings.Join(dropped, ", "))
+		}
+		mergeJSON(merged, overlay)
+		files[i] = data
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode merged config: %w", err)
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to apply project config: %w", err)
+	}
+
+	cfg.expandEnv()
+	cfg.ApplyDefaults()
+
+	// Keep the attribution of the user config; environment overrides still win
+	for path, source := range c.sources {
+		cfg.setSource(path, source)
+	}
+	for i, path := range paths {
+		cfg.recordSources(files[i], "project: "+path)
+	}
+	cfg.reapplyEnv(c.env)
+	return cfg, paths, nil
+}
+
+// restrictProjectConfig removes the settings a project config may not change from overlay
+// and returns their paths
+func restrictProjectConfig(overlay map[string]interface{}) []string {
+	var dropped []string
+	for key, value := range overlay {
+		switch key {
+		case "$schema", "statuses":
+		case "notifications":
+			notifications, ok := value.(map[string]interface{})
+			if !ok {
+				continue // Reported when the merged config is decoded
+			}
+			for name := range notifications {
+				if !projectNotificationKeys[name] {
+					delete(notifications, name)
+					dropped = append(dropped, "notifications."+name)
+				}
+			}
+		default:
+			delete(overlay, key)
+			dropped = append(dropped, key)
+		}
+	}
+	sort.Strings(dropped)
+	return dropped
+}
+
+// mergeJSON merges overlay into base: nested objects recursively, everything else replaced
+func mergeJSON(base, overlay map[string]interface{}) {
+	for key, value := range overlay {
+		if overlayObj, ok := value.(map[string]interface{}); ok {
+			if baseObj, ok := base[key].(map[string]interface{}); ok {
+				mergeJSON(baseObj, overlayObj)
+				continue
+			}
+		}
+		base[key] = value
+	}
+}
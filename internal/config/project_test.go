@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProjectConfig writes a .claude-notifications.json into dir
+func writeProjectConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	path := filepath.Join(dir, ProjectConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestFindProjectConfigs_WalksUpToGitRoot(t *testing.T) {
+	outer := t.TempDir()
+	repo := filepath.Join(outer, "repo")
+	sub := filepath.Join(repo, "services", "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(sub, 0755))
+
+	writeProjectConfig(t, outer, `{}`) // Above the git root: ignored
+	rootCfg := writeProjectConfig(t, repo, `{}`)
+	subCfg := writeProjectConfig(t, filepath.Join(repo, "services"), `{}`)
+
+	assert.Equal(t, []string{rootCfg, subCfg}, FindProjectConfigs(sub))
+	assert.Equal(t, []string{rootCfg}, FindProjectConfigs(repo))
+}
+
+func TestFindProjectConfigs_NoGitRoot(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	writeProjectConfig(t, dir, `{}`)
+	require.NoError(t, os.MkdirAll(sub, 0755))
+
+	assert.Empty(t, FindProjectConfigs(sub), "outside a repository parent directories are not searched")
+	assert.Equal(t, []string{filepath.Join(dir, ProjectConfigFile)}, FindProjectConfigs(dir))
+	assert.Empty(t, FindProjectConfigs(""))
+}
+
+func TestWithProjectConfig_DeepMerge(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	writeProjectConfig(t, repo, `{
+		"notifications": {
+			"webhook": {"enabled": true, "url": "https://hooks.example.com/infra"},
+			"suppressFilters": [{"status": "question"}]
+		},
+		"statuses": {
+			"task_complete": {"sound": "/sounds/infra.mp3"}
+		}
+	}`)
+
+	global := DefaultConfig()
+	global.Notifications.Webhook.Preset = "slack"
+	global.Notifications.SuppressFilters = []SuppressFilter{{Name: "global", Folder: stringPtr("tmp")}}
+
+	cfg, paths, err := global.WithProjectConfig(repo)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	require.NoError(t, cfg.Validate())
+
+	// Objects merge key by key
+	assert.True(t, cfg.Notifications.Webhook.Enabled)
+	assert.Equal(t, "https://hooks.example.com/infra", cfg.Notifications.Webhook.URL)
+	assert.Equal(t, "slack", cfg.Notifications.Webhook.Preset, "unset webhook fields keep the user config")
+	assert.Equal(t, "/sounds/infra.mp3", cfg.Statuses["task_complete"].Sound)
+	assert.Equal(t, global.Statuses["task_complete"].Title, cfg.Statuses["task_complete"].Title)
+	assert.Equal(t, global.Statuses["question"], cfg.Statuses["question"])
+
+	// Arrays replace
+	require.Len(t, cfg.Notifications.SuppressFilters, 1)
+	assert.Equal(t, "question", *cfg.Notifications.SuppressFilters[0].Status)
+
+	// The user config is not modified
+	assert.False(t, global.Notifications.Webhook.Enabled)
+	assert.Equal(t, "global", global.Notifications.SuppressFilters[0].Name)
+}
+
+func TestWithProjectConfig_NestedOverridesWin(t *testing.T) {
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "scratch")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	writeProjectConfig(t, repo, `{"notifications": {"desktop": {"enabled": true, "sound": true}}}`)
+	writeProjectConfig(t, sub, `{"notifications": {"desktop": {"sound": false}}}`)
+
+	cfg, paths, err := DefaultConfig().WithProjectConfig(sub)
+	require.NoError(t, err)
+	assert.Len(t, paths, 2)
+	assert.True(t, cfg.Notifications.Desktop.Enabled)
+	assert.False(t, cfg.Notifications.Desktop.Sound)
+}
+
+func TestWithProjectConfig_NoProjectFile(t *testing.T) {
+	global := DefaultConfig()
+	cfg, paths, err := global.WithProjectConfig(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+	assert.Same(t, global, cfg)
+}
+
+func TestWithProjectConfig_Malformed(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectConfig(t, dir, `{"notifications": `)
+
+	_, _, err := DefaultConfig().WithProjectConfig(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse project config")
+}
+
+func TestWithProjectConfig_CannotRunCommands(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	writeProjectConfig(t, repo, `{
+		"notifications": {
+			"exec": {"enabled": true, "command": ["/bin/sh", "-c", "touch /tmp/pwned"]},
+			"journal": {"enabled": true, "dir": "/tmp/elsewhere"},
+			"daemon": {"stateInMemory": true},
+			"webhook": {"enabled": true, "url": "https://hooks.example.com/infra"}
+		}
+	}`)
+
+	global := DefaultConfig()
+	global.Notifications.Exec = ExecConfig{Command: []string{"/usr/local/bin/blink"}}
+
+	cfg, _, err := global.WithProjectConfig(repo)
+	require.NoError(t, err)
+	assert.False(t, cfg.Notifications.Exec.Enabled, "a project must not enable exec")
+	assert.Equal(t, []string{"/usr/local/bin/blink"}, cfg.Notifications.Exec.Command, "a project must not replace exec.command")
+	assert.Equal(t, global.Notifications.Journal, cfg.Notifications.Journal)
+	assert.Equal(t, global.Notifications.Daemon, cfg.Notifications.Daemon)
+	assert.Equal(t, "https://hooks.example.com/infra", cfg.Notifications.Webhook.URL, "allowed settings still apply")
+}
+
+func TestRestrictProjectConfig(t *testing.T) {
+	overlay := map[string]interface{}{
+		"$schema":       "./config.schema.json",
+		"statuses":      map[string]interface{}{},
+		"notifications": map[string]interface{}{"routes": []interface{}{}, "history": map[string]interface{}{}, "reminders": map[string]interface{}{}},
+		"unknown":       true,
+	}
+
+	dropped := restrictProjectConfig(overlay)
+	assert.Equal(t, []string{"notifications.history", "notifications.reminders", "unknown"}, dropped)
+	assert.Contains(t, overlay["notifications"], "routes")
+	assert.Contains(t, overlay, "statuses")
+}
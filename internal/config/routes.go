@@ -0,0 +1,157 @@
+// ABOUTME: Routing rules (notifications.routes): ordered match conditions choosing channels and sound per notification.
+// ABOUTME: Without a matching rule the per-status settings decide, as before.
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Route channels
+const (
+	ChannelDesktop = "desktop"
+	ChannelWebhook = "webhook"
+	ChannelExec    = "exec"
+)
+
+// DefaultWebhookTarget names notifications.webhook in route actions
+const DefaultWebhookTarget = "default"
+
+// RouteRule sends notifications matching Match to the channels of its actions
+type RouteRule struct {
+	Name  string     `json:"name,omitempty"`
+	Match RouteMatch `json:"match"` // Empty = every notification
+
+	// Actions
+	Channels []string `json:"channels"`        // desktop, webhook, exec; [] = none. null/omitted = no channel choice
+	Webhooks []string `json:"webhooks"`        // Webhook target names ("default" = notifications.webhook)
+	Sound    *string  `json:"sound,omitempty"` // Desktop sound instead of the status's sound, "" = silent
+	Stop     bool     `json:"stop,omitempty"`  // Don't evaluate the rules after this one
+}
+
+// RouteMatch holds the conditions of a rule; all set fields must match. String fields are
+// patterns (see MatchPattern).
+type RouteMatch struct {
+	Status    *string      `json:"status,omitempty"`
+	CWD       *string      `json:"cwd,omitempty"` // Project path, e.g. "/home/me/work/*"
+	GitBranch *string      `json:"gitBranch,omitempty"`
+	Folder    *string      `json:"folder,omitempty"`
+	Session   *string      `json:"session,omitempty"`   // Session ID or label (e.g. "bold-cat")
+	Subagent  *bool        `json:"subagent,omitempty"`  // true = only subagents, false = only main sessions
+	TimeOfDay *QuietWindow `json:"timeOfDay,omitempty"` // In quietHours.timezone
+}
+
+// Matches returns true if all specified conditions match in
+func (m *RouteMatch) Matches(in MatchInput) bool {
+	if m.Subagent != nil && *m.Subagent != in.Subagent {
+		return false
+	}
+	if m.Session != nil && !MatchPattern(*m.Session, in.SessionID) && !MatchPattern(*m.Session, in.SessionName) {
+		return false
+	}
+	return matchConditions([]patternCondition{
+		{m.Status, in.Status},
+		{m.CWD, in.CWD},
+		{m.GitBranch, in.GitBranch},
+		{m.Folder, in.Folder},
+	}, m.TimeOfDay, in.Time)
+}
+
+// label names the rule in logs: its name, or routes[i]
+func (r *RouteRule) label(i int) string {
+	if r.Name != "" {
+		return fmt.Sprintf("%q", r.Name)
+	}
+	return fmt.Sprintf("routes[%d]", i)
+}
+
+// Route is where one notification goes
+type Route struct {
+	Rules    []string // Matched rules, in order (empty = per-status settings)
+	Desktop  bool
+	Webhook  bool
+	Exec     bool
+	Webhooks []string // Webhook targets chosen by the rules (nil = every target)
+	Sound    *string  // Desktop sound override (nil = the status's sound)
+}
+
+// Route evaluates notifications.routes in order for in. Every matching rule adds its
+// channels and webhook targets until a rule with stop; the first sound override wins.
+// If no matching rule names channels, the per-status settings apply. A channel must
+// still be enabled globally, and a disabled status is never sent. The webhook channel
+// is only chosen if an enabled target (of the named ones, if any) sends the status.
+// in.Time is converted to quietHours.timezone for timeOfDay conditions.
+func (c *Config) Route(in MatchInput) Route {
+	in.Time = in.Time.In(c.GetQuietHoursLocation())
+
+	var route Route
+	routed := false
+	channels := make(map[string]bool)
+	for i := range c.Notifications.Routes {
+		rule := &c.Notifications.Routes[i]
+		if !rule.Match.Matches(in) {
+			continue
+		}
+		route.Rules = append(route.Rules, rule.label(i))
+		if rule.Channels != nil || rule.Webhooks != nil {
+			routed = true
+		}
+		for _, ch := range rule.Channels {
+			channels[ch] = true
+		}
+		for _, target := range rule.Webhooks {
+			if !containsString(route.Webhooks, target) {
+				route.Webhooks = append(route.Webhooks, target)
+			}
+		}
+		if route.Sound == nil && rule.Sound != nil {
+			route.Sound = rule.Sound
+		}
+		if rule.Stop {
+			break
+		}
+	}
+
+	if !routed {
+		route.Desktop = c.IsStatusDesktopEnabled(in.Status)
+		route.Webhook = c.IsStatusWebhookEnabled(in.Status)
+		route.Exec = c.IsStatusExecEnabled(in.Status)
+		return route
+	}
+
+	enabled := c.IsStatusEnabled(in.Status)
+	route.Desktop = enabled && channels[ChannelDesktop] && c.IsDesktopEnabled()
+	route.Webhook = enabled && (channels[ChannelWebhook] || len(route.Webhooks) > 0) && len(c.WebhookTargetsFor(in.Status, route.Webhooks)) > 0
+	route.Exec = enabled && channels[ChannelExec] && c.IsExecEnabled()
+	return route
+}
+
+// validateRoutes reports invalid channels, webhook targets, patterns and windows of the routing rules
+func (c *Config) validateRoutes(add func(path, format string, args ...interface{})) {
+	validChannels := []string{ChannelDesktop, ChannelWebhook, ChannelExec}
+	targets := c.webhookTargetNames()
+	for i, rule := range c.Notifications.Routes {
+		path := fmt.Sprintf("notifications.routes[%d]", i)
+		for j, ch := range rule.Channels {
+			if !containsString(validChannels, ch) {
+				add(fmt.Sprintf("%s.channels[%d]", path, j), "routes[%d].channels[%d]: invalid channel %q (use %s)", i, j, ch, strings.Join(validChannels, ", "))
+			}
+		}
+		for j, target := range rule.Webhooks {
+			if !containsString(targets, target) {
+				add(fmt.Sprintf("%s.webhooks[%d]", path, j), "routes[%d].webhooks[%d]: unknown webhook target %q (use %s)", i, j, target, strings.Join(targets, ", "))
+			}
+		}
+
+		m := rule.Match
+		if m.Status != nil && !IsPattern(*m.Status) && !validStatuses[*m.Status] {
+			add(path+".match.status", "routes[%d].match: invalid status %q", i, *m.Status)
+		}
+		validatePatterns(fmt.Sprintf("routes[%d].match", i), map[string]*string{
+			"status": m.Status, "cwd": m.CWD, "gitBranch": m.GitBranch, "folder": m.Folder, "session": m.Session,
+		}, add)
+		if m.TimeOfDay != nil {
+			validateWindow(*m.TimeOfDay, fmt.Sprintf("routes[%d].match.timeOfDay", i), add)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// routingConfig enables desktop, webhook and exec and sets the routes
+func routingConfig(routes ...RouteRule) *Config {
+	cfg := DefaultConfig()
+	cfg.Notifications.Webhook = WebhookConfig{Enabled: true, Preset: "slack", Format: "json", URL: "https://hooks.example.com/x"}
+	cfg.Notifications.Exec = ExecConfig{Enabled: true, Command: []string{"notify"}}
+	cfg.Notifications.Routes = routes
+	return cfg
+}
+
+func TestRoute_NoRulesUsesStatusSettings(t *testing.T) {
+	cfg := routingConfig()
+	cfg.Notifications.Exec.Statuses = []string{"api_error"}
+
+	route := cfg.Route(MatchInput{Status: "task_complete", Time: time.Now()})
+	assert.Empty(t, route.Rules)
+	assert.True(t, route.Desktop)
+	assert.True(t, route.Webhook)
+	assert.False(t, route.Exec, "exec.statuses still applies without a rule")
+	assert.Nil(t, route.Sound)
+}
+
+func TestRoute_OrderAndStop(t *testing.T) {
+	pagerSound := "/sounds/alarm.mp3"
+	cfg := routingConfig(
+		RouteRule{Name: "errors to pager", Match: RouteMatch{Status: stringPtr("re:^api_error")},
+			Channels: []string{ChannelWebhook, ChannelExec}, Sound: &pagerSound, Stop: true},
+		RouteRule{Name: "questions on desktop", Match: RouteMatch{Status: stringPtr("question")},
+			Channels: []string{ChannelDesktop}, Stop: true},
+		RouteRule{Name: "main completions to slack", Match: RouteMatch{Status: stringPtr("task_complete"), GitBranch: stringPtr("main")},
+			Webhooks: []string{DefaultWebhookTarget}},
+		RouteRule{Name: "completions on desktop", Match: RouteMatch{Status: stringPtr("task_complete")},
+			Channels: []string{ChannelDesktop}},
+	)
+	now := time.Now()
+
+	route := cfg.Route(MatchInput{Status: "api_error_overloaded", Time: now})
+	assert.Equal(t, []string{`"errors to pager"`}, route.Rules)
+	assert.False(t, route.Desktop)
+	assert.True(t, route.Webhook)
+	assert.True(t, route.Exec, "a route selects exec regardless of exec.statuses")
+	assert.Equal(t, &pagerSound, route.Sound)
+
+	route = cfg.Route(MatchInput{Status: "question", GitBranch: "main", Time: now})
+	assert.Equal(t, []string{`"questions on desktop"`}, route.Rules)
+	assert.True(t, route.Desktop)
+	assert.False(t, route.Webhook)
+	assert.False(t, route.Exec)
+
+	route = cfg.Route(MatchInput{Status: "task_complete", GitBranch: "main", Time: now})
+	assert.Equal(t, []string{`"main completions to slack"`, `"completions on desktop"`}, route.Rules, "rules without stop accumulate")
+	assert.True(t, route.Desktop)
+	assert.True(t, route.Webhook)
+	assert.Equal(t, []string{DefaultWebhookTarget}, route.Webhooks)
+
+	route = cfg.Route(MatchInput{Status: "task_complete", GitBranch: "feature/x", Time: now})
+	assert.True(t, route.Desktop)
+	assert.False(t, route.Webhook)
+}
+
+func TestRoute_RespectsGlobalAndStatusSwitches(t *testing.T) {
+	disabled := false
+	cfg := routingConfig(RouteRule{Channels: []string{ChannelDesktop, ChannelWebhook}})
+	cfg.Notifications.Webhook.Enabled = false
+	info := cfg.Statuses["question"]
+	info.Enabled = &disabled
+	cfg.Statuses["question"] = info
+
+	route := cfg.Route(MatchInput{Status: "task_complete", Time: time.Now()})
+	assert.Equal(t, []string{"routes[0]"}, route.Rules, "an empty match matches everything")
+	assert.True(t, route.Desktop)
+	assert.False(t, route.Webhook, "webhook.enabled is still required")
+
+	route = cfg.Route(MatchInput{Status: "question", Time: time.Now()})
+	assert.False(t, route.Desktop, "a disabled status is never sent")
+
+	cfg.Notifications.Routes = []RouteRule{{Channels: []string{}}}
+	route = cfg.Route(MatchInput{Status: "task_complete", Time: time.Now()})
+	assert.False(t, route.Desktop || route.Webhook || route.Exec, "channels: [] sends nowhere")
+}
+
+func TestRouteMatch_Conditions(t *testing.T) {
+	in := MatchInput{
+		Status:      "task_complete",
+		CWD:         "/home/me/work/api",
+		Folder:      "api",
+		GitBranch:   "main",
+		SessionID:   "0b1c2d3e-aaaa",
+		SessionName: "bold-cat",
+		Subagent:    true,
+		Time:        time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC),
+	}
+
+	assert.True(t, (&RouteMatch{}).Matches(in))
+	assert.True(t, (&RouteMatch{CWD: stringPtr("/home/me/work/*")}).Matches(in))
+	assert.True(t, (&RouteMatch{Session: stringPtr("bold-cat")}).Matches(in))
+	assert.True(t, (&RouteMatch{Session: stringPtr("0b1c2d3e-*")}).Matches(in))
+	assert.False(t, (&RouteMatch{Session: stringPtr("calm-*")}).Matches(in))
+	assert.True(t, (&RouteMatch{Subagent: boolPtr(true)}).Matches(in))
+	assert.False(t, (&RouteMatch{Subagent: boolPtr(false)}).Matches(in))
+	assert.True(t, (&RouteMatch{TimeOfDay: &QuietWindow{Start: "09:00", End: "17:00"}}).Matches(in))
+	assert.False(t, (&RouteMatch{TimeOfDay: &QuietWindow{Start: "09:00", End: "17:00", Days: []string{"sat", "sun"}}}).Matches(in))
+	assert.False(t, (&RouteMatch{GitBranch: stringPtr("main"), Folder: stringPtr("web")}).Matches(in))
+}
+
+func TestValidate_Routes(t *testing.T) {
+	cfg := routingConfig(
+		RouteRule{Match: RouteMatch{Status: stringPtr("re:^api_")}, Channels: []string{ChannelWebhook}},
+		RouteRule{Match: RouteMatch{Status: stringPtr("questoin")}, Channels: []string{"pager"}, Webhooks: []string{"ops"}},
+		RouteRule{Match: RouteMatch{Session: stringPtr("re:("), TimeOfDay: &QuietWindow{Start: "9", End: "17:00"}}},
+	)
+
+	errs := cfg.ValidateAll()
+	paths := make([]string, 0, len(errs))
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	require.Equal(t, []string{
+		"notifications.routes[1].channels[0]",
+		"notifications.routes[1].webhooks[0]",
+		"notifications.routes[1].match.status",
+		"notifications.routes[2].match.session",
+		"notifications.routes[2].match.timeOfDay.start",
+	}, paths)
+	assert.Contains(t, errs[0].Message, `invalid channel "pager" (use desktop, webhook, exec)`)
+	assert.Contains(t, errs[1].Message, `unknown webhook target "ops"`)
+}
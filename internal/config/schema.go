@@ -0,0 +1,186 @@
+// ABOUTME: JSON Schema for config.json, generated from the config structs by reflection.
+// ABOUTME: Shipped as config/config.schema.json for editor completion and used by `config validate`.
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaID is the $id of the generated schema
+const SchemaID = "https://github.com/777genius/claude-notifications-go/config/config.schema.json"
+
+// JSONSchema is the subset of JSON Schema (draft-07) needed to describe the config
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"` // a type name, or a list of names for nullable values
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"` // false for structs, a *JSONSchema for maps
+	PropertyNames        *JSONSchema            `json:"propertyNames,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+}
+
+// Types returns the JSON types the schema accepts
+func (s *JSONSchema) Types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+// additional returns the schema for keys not listed in Properties, or nil if they are not allowed
+func (s *JSONSchema) additional() *JSONSchema {
+	extra, _ := s.AdditionalProperties.(*JSONSchema)
+	return extra
+}
+
+// schemaAnnotations adds what reflection can't see, keyed by path ("*" is a map value, "[]" an array item)
+var schemaAnnotations = map[string]JSONSchema{
+	"notifications.desktop.volume":                              {Minimum: float64Ptr(0), Maximum: float64Ptr(1)},
+	"notifications.webhook.preset":                              {Enum: webhookPresets},
+	"notifications.webhook.format":                              {Enum: []string{"json", "text"}},
+	"notifications.webhook.statuses[]":                          {Enum: statusNames()},
+	"notifications.webhooks[].preset":                           {Enum: webhookPresets},
+	"notifications.webhooks[].format":                           {Enum: []string{"json", "text"}},
+	"notifications.webhooks[].statuses[]":                       {Enum: statusNames()},
+	"notifications.exec.maxConcurrent":                          {Minimum: float64Ptr(0)},
+	"notifications.exec.statuses[]":                             {Enum: statusNames()},
+	"notifications.suppressQuestionAfterTaskCompleteSeconds":    {Minimum: float64Ptr(0)},
+	"notifications.suppressQuestionAfterAnyNotificationSeconds": {Minimum: float64Ptr(0)},
+	"notifications.notifyOnSubagentStop": {
+		Deprecated:  true,
+		Description: "Legacy gate for SubagentStop notifications; subagents are filtered by suppressForSubagents (transcript path detection)",
+	},
+	"notifications.suppressFilters[].status":         {Description: "Status name or pattern (glob, \"re:\" regex, \"!\" to negate)"},
+	"notifications.minTurnDurationSeconds":           {Minimum: float64Ptr(0)},
+	"notifications.minTurnDurationExemptStatuses[]":  {Enum: statusNames()},
+	"notifications.reminders.statuses[]":             {Enum: statusNames()},
+	"notifications.reminders.escalateToWebhookAfter": {Minimum: float64Ptr(0)},
+	"notifications.journal.maxEntries":               {Minimum: float64Ptr(0)},
+	"notifications.history.maxSizeMB":                {Minimum: float64Ptr(0)},
+	"notifications.quietHours.mode":                  {Enum: []string{"", QuietModeMute, QuietModeSuppress}},
+	"notifications.quietHours.breakThrough[]":        {Enum: statusNames()},
+	"notifications.routes[].match.status":            {Description: "Status name or pattern (glob, \"re:\" regex, \"!\" to negate)"},
+	"notifications.routes[].channels[]":              {Enum: []string{ChannelDesktop, ChannelWebhook, ChannelExec}},
+	"notifications.templates":                        {Description: "Go text/template strings for desktop and webhook messages (see README, Message Templates)"},
+	"statuses":                                       {PropertyNames: &JSONSchema{Enum: statusNames()}},
+	"statuses.*.minTurnDurationSeconds":              {Minimum: float64Ptr(0)},
+}
+
+// removedFields were read by earlier versions and are now ignored; validation warns instead of failing
+var removedFields = map[string]string{
+	"statuses.*.keywords": "no longer used (statuses are detected from the transcript, not keywords); it can be removed",
+}
+
+// GenerateSchema returns the JSON Schema describing config.json
+func GenerateSchema() *JSONSchema {
+	s := schemaFor(reflect.TypeOf(Config{}), "")
+	s.Schema = "http://json-schema.org/draft-07/schema#"
+	s.ID = SchemaID
+	s.Title = "claude-notifications config"
+	s.Properties["$schema"] = &JSONSchema{Type: "string"}
+	return s
+}
+
+// SchemaJSON returns the generated schema as indented JSON, as shipped in config/config.schema.json
+func SchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(GenerateSchema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func schemaFor(t reflect.Type, path string) *JSONSchema {
+	nullable := false
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	s := &JSONSchema{}
+	var typ string
+	switch t.Kind() {
+	case reflect.Struct:
+		typ = "object"
+		s.Properties = make(map[string]*JSONSchema)
+		s.AdditionalProperties = false
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			s.Properties[name] = schemaFor(field.Type, joinPath(path, name))
+		}
+	case reflect.Map:
+		typ = "object"
+		nullable = true
+		s.AdditionalProperties = schemaFor(t.Elem(), path+".*")
+	case reflect.Slice:
+		typ = "array"
+		nullable = true
+		s.Items = schemaFor(t.Elem(), path+"[]")
+	case reflect.String:
+		typ = "string"
+	case reflect.Bool:
+		typ = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		typ = "integer"
+	case reflect.Float32, reflect.Float64:
+		typ = "number"
+	}
+
+	// encoding/json accepts null for pointers, maps and slices and leaves the default in place
+	if nullable {
+		s.Type = []string{typ, "null"}
+	} else {
+		s.Type = typ
+	}
+
+	if a, ok := schemaAnnotations[path]; ok {
+		s.Description = a.Description
+		s.Deprecated = a.Deprecated
+		s.Enum = a.Enum
+		s.Minimum = a.Minimum
+		s.Maximum = a.Maximum
+		s.PropertyNames = a.PropertyNames
+	}
+	return s
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// statusNames returns the valid status names, sorted
+func statusNames() []string {
+	names := make([]string, 0, len(validStatuses))
+	for name := range validStatuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_MatchesShippedFile(t *testing.T) {
+	generated, err := SchemaJSON()
+	require.NoError(t, err)
+
+	shipped, err := os.ReadFile(filepath.Join("..", "..", "config", "config.schema.json"))
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(shipped), "config/config.schema.json is stale, run `make schema`")
+}
+
+func TestSchema_DescribesStructs(t *testing.T) {
+	s := GenerateSchema()
+
+	notifications := s.Properties["notifications"]
+	require.NotNil(t, notifications)
+	assert.Equal(t, false, notifications.AdditionalProperties)
+	assert.Equal(t, "number", notifications.Properties["desktop"].Properties["volume"].Type)
+	assert.Equal(t, []string{"integer", "null"}, notifications.Properties["minTurnDurationSeconds"].Type)
+	assert.True(t, notifications.Properties["notifyOnSubagentStop"].Deprecated)
+
+	statuses := s.Properties["statuses"]
+	require.NotNil(t, statuses.additional())
+	assert.Contains(t, statuses.PropertyNames.Enum, "task_complete")
+	assert.Contains(t, statuses.additional().Properties, "sound")
+}
+
+func TestValidateJSON_ShippedConfig(t *testing.T) {
+	problems, err := ValidateFile(filepath.Join("..", "..", "config", "config.json"))
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestValidateJSON_DefaultConfig(t *testing.T) {
+	data, err := json.Marshal(DefaultConfig())
+	require.NoError(t, err)
+
+	// Only the deprecated field, which the defaults still carry
+	problems := ValidateJSON(data)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "notifications.notifyOnSubagentStop", problems[0].Path)
+	assert.Equal(t, SeverityWarning, problems[0].Severity)
+}
+
+func TestValidateJSON_ReportsEveryProblem(t *testing.T) {
+	data := []byte(`{
+  "notifications": {
+    "desktop": {"enabled": true, "voluem": 0.5},
+    "webhook": {"enabled": true, "preset": "slak", "url": "https://example.com"},
+    "exec": {"enabled": true},
+    "notifyOnSubagentStop": true,
+    "minTurnDurationSeconds": "30"
+  },
+  "statuses": {
+    "task_complete": {"title": "Done", "keywords": ["done"]},
+    "questoin": {"title": "?"}
+  }
+}`)
+
+	problems := ValidateJSON(data)
+
+	byPath := make(map[string]Problem)
+	for _, p := range problems {
+		byPath[p.Path] = p
+	}
+	require.Len(t, byPath, len(problems), "one problem per path: %v", problems)
+
+	expect := []struct {
+		path, severity, contains string
+		line, col                int
+	}{
+		{"notifications.desktop.voluem", SeverityError, `unknown key "voluem" (did you mean "volume"?)`, 3, 34},
+		{"notifications.webhook.preset", SeverityError, `invalid value "slak"`, 4, 44},
+		{"notifications.exec.command", SeverityError, "exec.command is required", 5, 13},
+		{"notifications.notifyOnSubagentStop", SeverityWarning, "deprecated", 6, 5},
+		{"notifications.minTurnDurationSeconds", SeverityError, `expected integer or null, got string "30"`, 7, 31},
+		{"statuses.task_complete.keywords", SeverityWarning, "no longer used", 10, 40},
+		{"statuses.questoin", SeverityError, `unknown status "questoin" (did you mean "question"?)`, 11, 5},
+	}
+	require.Len(t, problems, len(expect))
+	for i, e := range expect {
+		p := byPath[e.path]
+		assert.Equal(t, e.path, problems[i].Path, "problems are ordered by position")
+		assert.Equal(t, e.severity, p.Severity, e.path)
+		assert.Contains(t, p.Message, e.contains, e.path)
+		assert.Equal(t, e.line, p.Line, e.path)
+		assert.Equal(t, e.col, p.Column, e.path)
+	}
+	assert.True(t, HasErrors(problems))
+}
+
+func TestValidateJSON_WarningsOnly(t *testing.T) {
+	problems := ValidateJSON([]byte(`{"notifications": {"notifyOnSubagentStop": false, "desktop": {"sound": true, "sound": false}}}`))
+	require.Len(t, problems, 2)
+	assert.Contains(t, problems[0].Message, "deprecated")
+	assert.Contains(t, problems[1].Message, `duplicate key "sound"`)
+	assert.False(t, HasErrors(problems))
+}
+
+func TestValidateJSON_SyntaxError(t *testing.T) {
+	problems := ValidateJSON([]byte("{\n  \"notifications\": {\n    \"desktop\": {\"enabled\": true,}\n  }\n}"))
+	require.Len(t, problems, 1)
+	assert.Equal(t, SeverityError, problems[0].Severity)
+	assert.Equal(t, 3, problems[0].Line)
+	assert.Equal(t, 32, problems[0].Column)
+	assert.Contains(t, problems[0].Message, "trailing comma")
+	assert.Empty(t, problems[0].Path)
+
+	problems = ValidateJSON([]byte(`{"notifications": `))
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Message, "unexpected end")
+}
+
+func TestValidateAll_CollectsEveryError(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Notifications.Desktop.Volume = 2
+	cfg.Notifications.Journal.MaxEntries = -1
+	cfg.Notifications.History.MaxSizeMB = -1
+
+	errs := cfg.ValidateAll()
+	require.Len(t, errs, 3)
+	assert.Equal(t, "notifications.desktop.volume", errs[0].Path)
+	assert.Equal(t, "notifications.journal.maxEntries", errs[1].Path)
+	assert.Equal(t, "notifications.history.maxSizeMB", errs[2].Path)
+	assert.EqualError(t, cfg.Validate(), errs[0].Message)
+}
+
+func TestDidYouMean(t *testing.T) {
+	keys := []string{"enabled", "sound", "volume", "terminalBell"}
+	assert.Equal(t, ` (did you mean "volume"?)`, didYouMean("volme", keys))
+	assert.Equal(t, ` (did you mean "terminalBell"?)`, didYouMean("terminalbell", keys))
+	assert.Equal(t, "", didYouMean("colour", keys))
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Message templates (notifications.templates and statuses.<name>.templates) per channel.
+// ABOUTME: Empty fields fall back to the global template, then to the built-in default layout.
+package config
+
+import (
+	"github.com/777genius/claude-notifications/internal/msgtemplate"
+)
+
+// TemplatesConfig holds text/template strings per channel; empty = inherit
+type TemplatesConfig struct {
+	Desktop DesktopTemplates `json:"desktop"`
+	Webhook WebhookTemplates `json:"webhook"`
+}
+
+// DesktopTemplates render the parts of a desktop notification
+type DesktopTemplates struct {
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"` // macOS only
+	Body     string `json:"body,omitempty"`
+}
+
+// WebhookTemplates render the message sent to webhooks (the title stays the status title)
+type WebhookTemplates struct {
+	Text string `json:"text,omitempty"`
+}
+
+// GetTemplates returns the templates for status: the status's own, else the global
+// ones, else the built-in defaults
+func (c *Config) GetTemplates(status string) TemplatesConfig {
+	global := c.Notifications.Templates
+	var own TemplatesConfig
+	if info, ok := c.Statuses[status]; ok && info.Templates != nil {
+		own = *info.Templates
+	}
+	return TemplatesConfig{
+		Desktop: DesktopTemplates{
+			Title:    firstNonEmpty(own.Desktop.Title, global.Desktop.Title, msgtemplate.DefaultDesktopTitle),
+			Subtitle: firstNonEmpty(own.Desktop.Subtitle, global.Desktop.Subtitle, msgtemplate.DefaultDesktopSubtitle),
+			Body:     firstNonEmpty(own.Desktop.Body, global.Desktop.Body, msgtemplate.DefaultDesktopBody),
+		},
+		Webhook: WebhookTemplates{
+			Text: firstNonEmpty(own.Webhook.Text, global.Webhook.Text, msgtemplate.DefaultWebhookText),
+		},
+	}
+}
+
+// validateTemplates reports templates that don't parse or use unknown fields or functions
+func (c *Config) validateTemplates(add func(path, format string, args ...interface{})) {
+	c.Notifications.Templates.validate("notifications.templates", add)
+	for _, name := range statusNames() {
+		if info, ok := c.Statuses[name]; ok && info.Templates != nil {
+			info.Templates.validate("statuses."+name+".templates", add)
+		}
+	}
+}
+
+func (t *TemplatesConfig) validate(path string, add func(path, format string, args ...interface{})) {
+	fields := []struct {
+		name, text string
+	}{
+		{"desktop.title", t.Desktop.Title},
+		{"desktop.subtitle", t.Desktop.Subtitle},
+		{"desktop.body", t.Desktop.Body},
+		{"webhook.text", t.Webhook.Text},
+	}
+	for _, f := range fields {
+		if f.text == "" {
+			continue
+		}
+		if err := msgtemplate.Check(f.text); err != nil {
+			add(path+"."+f.name, "%s.%s: invalid template: %v", path, f.name, err)
+		}
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/777genius/claude-notifications/internal/msgtemplate"
+)
+
+func TestGetTemplates_StatusThenGlobalThenDefault(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, msgtemplate.DefaultDesktopTitle, cfg.GetTemplates("question").Desktop.Title)
+	assert.Equal(t, msgtemplate.DefaultWebhookText, cfg.GetTemplates("question").Webhook.Text)
+
+	cfg.Notifications.Templates = TemplatesConfig{
+		Desktop: DesktopTemplates{Title: "{{.Title}}", Body: "{{.Summary}} ({{.Folder}})"},
+	}
+	info := cfg.Statuses["question"]
+	info.Templates = &TemplatesConfig{Desktop: DesktopTemplates{Body: "{{.Question}}"}}
+	cfg.Statuses["question"] = info
+
+	question := cfg.GetTemplates("question")
+	assert.Equal(t, "{{.Title}}", question.Desktop.Title, "from the global templates")
+	assert.Equal(t, "{{.Question}}", question.Desktop.Body, "the status's own template wins")
+	assert.Equal(t, msgtemplate.DefaultDesktopSubtitle, question.Desktop.Subtitle)
+	assert.Equal(t, msgtemplate.DefaultWebhookText, question.Webhook.Text)
+
+	assert.Equal(t, "{{.Summary}} ({{.Folder}})", cfg.GetTemplates("task_complete").Desktop.Body)
+}
+
+func TestValidate_Templates(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Notifications.Templates = TemplatesConfig{
+		Desktop: DesktopTemplates{Title: "{{.Title", Body: "{{.Summary}}"},
+		Webhook: WebhookTemplates{Text: "{{.Nope}}"},
+	}
+	info := cfg.Statuses["task_complete"]
+	info.Templates = &TemplatesConfig{Desktop: DesktopTemplates{Subtitle: "{{shout .Branch}}"}}
+	cfg.Statuses["task_complete"] = info
+
+	var paths []string
+	for _, e := range cfg.ValidateAll() {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{
+		"notifications.templates.desktop.title",
+		"notifications.templates.webhook.text",
+		"statuses.task_complete.templates.desktop.subtitle",
+	}, paths)
+}
@@ -0,0 +1,471 @@
+// ABOUTME: Validates a config file with positions: JSON syntax, the schema (types, unknown keys, deprecations) and Validate.
+// ABOUTME: Backs `claude-notifications config validate`, which reports every problem at once.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Problem severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Problem is one finding in a config file
+type Problem struct {
+	Path     string `json:"path,omitempty"` // e.g. "notifications.webhook.preset"; empty for syntax errors
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+
+	offset int
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return fmt.Sprintf("%d:%d: %s: %s", p.Line, p.Column, p.Severity, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s: %s", p.Line, p.Column, p.Severity, p.Path, p.Message)
+}
+
+// HasErrors reports whether any of the problems is an error rather than a warning
+func HasErrors(problems []Problem) bool {
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateFile validates the config file at path. The error is only set if the file can't be read.
+func ValidateFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return ValidateJSON(data), nil
+}
+
+// ValidateJSON validates config file contents: JSON syntax, then types, enums and unknown keys
+// against the schema, then the checks of Validate on the config Load would produce.
+// Every problem is returned, ordered by position.
+func ValidateJSON(data []byte) []Problem {
+	v := &fileValidator{data: data, reported: make(map[string]bool)}
+
+	root, err := parseJSONNode(data)
+	if err != nil {
+		var perr *jsonPosError
+		if errors.As(err, &perr) {
+			v.add(perr.offset, "", SeverityError, perr.msg)
+		}
+		return v.problems
+	}
+	v.check(root, GenerateSchema(), "", "")
+
+	// Values the schema rejected keep their defaults here, so they aren't reported twice
+	cfg := DefaultConfig()
+	_ = json.Unmarshal(data, cfg)
+	cfg.ApplyDefaults()
+	for _, fe := range cfg.ValidateAll() {
+		if v.reportedAt(fe.Path) {
+			continue
+		}
+		v.add(root.lookup(fe.Path).offset, fe.Path, SeverityError, fe.Message)
+	}
+
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].offset < v.problems[j].offset
+	})
+	return v.problems
+}
+
+type fileValidator struct {
+	data     []byte
+	problems []Problem
+	reported map[string]bool // paths with an error, so semantic checks skip them
+}
+
+func (v *fileValidator) add(offset int, path, severity, msg string) {
+	line, col := position(v.data, offset)
+	v.problems = append(v.problems, Problem{Path: path, Line: line, Column: col, Severity: severity, Message: msg, offset: offset})
+	if severity == SeverityError {
+		v.reported[path] = true
+	}
+}
+
+// reportedAt reports whether path or one of its parents already has an error
+func (v *fileValidator) reportedAt(path string) bool {
+	for path != "" {
+		if v.reported[path] {
+			return true
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return false
+}
+
+// check validates n against s. path is the concrete path ("statuses.question.title"),
+// pattern the schema path ("statuses.*.title") used for removedFields.
+func (v *fileValidator) check(n *jsonNode, s *JSONSchema, path, pattern string) {
+	if s.Deprecated {
+		v.add(n.keyPos(), path, SeverityWarning, "deprecated: "+s.Description)
+	}
+
+	types := s.Types()
+	if !n.hasType(types) {
+		v.add(n.offset, path, SeverityError, fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), n.describe()))
+		return
+	}
+
+	switch n.kind {
+	case "string":
+		value := n.value.(string)
+		if len(s.Enum) > 0 && !containsString(s.Enum, value) {
+			v.add(n.offset, path, SeverityError, fmt.Sprintf("invalid value %q (must be one of: %s)%s", value, quoteList(s.Enum), didYouMean(value, s.Enum)))
+		}
+	case "number":
+		value, _ := n.value.(json.Number).Float64()
+		if s.Minimum != nil && value < *s.Minimum {
+			v.add(n.offset, path, SeverityError, fmt.Sprintf("must be >= %g (got %s)", *s.Minimum, n.value))
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			v.add(n.offset, path, SeverityError, fmt.Sprintf("must be <= %g (got %s)", *s.Maximum, n.value))
+		}
+	case "array":
+		for i, item := range n.items {
+			v.check(item, s.Items, fmt.Sprintf("%s[%d]", path, i), pattern+"[]")
+		}
+	case "object":
+		for _, dup := range n.duplicates {
+			v.add(dup.keyPos(), joinPath(path, dup.key), SeverityWarning, fmt.Sprintf("duplicate key %q, the last value wins", dup.key))
+		}
+		for _, key := range n.keys {
+			v.checkMember(n.fields[key], s, key, path, pattern)
+		}
+	}
+}
+
+func (v *fileValidator) checkMember(child *jsonNode, s *JSONSchema, key, path, pattern string) {
+	childPath := joinPath(path, key)
+	if prop, ok := s.Properties[key]; ok {
+		v.check(child, prop, childPath, joinPath(pattern, key))
+		return
+	}
+	if extra := s.additional(); extra != nil {
+		if s.PropertyNames != nil && !containsString(s.PropertyNames.Enum, key) {
+			v.add(child.keyPos(), childPath, SeverityError, fmt.Sprintf("unknown status %q%s", key, didYouMean(key, s.PropertyNames.Enum)))
+			return
+		}
+		v.check(child, extra, childPath, pattern+".*")
+		return
+	}
+	if note, ok := removedFields[joinPath(pattern, key)]; ok {
+		v.add(child.keyPos(), childPath, SeverityWarning, note)
+		return
+	}
+
+	known := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+	v.add(child.keyPos(), childPath, SeverityError, fmt.Sprintf("unknown key %q%s", key, didYouMean(key, known)))
+}
+
+// jsonNode is a parsed JSON value with its position in the file
+type jsonNode struct {
+	kind       string // object, array, string, number, boolean or null
+	offset     int    // start of the value
+	key        string // member name in the parent object
+	keyOffset  int    // start of the member name, -1 outside objects
+	value      interface{}
+	keys       []string // object member names in file order
+	fields     map[string]*jsonNode
+	duplicates []*jsonNode // members whose name appeared before
+	items      []*jsonNode
+}
+
+// keyPos returns the position to report for the member itself: its name, or the value in arrays
+func (n *jsonNode) keyPos() int {
+	if n.keyOffset >= 0 {
+		return n.keyOffset
+	}
+	return n.offset
+}
+
+func (n *jsonNode) hasType(types []string) bool {
+	for _, t := range types {
+		switch {
+		case t == n.kind:
+			return true
+		case t == "integer" && n.kind == "number":
+			if _, err := n.value.(json.Number).Int64(); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *jsonNode) describe() string {
+	switch n.kind {
+	case "string":
+		return fmt.Sprintf("string %q", n.value)
+	case "number", "boolean":
+		return fmt.Sprintf("%s %v", n.kind, n.value)
+	}
+	return n.kind
+}
+
+// lookup returns the node at path, or its deepest existing parent
+func (n *jsonNode) lookup(path string) *jsonNode {
+	current := n
+	for _, segment := range strings.Split(path, ".") {
+		name := segment
+		var indexes []int
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			name = segment[:i]
+			for _, part := range strings.Split(strings.Trim(segment[i:], "[]"), "][") {
+				var idx int
+				if _, err := fmt.Sscanf(part, "%d", &idx); err == nil {
+					indexes = append(indexes, idx)
+				}
+			}
+		}
+
+		next, ok := current.fields[name]
+		if !ok {
+			return current
+		}
+		current = next
+		for _, idx := range indexes {
+			if idx >= len(current.items) {
+				return current
+			}
+			current = current.items[idx]
+		}
+	}
+	return current
+}
+
+// jsonPosError is a syntax error at a byte offset
+type jsonPosError struct {
+	offset int
+	msg    string
+}
+
+func (e *jsonPosError) Error() string {
+	return e.msg
+}
+
+type jsonNodeParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// parseJSONNode parses data into a tree of nodes that remember where each value starts
+func parseJSONNode(data []byte) (*jsonNode, error) {
+	p := &jsonNodeParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.dec.Token(); err != io.EOF {
+		return nil, &jsonPosError{offset: p.next(), msg: "unexpected data after the top-level value"}
+	}
+	return root, nil
+}
+
+// next returns the offset of the next token, skipping whitespace and separators
+func (p *jsonNodeParser) next() int {
+	offset := int(p.dec.InputOffset())
+	for offset < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func (p *jsonNodeParser) token() (json.Token, error) {
+	tok, err := p.dec.Token()
+	if err == nil {
+		return tok, nil
+	}
+	var syntax *json.SyntaxError
+	switch {
+	case errors.As(err, &syntax):
+		offset := int(syntax.Offset) - 1
+		if offset < 0 {
+			offset = 0
+		}
+		msg := syntax.Error()
+		if offset < len(p.data) && p.data[offset] == ',' {
+			rest := bytes.TrimLeft(p.data[offset+1:], " \t\r\n")
+			if len(rest) > 0 && (rest[0] == '}' || rest[0] == ']') {
+				msg = "trailing comma (not allowed in JSON)"
+			}
+		}
+		return nil, &jsonPosError{offset: offset, msg: msg}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return nil, &jsonPosError{offset: len(p.data), msg: "unexpected end of JSON input"}
+	}
+	return nil, &jsonPosError{offset: p.next(), msg: err.Error()}
+}
+
+func (p *jsonNodeParser) parse() (*jsonNode, error) {
+	offset := p.next()
+	tok, err := p.token()
+	if err != nil {
+		return nil, err
+	}
+	node := &jsonNode{offset: offset, keyOffset: -1, value: tok}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		node.value = nil
+		if t == '{' {
+			node.kind = "object"
+			node.fields = make(map[string]*jsonNode)
+			for p.dec.More() {
+				keyOffset := p.next()
+				keyTok, err := p.token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				child, err := p.parse()
+				if err != nil {
+					return nil, err
+				}
+				child.key, child.keyOffset = key, keyOffset
+				if previous, dup := node.fields[key]; dup {
+					node.duplicates = append(node.duplicates, child)
+					// encoding/json keeps the last value; check that one
+					*previous = *child
+					continue
+				}
+				node.keys = append(node.keys, key)
+				node.fields[key] = child
+			}
+		} else {
+			node.kind = "array"
+			for p.dec.More() {
+				item, err := p.parse()
+				if err != nil {
+					return nil, err
+				}
+				node.items = append(node.items, item)
+			}
+		}
+		if _, err := p.token(); err != nil { // closing delimiter
+			return nil, err
+		}
+	case string:
+		node.kind = "string"
+	case json.Number:
+		node.kind = "number"
+	case bool:
+		node.kind = "boolean"
+	case nil:
+		node.kind = "null"
+	}
+	return node, nil
+}
+
+// position converts a byte offset into a 1-based line and column (in characters)
+func position(data []byte, offset int) (line, col int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	before := data[:offset]
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return bytes.Count(before, []byte{'\n'}) + 1, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+// didYouMean returns a " (did you mean ...?)" hint for the closest candidate, or ""
+func didYouMean(word string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		d := levenshtein(strings.ToLower(word), strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+
+	limit := utf8.RuneCountInString(word) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	if bestDist < 0 || bestDist > limit {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,167 @@
+// ABOUTME: Webhook targets: notifications.webhook (named "default") plus the named targets of notifications.webhooks.
+// ABOUTME: Each target has its own preset, URL, retry, circuit breaker, rate limit and statuses.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// webhookPresets are the valid values of preset
+var webhookPresets = []string{"slack", "discord", "telegram", "lark", "teams", "ntfy", "gotify", "matrix", "custom"}
+
+// webhookNameRegexp restricts target names to what reads well in logs and routes
+var webhookNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// WebhookTargets is the notifications.webhooks list. Unset fields of a target take the
+// defaults of notifications.webhook, except that a target is enabled unless it says otherwise.
+type WebhookTargets []WebhookConfig
+
+// UnmarshalJSON decodes each target over the defaults
+func (w *WebhookTargets) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return nil // null keeps the current value, like other lists
+	}
+	targets := make(WebhookTargets, len(raw))
+	for i, item := range raw {
+		targets[i] = defaultWebhookConfig()
+		targets[i].Enabled = true
+		if err := json.Unmarshal(item, &targets[i]); err != nil {
+			return err
+		}
+	}
+	*w = targets
+	return nil
+}
+
+// applyDefaults fills in an empty preset, format and headers
+func (w *WebhookConfig) applyDefaults() {
+	if w.Preset == "" {
+		w.Preset = "custom"
+	}
+	if w.Format == "" {
+		w.Format = "json"
+	}
+	if w.Headers == nil {
+		w.Headers = make(map[string]string)
+	}
+}
+
+// AcceptsStatus reports whether the target sends status (statuses empty = every status)
+func (w *WebhookConfig) AcceptsStatus(status string) bool {
+	return len(w.Statuses) == 0 || containsString(w.Statuses, status)
+}
+
+// WebhookTargets returns every enabled webhook target: notifications.webhook as
+// "default", then notifications.webhooks in order
+func (c *Config) WebhookTargets() []WebhookConfig {
+	var targets []WebhookConfig
+	if c.Notifications.Webhook.Enabled {
+		legacy := c.Notifications.Webhook
+		legacy.Name = DefaultWebhookTarget
+		targets = append(targets, legacy)
+	}
+	for _, target := range c.Notifications.Webhooks {
+		if target.Enabled {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// WebhookTargetsFor returns the enabled targets that send status, limited to names
+// unless names is nil
+func (c *Config) WebhookTargetsFor(status string, names []string) []WebhookConfig {
+	var targets []WebhookConfig
+	for _, target := range c.WebhookTargets() {
+		if names != nil && !containsString(names, target.Name) {
+			continue
+		}
+		if target.AcceptsStatus(status) {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// webhookTargetNames returns the names routes may use: "default" and every configured target
+func (c *Config) webhookTargetNames() []string {
+	names := []string{DefaultWebhookTarget}
+	for _, target := range c.Notifications.Webhooks {
+		names = append(names, target.Name)
+	}
+	return names
+}
+
+// validateWebhooks reports problems in notifications.webhook and notifications.webhooks
+func (c *Config) validateWebhooks(add func(path, format string, args ...interface{})) {
+	if name := c.Notifications.Webhook.Name; name != "" && name != DefaultWebhookTarget {
+		add("notifications.webhook.name", "webhook.name: notifications.webhook is always named %q; use notifications.webhooks for other names", DefaultWebhookTarget)
+	}
+	validateWebhook(c.Notifications.Webhook, "notifications.webhook", "", add)
+
+	seen := make(map[string]bool)
+	for i, target := range c.Notifications.Webhooks {
+		path := fmt.Sprintf("notifications.webhooks[%d]", i)
+		label := fmt.Sprintf("webhooks[%d]: ", i)
+		switch {
+		case target.Name == "":
+			add(path+".name", "%sname is required", label)
+		case target.Name == DefaultWebhookTarget:
+			add(path+".name", "%sname %q is reserved for notifications.webhook", label, target.Name)
+		case !webhookNameRegexp.MatchString(target.Name):
+			add(path+".name", "%sinvalid name %q (use letters, digits, '.', '_' and '-')", label, target.Name)
+		case seen[target.Name]:
+			add(path+".name", "%sduplicate name %q", label, target.Name)
+		}
+		seen[target.Name] = true
+		validateWebhook(target, path, label, add)
+	}
+}
+
+// validateWebhook checks one target's preset, format, URL, chat_id, room_id, token and statuses if it is enabled
+func validateWebhook(w WebhookConfig, path, label string, add func(path, format string, args ...interface{})) {
+	if !w.Enabled {
+		return
+	}
+	if !containsString(webhookPresets, w.Preset) {
+		add(path+".preset", "%sinvalid webhook preset: %s (must be one of: %s)", label, w.Preset, strings.Join(webhookPresets, ", "))
+	}
+	if w.Format != "json" && w.Format != "text" {
+		add(path+".format", "%sinvalid webhook format: %s (must be one of: json, text)", label, w.Format)
+	}
+	if w.URL == "" {
+		add(path+".url", "%swebhook URL is required when webhooks are enabled", label)
+	}
+	if w.Preset == "telegram" && w.ChatID == "" {
+		add(path+".chat_id", "%schat_id is required for Telegram webhook", label)
+	}
+	if w.Preset == "gotify" && w.Token == "" {
+		add(path+".token", "%stoken (the application token) is required for Gotify webhook", label)
+	}
+	if w.Preset == "matrix" {
+		if w.Token == "" {
+			add(path+".token", "%stoken (the access token) is required for Matrix webhook", label)
+		}
+		if !strings.HasPrefix(w.RoomID, "!") {
+			add(path+".room_id", "%sroom_id (e.g. !abc123:matrix.org, not an #alias) is required for Matrix webhook", label)
+		}
+	}
+	if w.Preset == "ntfy" && w.URL != "" {
+		if u, err := url.Parse(w.URL); err != nil || strings.Trim(u.Path, "/") == "" {
+			add(path+".url", "%sntfy URL must end with the topic, e.g. https://ntfy.sh/my-topic", label)
+		}
+	}
+	for i, s := range w.Statuses {
+		if !validStatuses[s] {
+			add(fmt.Sprintf("%s.statuses[%d]", path, i), "%sstatuses[%d]: invalid status %q", label, i, s)
+		}
+	}
+}
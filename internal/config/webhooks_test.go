@@ -0,0 +1,135 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookTargets_UnmarshalStartsFromDefaults(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, json.Unmarshal([]byte(`{"notifications": {"webhooks": [
+		{"name": "team", "preset": "slack", "url": "https://hooks.slack.com/x", "retry": {"maxAttempts": 5}},
+		{"name": "phone", "enabled": false, "url": "https://ntfy.sh/me", "circuitBreaker": {"enabled": false}}
+	]}}`), cfg))
+	cfg.ApplyDefaults()
+
+	targets := cfg.Notifications.Webhooks
+	require.Len(t, targets, 2)
+	assert.True(t, targets[0].Enabled, "targets are enabled unless they say otherwise")
+	assert.Equal(t, "json", targets[0].Format)
+	assert.Equal(t, 5, targets[0].Retry.MaxAttempts)
+	assert.True(t, targets[0].Retry.Enabled, "unset fields keep the defaults")
+	assert.Equal(t, "1s", targets[0].Retry.InitialBackoff)
+	assert.True(t, targets[0].RateLimit.Enabled)
+	assert.False(t, targets[1].Enabled)
+	assert.Equal(t, "custom", targets[1].Preset)
+	assert.False(t, targets[1].CircuitBreaker.Enabled)
+	assert.Equal(t, 5, targets[1].CircuitBreaker.FailureThreshold)
+	assert.Empty(t, cfg.ValidateAll())
+}
+
+func TestWebhookTargets_LegacyIsDefault(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.False(t, cfg.IsWebhookEnabled())
+
+	cfg.Notifications.Webhook = WebhookConfig{Enabled: true, Preset: "custom", Format: "json", URL: "https://example.com/hook"}
+	cfg.Notifications.Webhooks = WebhookTargets{
+		{Name: "team", Enabled: true, URL: "https://example.com/team", Statuses: []string{"question"}},
+		{Name: "off", Enabled: false, URL: "https://example.com/off"},
+	}
+
+	var names []string
+	for _, target := range cfg.WebhookTargets() {
+		names = append(names, target.Name)
+	}
+	assert.Equal(t, []string{DefaultWebhookTarget, "team"}, names, "enabled targets in order")
+	assert.Len(t, cfg.WebhookTargetsFor("task_complete", nil), 1, "team only sends questions")
+	assert.Len(t, cfg.WebhookTargetsFor("question", nil), 2)
+	assert.Len(t, cfg.WebhookTargetsFor("question", []string{"team", "off"}), 1)
+	assert.Empty(t, cfg.WebhookTargetsFor("task_complete", []string{"team"}))
+
+	cfg.Notifications.Webhook.Enabled = false
+	assert.True(t, cfg.IsWebhookEnabled(), "a target in webhooks is enough")
+	assert.True(t, cfg.IsStatusWebhookEnabled("question"))
+	assert.False(t, cfg.IsStatusWebhookEnabled("task_complete"))
+}
+
+func TestValidate_WebhookTargets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Notifications.Webhook.Name = "main"
+	cfg.Notifications.Webhooks = WebhookTargets{
+		{Name: "", Enabled: true, Preset: "custom", Format: "json", URL: "https://example.com/a"},
+		{Name: "default", Enabled: true, Preset: "custom", Format: "json", URL: "https://example.com/b"},
+		{Name: "team", Enabled: true, Preset: "telegram", Format: "json", URL: "https://example.com/c"},
+		{Name: "team", Enabled: true, Preset: "custom", Format: "xml", URL: "https://example.com/d", Statuses: []string{"done"}},
+		{Name: "bad name", Enabled: false},
+	}
+	cfg.Notifications.Routes = []RouteRule{{Webhooks: []string{"team", "default", "nope"}}}
+
+	var paths []string
+	for _, e := range cfg.ValidateAll() {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{
+		"notifications.webhook.name",
+		"notifications.webhooks[0].name",
+		"notifications.webhooks[1].name",
+		"notifications.webhooks[2].chat_id",
+		"notifications.webhooks[3].name",
+		"notifications.webhooks[3].format",
+		"notifications.webhooks[3].statuses[0]",
+		"notifications.webhooks[4].name",
+		"notifications.routes[0].webhooks[2]",
+	}, paths)
+}
+
+func TestApplyEnv_WebhookTargets(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Empty(t, cfg.ApplyEnv([]string{
+		`CLAUDE_NOTIFICATIONS_WEBHOOKS=[{"name": "ci", "url": "https://example.com/ci"}]`,
+	}))
+	require.Len(t, cfg.Notifications.Webhooks, 1)
+	assert.True(t, cfg.Notifications.Webhooks[0].Enabled)
+	assert.True(t, cfg.Notifications.Webhooks[0].Retry.Enabled)
+	assert.Equal(t, "env: CLAUDE_NOTIFICATIONS_WEBHOOKS", cfg.Source("notifications.webhooks[0].url"))
+}
+
+func TestValidate_PushPresets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Notifications.Webhooks = WebhookTargets{
+		{Name: "ntfy-ok", Enabled: true, Preset: "ntfy", Format: "json", URL: "https://ntfy.sh/alerts"},
+		{Name: "ntfy-root", Enabled: true, Preset: "ntfy", Format: "json", URL: "https://ntfy.sh/"},
+		{Name: "gotify-ok", Enabled: true, Preset: "gotify", Format: "json", URL: "https://push.example.com", Token: "A1b2"},
+		{Name: "gotify-no-token", Enabled: true, Preset: "gotify", Format: "json", URL: "https://push.example.com"},
+	}
+
+	var paths []string
+	for _, e := range cfg.ValidateAll() {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{
+		"notifications.webhooks[1].url",
+		"notifications.webhooks[3].token",
+	}, paths)
+}
+
+func TestValidate_MatrixPreset(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Notifications.Webhooks = WebhookTargets{
+		{Name: "ok", Enabled: true, Preset: "matrix", Format: "json", URL: "https://matrix.org", RoomID: "!abc:matrix.org", Token: "syt_x"},
+		{Name: "alias", Enabled: true, Preset: "matrix", Format: "json", URL: "https://matrix.org", RoomID: "#room:matrix.org", Token: "syt_x"},
+		{Name: "no-token", Enabled: true, Preset: "matrix", Format: "json", URL: "https://matrix.org", RoomID: "!abc:matrix.org"},
+	}
+
+	var paths []string
+	for _, e := range cfg.ValidateAll() {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{
+		"notifications.webhooks[1].room_id",
+		"notifications.webhooks[2].token",
+	}, paths)
+}
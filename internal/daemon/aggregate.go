@@ -0,0 +1,111 @@
+//go:build linux
+
+// ABOUTME: Aggregation window that collects notifications from several sessions into one batch.
+// ABOUTME: Delivery runs `claude-notifications deliver`, which sends one merged toast, sound and webhook message.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// maxAggregateWindow caps the window a hook may ask for (config.maxAggregateWindow)
+const maxAggregateWindow = time.Minute
+
+// aggregator collects notifications until the window opened by the first one closes
+type aggregator struct {
+	mu      sync.Mutex
+	pending []AggregateRequest
+	timer   *time.Timer
+
+	// Replaceable for tests
+	deliver func(batch []AggregateRequest) error
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{deliver: deliverBatch}
+}
+
+// add queues a notification, opening a window if none is open
+func (a *aggregator) add(req *AggregateRequest) error {
+	if req.SessionID == "" {
+		return fmt.Errorf("missing session_id")
+	}
+	if req.Status == "" {
+		return fmt.Errorf("missing status")
+	}
+	if req.Window <= 0 || req.Window > maxAggregateWindow {
+		return fmt.Errorf("window must be between 0s and %v, got %v", maxAggregateWindow, req.Window)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pending = append(a.pending, *req)
+	if a.timer == nil {
+		a.timer = time.AfterFunc(req.Window, a.flush)
+		log.Printf("[INFO] Aggregation window opened for %v", req.Window)
+	}
+	return nil
+}
+
+// flush delivers the pending notifications and closes the window
+func (a *aggregator) flush() {
+	a.mu.Lock()
+	batch := a.pending
+	a.pending = nil
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	a.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+	log.Printf("[INFO] Delivering %d aggregated notification(s)", len(batch))
+	if err := a.deliver(batch); err != nil {
+		log.Printf("[ERROR] Failed to deliver aggregated notifications: %v", err)
+	}
+}
+
+// count returns the number of notifications waiting for the window to close
+func (a *aggregator) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
+
+// deliverBatch runs `claude-notifications deliver` with the batch on stdin
+func deliverBatch(batch []AggregateRequest) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate executable: %w", err)
+	}
+
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, exe, "deliver")
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = os.Environ()
+	if root := batch[0].PluginRoot; root != "" {
+		cmd.Env = append(cmd.Env, "CLAUDE_PLUGIN_ROOT="+root)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("deliver command failed: %w (output: %s)", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+//go:build linux
+
+package daemon
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestAggregator returns an aggregator that records delivered batches
+func newTestAggregator() (*aggregator, func() [][]AggregateRequest) {
+	var mu sync.Mutex
+	var batches [][]AggregateRequest
+	a := newAggregator()
+	a.deliver = func(batch []AggregateRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, batch)
+		return nil
+	}
+	return a, func() [][]AggregateRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][]AggregateRequest(nil), batches...)
+	}
+}
+
+func TestAggregator_MergesNotificationsInWindow(t *testing.T) {
+	a, batches := newTestAggregator()
+
+	for _, id := range []string{"s1", "s2", "s3"} {
+		if err := a.add(&AggregateRequest{SessionID: id, Status: "task_complete", Window: 50 * time.Millisecond}); err != nil {
+			t.Fatalf("add(%s) error: %v", id, err)
+		}
+	}
+	if a.count() != 3 {
+		t.Errorf("count() = %d, want 3", a.count())
+	}
+
+	waitFor(t, func() bool { return len(batches()) == 1 && a.count() == 0 })
+
+	batch := batches()[0]
+	if len(batch) != 3 {
+		t.Fatalf("batch has %d notifications, want 3", len(batch))
+	}
+	for i, id := range []string{"s1", "s2", "s3"} {
+		if batch[i].SessionID != id {
+			t.Errorf("batch[%d].SessionID = %q, want %q", i, batch[i].SessionID, id)
+		}
+	}
+}
+
+func TestAggregator_NewWindowAfterFlush(t *testing.T) {
+	a, batches := newTestAggregator()
+	req := &AggregateRequest{SessionID: "s1", Status: "question", Window: 20 * time.Millisecond}
+
+	if err := a.add(req); err != nil {
+		t.Fatalf("add() error: %v", err)
+	}
+	waitFor(t, func() bool { return len(batches()) == 1 })
+
+	if err := a.add(req); err != nil {
+		t.Fatalf("add() error: %v", err)
+	}
+	waitFor(t, func() bool { return len(batches()) == 2 })
+
+	for i, batch := range batches() {
+		if len(batch) != 1 {
+			t.Errorf("batch %d has %d notifications, want 1", i, len(batch))
+		}
+	}
+}
+
+func TestAggregator_FlushDeliversEarly(t *testing.T) {
+	a, batches := newTestAggregator()
+	if err := a.add(&AggregateRequest{SessionID: "s1", Status: "task_complete", Window: time.Minute}); err != nil {
+		t.Fatalf("add() error: %v", err)
+	}
+
+	a.flush()
+
+	if got := len(batches()); got != 1 {
+		t.Fatalf("flush() delivered %d batches, want 1", got)
+	}
+	if a.count() != 0 {
+		t.Errorf("count() = %d after flush, want 0", a.count())
+	}
+}
+
+func TestAggregator_RejectsInvalidRequests(t *testing.T) {
+	a, _ := newTestAggregator()
+	tests := []struct {
+		name string
+		req  AggregateRequest
+	}{
+		{"missing session", AggregateRequest{Status: "task_complete", Window: time.Second}},
+		{"missing status", AggregateRequest{SessionID: "s1", Window: time.Second}},
+		{"zero window", AggregateRequest{SessionID: "s1", Status: "task_complete"}},
+		{"window too long", AggregateRequest{SessionID: "s1", Status: "task_complete", Window: 2 * time.Minute}},
+	}
+	for _, tt := range tests {
+		if err := a.add(&tt.req); err == nil {
+			t.Errorf("%s: add() should fail", tt.name)
+		}
+	}
+	if a.count() != 0 {
+		t.Errorf("count() = %d, want 0", a.count())
+	}
+}
+
+func TestClient_AggregateOverSocket(t *testing.T) {
+	s, client := startTestServer(t)
+	a, batches := newTestAggregator()
+	s.aggregator = a
+
+	sound := "/tmp/ping.wav"
+	err := client.Aggregate(&AggregateRequest{
+		SessionID: "s1",
+		Status:    "question",
+		Body:      "Which database?",
+		Desktop:   true,
+		Sound:     &sound,
+		Window:    20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Aggregate() error: %v", err)
+	}
+
+	waitFor(t, func() bool { return len(batches()) == 1 })
+	got := batches()[0][0]
+	if got.SessionID != "s1" || got.Body != "Which database?" || !got.Desktop {
+		t.Errorf("delivered %+v", got)
+	}
+	if got.Sound == nil || *got.Sound != sound {
+		t.Errorf("Sound = %v, want %q", got.Sound, sound)
+	}
+
+	if err := client.Aggregate(&AggregateRequest{SessionID: "s1", Status: "question"}); err == nil {
+		t.Error("Aggregate() without a window should fail")
+	}
+}
@@ -7,6 +7,7 @@ package daemon
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
@@ -15,6 +16,8 @@ import (
 	"strings"
 	"syscall"
 	"time"
+
+	"github.com/godbus/dbus/v5"
 )
 
 // Client communicates with the daemon via Unix socket
@@ -35,18 +38,12 @@ func NewClient() (*Client, error) {
 }
 
 // SendNotification sends a notification request to the daemon.
-// focusFolder is the project folder name for window-specific focus (may be empty).
-func (c *Client) SendNotification(title, body, focusTarget, focusFolder string, timeout int) (*NotifyResponse, error) {
+// FocusFolder is the project folder name for window-specific focus (may be empty).
+func (c *Client) SendNotification(notify *NotifyRequest) (*NotifyResponse, error) {
 	req := Request{
 		Type:    MessageTypeNotify,
 		Version: ProtocolVersion,
-		Notify: &NotifyRequest{
-			Title:       title,
-			Body:        body,
-			FocusTarget: focusTarget,
-			FocusFolder: focusFolder,
-			Timeout:     timeout,
-		},
+		Notify:  notify,
 	}
 
 	resp, err := c.send(req)
@@ -61,6 +58,177 @@ func (c *Client) SendNotification(title, body, focusTarget, focusFolder string,
 	return resp.Notify, nil
 }
 
+// ScheduleReminder asks the daemon to re-notify while a prompt stays unanswered
+func (c *Client) ScheduleReminder(req *RemindRequest) error {
+	resp, err := c.send(Request{
+		Type:    MessageTypeRemind,
+		Version: ProtocolVersion,
+		Remind:  req,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	return nil
+}
+
+// CancelReminders drops pending reminders of a session
+func (c *Client) CancelReminders(sessionID string) error {
+	resp, err := c.send(Request{
+		Type:    MessageTypeCancelRemind,
+		Version: ProtocolVersion,
+		Remind:  &RemindRequest{SessionID: sessionID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	return nil
+}
+
+// PublishEvent passes a hook's event on to the daemon's subscribers
+func (c *Client) PublishEvent(ev *Event) error {
+	resp, err := c.send(Request{
+		Type:    MessageTypeEvent,
+		Version: ProtocolVersion,
+		Event:   ev,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	return nil
+}
+
+// Subscribe streams the daemon's events to fn until the connection ends or fn returns
+// an error. It returns nil only if the daemon closed the stream.
+func (c *Client) Subscribe(fn func(Event) error) error {
+	conn, err := net.DialTimeout("unix", c.socketPath, 5*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(Request{Type: MessageTypeSubscribe, Version: ProtocolVersion}); err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	// The acknowledgement is a Response, the rest are events
+	decoder := json.NewDecoder(conn)
+	var resp Response
+	if err := decoder.Decode(&resp); err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+
+	for {
+		var ev Event
+		if err := decoder.Decode(&ev); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read event: %w", err)
+		}
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+}
+
+// PlaySound asks the daemon to play a sound file. It returns once the daemon has
+// accepted the sound, not when playback ends.
+func (c *Client) PlaySound(req *PlayRequest) error {
+	resp, err := c.send(Request{
+		Type:    MessageTypePlay,
+		Version: ProtocolVersion,
+		Play:    req,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	return nil
+}
+
+// CheckDuplicate asks whether req's hook event was handled for the session a moment ago
+func (c *Client) CheckDuplicate(req *ClaimRequest) (*ClaimResponse, error) {
+	return c.claim(MessageTypeCheck, req)
+}
+
+// ClaimNotification checks a notification against dedup and cooldowns and records it
+// if it may be sent, atomically across hook processes
+func (c *Client) ClaimNotification(req *ClaimRequest) (*ClaimResponse, error) {
+	return c.claim(MessageTypeClaim, req)
+}
+
+func (c *Client) claim(msgType MessageType, req *ClaimRequest) (*ClaimResponse, error) {
+	resp, err := c.send(Request{
+		Type:    msgType,
+		Version: ProtocolVersion,
+		Claim:   req,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	if resp.Claim == nil {
+		return nil, fmt.Errorf("daemon error: empty %s response", msgType)
+	}
+	return resp.Claim, nil
+}
+
+// ForgetSession drops the dedup and cooldown state of a session
+func (c *Client) ForgetSession(sessionID string) error {
+	resp, err := c.send(Request{
+		Type:    MessageTypeForget,
+		Version: ProtocolVersion,
+		Claim:   &ClaimRequest{SessionID: sessionID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	return nil
+}
+
+// Aggregate queues a notification for the aggregation window; the daemon delivers it
+// with the others that arrive before the window closes
+func (c *Client) Aggregate(req *AggregateRequest) error {
+	resp, err := c.send(Request{
+		Type:      MessageTypeAggregate,
+		Version:   ProtocolVersion,
+		Aggregate: req,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	return nil
+}
+
 // Ping checks if the daemon is responding and returns status info
 func (c *Client) Ping() (*PingResponse, error) {
 	req := Request{
@@ -120,6 +288,15 @@ func (c *Client) send(req Request) (*Response, error) {
 	return &resp, nil
 }
 
+// CheckSessionBus connects to the D-Bus session bus, which desktop notifications need
+func CheckSessionBus() error {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 // IsDaemonRunning checks if the daemon is running and responsive
 func IsDaemonRunning() bool {
 	client, err := NewClient()
@@ -131,6 +308,13 @@ func IsDaemonRunning() bool {
 	return err == nil
 }
 
+// IsDaemonStarted reports whether a daemon process is already serving. Unlike
+// IsDaemonRunning it checks the PID file before connecting, so it doesn't start the
+// daemon through the systemd socket unit.
+func IsDaemonStarted() bool {
+	return GetDaemonPID() != 0 && IsDaemonRunning()
+}
+
 // StartDaemonOnDemand starts the daemon if it's not already running.
 // Returns true if daemon is running (either started now or was already running).
 func StartDaemonOnDemand() bool {
@@ -0,0 +1,147 @@
+//go:build linux
+
+// ABOUTME: In-memory dedup and cooldown state, the daemon's replacement for lock and state files.
+// ABOUTME: A claim checks and records a notification under one mutex, so concurrent hooks can't race.
+package daemon
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/state"
+)
+
+// hookDuplicateWindow is how long a hook event blocks the same event of the session
+// (the age of a fresh lock file in dedup.Manager)
+const hookDuplicateWindow = 2 * time.Second
+
+// sessionRetention drops sessions without claims for this long; it is far beyond
+// any cooldown or duplicate window
+const sessionRetention = time.Hour
+
+// Suppression reasons, worded like the hook's file-based path
+const (
+	reasonHookDuplicate     = "Hook event already handled within 2s (duplicate), skipping"
+	reasonAfterAny          = "Question suppressed due to recent notification from this session"
+	reasonAfterTaskComplete = "Question suppressed due to cooldown after task complete"
+	reasonDuplicateMessage  = "Duplicate message content detected within %ds, skipping"
+)
+
+// sessionDedup is the dedup and cooldown state of one session
+type sessionDedup struct {
+	hookEvents       map[string]time.Time // Hook event -> last claim
+	lastNotification time.Time
+	lastMessage      string // Normalized
+	lastTaskComplete time.Time
+	lastSeen         time.Time
+}
+
+// dedupStore holds the state of all sessions
+type dedupStore struct {
+	mu       sync.Mutex
+	sessions map[string]*sessionDedup
+
+	// Replaceable for tests
+	now func() time.Time
+}
+
+func newDedupStore() *dedupStore {
+	return &dedupStore{
+		sessions: make(map[string]*sessionDedup),
+		now:      time.Now,
+	}
+}
+
+// check is phase 1: reports whether the hook event was claimed for the session less
+// than hookDuplicateWindow ago. Records nothing.
+func (ds *dedupStore) check(req *ClaimRequest) (*ClaimResponse, error) {
+	if req.SessionID == "" {
+		return nil, fmt.Errorf("missing session_id")
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	sess := ds.sessions[req.SessionID]
+	if sess != nil && ds.now().Sub(sess.hookEvents[req.HookEvent]) < hookDuplicateWindow {
+		return &ClaimResponse{Duplicate: true, Reason: reasonHookDuplicate}, nil
+	}
+	return &ClaimResponse{}, nil
+}
+
+// claim runs the hook's phase 2 checks in order (hook event lock, question cooldowns,
+// duplicate content) and records the notification if it passes. As with the lock files,
+// the hook event stays claimed even if a later check rejects the notification.
+func (ds *dedupStore) claim(req *ClaimRequest) (*ClaimResponse, error) {
+	if req.SessionID == "" {
+		return nil, fmt.Errorf("missing session_id")
+	}
+	if req.HookEvent == "" {
+		return nil, fmt.Errorf("missing hook_event")
+	}
+	if req.CooldownAfterAny < 0 || req.CooldownAfterTask < 0 || req.DuplicateWindow < 0 {
+		return nil, fmt.Errorf("cooldowns and duplicate window must not be negative")
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	now := ds.now()
+	ds.prune(now)
+
+	sess := ds.sessions[req.SessionID]
+	if sess == nil {
+		sess = &sessionDedup{hookEvents: make(map[string]time.Time)}
+		ds.sessions[req.SessionID] = sess
+	}
+	sess.lastSeen = now
+
+	if now.Sub(sess.hookEvents[req.HookEvent]) < hookDuplicateWindow {
+		return &ClaimResponse{Duplicate: true, Reason: reasonHookDuplicate}, nil
+	}
+	sess.hookEvents[req.HookEvent] = now
+
+	if req.InputRequest {
+		if within(now, sess.lastNotification, req.CooldownAfterAny) {
+			return &ClaimResponse{Reason: reasonAfterAny}, nil
+		}
+		if within(now, sess.lastTaskComplete, req.CooldownAfterTask) {
+			return &ClaimResponse{Reason: reasonAfterTaskComplete}, nil
+		}
+	}
+
+	if req.TaskComplete {
+		sess.lastTaskComplete = now
+	}
+
+	message := state.NormalizeMessage(req.Message)
+	if sess.lastMessage != "" && message == sess.lastMessage && within(now, sess.lastNotification, req.DuplicateWindow) {
+		return &ClaimResponse{Duplicate: true, Reason: fmt.Sprintf(reasonDuplicateMessage, req.DuplicateWindow)}, nil
+	}
+
+	sess.lastNotification = now
+	sess.lastMessage = message
+	return &ClaimResponse{Allowed: true}, nil
+}
+
+// forget drops the state of a session (SessionEnd)
+func (ds *dedupStore) forget(sessionID string) {
+	ds.mu.Lock()
+	delete(ds.sessions, sessionID)
+	ds.mu.Unlock()
+}
+
+// prune drops sessions not seen for sessionRetention; call with mu held
+func (ds *dedupStore) prune(now time.Time) {
+	for id, sess := range ds.sessions {
+		if now.Sub(sess.lastSeen) > sessionRetention {
+			delete(ds.sessions, id)
+		}
+	}
+}
+
+// within reports whether t is set and less than seconds before now
+func within(now, t time.Time, seconds int) bool {
+	return seconds > 0 && !t.IsZero() && now.Sub(t) < time.Duration(seconds)*time.Second
+}
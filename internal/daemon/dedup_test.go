@@ -0,0 +1,176 @@
+//go:build linux
+
+package daemon
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestDedupStore returns a store whose clock only moves with advance
+func newTestDedupStore() (*dedupStore, func(d time.Duration)) {
+	ds := newDedupStore()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ds.now = func() time.Time { return now }
+	return ds, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestDedupStore_HookEventDuplicate(t *testing.T) {
+	ds, advance := newTestDedupStore()
+	req := &ClaimRequest{SessionID: "s1", HookEvent: "Stop", Message: "Done"}
+
+	if resp, _ := ds.check(req); resp.Duplicate {
+		t.Error("first check should not be a duplicate")
+	}
+	if resp, _ := ds.claim(req); !resp.Allowed {
+		t.Fatalf("first claim rejected: %+v", resp)
+	}
+	if resp, _ := ds.check(req); !resp.Duplicate {
+		t.Error("check right after the claim should be a duplicate")
+	}
+	if resp, _ := ds.claim(&ClaimRequest{SessionID: "s1", HookEvent: "Stop", Message: "Other"}); resp.Allowed || !resp.Duplicate {
+		t.Errorf("second claim of the hook event = %+v, want a duplicate", resp)
+	}
+	if resp, _ := ds.claim(&ClaimRequest{SessionID: "s2", HookEvent: "Stop", Message: "Done"}); !resp.Allowed {
+		t.Errorf("other session rejected: %+v", resp)
+	}
+
+	advance(hookDuplicateWindow)
+	if resp, _ := ds.check(req); resp.Duplicate {
+		t.Error("check after the window should not be a duplicate")
+	}
+}
+
+func TestDedupStore_QuestionCooldowns(t *testing.T) {
+	ds, advance := newTestDedupStore()
+	question := func(hookEvent string) *ClaimResponse {
+		resp, err := ds.claim(&ClaimRequest{SessionID: "s1", HookEvent: hookEvent, Message: "Which database?",
+			InputRequest: true, CooldownAfterAny: 5, CooldownAfterTask: 12})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp, _ := ds.claim(&ClaimRequest{SessionID: "s1", HookEvent: "Stop", Message: "Done", TaskComplete: true}); !resp.Allowed {
+		t.Fatalf("task complete rejected: %+v", resp)
+	}
+
+	advance(3 * time.Second)
+	if resp := question("Notification"); resp.Allowed || resp.Reason != reasonAfterAny {
+		t.Errorf("question 3s after a notification = %+v, want the any-notification cooldown", resp)
+	}
+
+	advance(5 * time.Second)
+	if resp := question("PreToolUse"); resp.Allowed || resp.Reason != reasonAfterTaskComplete {
+		t.Errorf("question 8s after task complete = %+v, want the task complete cooldown", resp)
+	}
+
+	advance(5 * time.Second)
+	if resp := question("PermissionRequest"); !resp.Allowed {
+		t.Errorf("question after both cooldowns rejected: %+v", resp)
+	}
+}
+
+func TestDedupStore_DuplicateMessage(t *testing.T) {
+	ds, advance := newTestDedupStore()
+	claim := func(hookEvent, message string) *ClaimResponse {
+		resp, err := ds.claim(&ClaimRequest{SessionID: "s1", HookEvent: hookEvent, Message: message, DuplicateWindow: 180})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp := claim("Stop", "Created the parser."); !resp.Allowed {
+		t.Fatalf("first message rejected: %+v", resp)
+	}
+	if resp := claim("Notification", "  created the parser "); resp.Allowed || !resp.Duplicate {
+		t.Errorf("same message from another hook = %+v, want a duplicate", resp)
+	}
+	if resp := claim("SubagentStop", "Fixed the tests"); !resp.Allowed {
+		t.Errorf("new message rejected: %+v", resp)
+	}
+
+	advance(181 * time.Second)
+	if resp := claim("Stop", "Fixed the tests"); !resp.Allowed {
+		t.Errorf("same message after the window rejected: %+v", resp)
+	}
+}
+
+func TestDedupStore_ForgetAndPrune(t *testing.T) {
+	ds, advance := newTestDedupStore()
+	req := &ClaimRequest{SessionID: "s1", HookEvent: "Stop", Message: "Done", DuplicateWindow: 180}
+	ds.claim(req)
+
+	ds.forget("s1")
+	if resp, _ := ds.claim(req); !resp.Allowed {
+		t.Errorf("claim after forget rejected: %+v", resp)
+	}
+
+	ds.claim(&ClaimRequest{SessionID: "s2", HookEvent: "Stop"})
+	advance(sessionRetention + time.Second)
+	ds.claim(&ClaimRequest{SessionID: "s3", HookEvent: "Stop"})
+	if len(ds.sessions) != 1 {
+		t.Errorf("got %d sessions, want only the one claimed after the retention", len(ds.sessions))
+	}
+}
+
+func TestDedupStore_ConcurrentClaims(t *testing.T) {
+	ds := newDedupStore()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for _, hookEvent := range []string{"Stop", "Notification", "Stop", "Notification", "SubagentStop"} {
+		wg.Add(1)
+		go func(hookEvent string) {
+			defer wg.Done()
+			resp, err := ds.claim(&ClaimRequest{SessionID: "s1", HookEvent: hookEvent, Message: "Done", DuplicateWindow: 180})
+			if err == nil && resp.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}(hookEvent)
+	}
+	wg.Wait()
+
+	if allowed != 1 {
+		t.Errorf("%d hooks may send the same message, want 1", allowed)
+	}
+}
+
+func TestDedupStore_RejectsInvalidRequests(t *testing.T) {
+	ds := newDedupStore()
+	for _, req := range []*ClaimRequest{
+		{HookEvent: "Stop"},
+		{SessionID: "s1"},
+		{SessionID: "s1", HookEvent: "Stop", CooldownAfterAny: -1},
+	} {
+		if _, err := ds.claim(req); err == nil {
+			t.Errorf("claim(%+v) should fail", req)
+		}
+	}
+}
+
+func TestClaim_OverSocket(t *testing.T) {
+	_, client := startTestServer(t)
+	req := &ClaimRequest{SessionID: "s1", HookEvent: "Stop", Message: "Done"}
+
+	if resp, err := client.ClaimNotification(req); err != nil || !resp.Allowed {
+		t.Fatalf("ClaimNotification = %+v, %v", resp, err)
+	}
+	if resp, err := client.CheckDuplicate(req); err != nil || !resp.Duplicate {
+		t.Errorf("CheckDuplicate = %+v, %v, want a duplicate", resp, err)
+	}
+	if err := client.ForgetSession("s1"); err != nil {
+		t.Fatalf("ForgetSession error: %v", err)
+	}
+	if resp, err := client.CheckDuplicate(req); err != nil || resp.Duplicate {
+		t.Errorf("CheckDuplicate after forget = %+v, %v", resp, err)
+	}
+	if _, err := client.ClaimNotification(&ClaimRequest{HookEvent: "Stop"}); err == nil {
+		t.Error("expected an error without session ID")
+	}
+}
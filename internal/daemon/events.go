@@ -0,0 +1,63 @@
+//go:build linux
+
+// ABOUTME: Event broker that fans out notification events to `watch` subscribers.
+// ABOUTME: Slow subscribers lose events instead of blocking hooks or D-Bus callbacks.
+package daemon
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is the number of events a subscriber may fall behind before events are dropped
+const subscriberBuffer = 64
+
+// eventBroker keeps the subscribe connections
+type eventBroker struct {
+	mu   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subs: make(map[chan Event]struct{})}
+}
+
+// subscribe registers a subscriber; call unsubscribe when its connection ends
+func (b *eventBroker) subscribe() chan Event {
+	ch := make(chan Event, subscriberBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *eventBroker) unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+}
+
+// publish sends ev to every subscriber without waiting
+func (b *eventBroker) publish(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+			log.Printf("[WARN] Subscriber is %d events behind, dropping %s event", subscriberBuffer, ev.Type)
+		}
+	}
+}
+
+// count returns the number of subscribers
+func (b *eventBroker) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
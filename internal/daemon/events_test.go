@@ -0,0 +1,126 @@
+//go:build linux
+
+package daemon
+
+import (
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBroker_PublishToSubscribers(t *testing.T) {
+	b := newEventBroker()
+	a, c := b.subscribe(), b.subscribe()
+
+	b.publish(Event{Type: EventSent, SessionID: "s1"})
+
+	for _, ch := range []chan Event{a, c} {
+		ev := <-ch
+		if ev.Type != EventSent || ev.SessionID != "s1" || ev.Time.IsZero() {
+			t.Errorf("unexpected event %+v", ev)
+		}
+	}
+
+	b.unsubscribe(a)
+	if b.count() != 1 {
+		t.Errorf("count = %d, want 1", b.count())
+	}
+}
+
+func TestEventBroker_DropsForSlowSubscriber(t *testing.T) {
+	b := newEventBroker()
+	ch := b.subscribe()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		b.publish(Event{Type: EventSent})
+	}
+	if len(ch) != subscriberBuffer {
+		t.Errorf("buffered %d events, want %d", len(ch), subscriberBuffer)
+	}
+}
+
+// startTestServer serves connections with a server that has no D-Bus connection,
+// which is enough for the subscribe and event messages
+func startTestServer(t *testing.T) (*Server, *Client) {
+	t.Helper()
+	socketPath := filepath.Join(t.TempDir(), "daemon.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{
+		listener:   listener,
+		reminders:  newReminderScheduler(),
+		events:     newEventBroker(),
+		dedup:      newDedupStore(),
+		aggregator: newAggregator(),
+		done:       make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.acceptLoop()
+	t.Cleanup(func() {
+		s.mu.Lock()
+		if !s.shutdown {
+			s.shutdown = true
+			close(s.done)
+		}
+		s.mu.Unlock()
+		listener.Close()
+		s.wg.Wait()
+	})
+	return s, &Client{socketPath: socketPath}
+}
+
+func TestSubscribe_StreamsPublishedEvents(t *testing.T) {
+	s, client := startTestServer(t)
+
+	var mu sync.Mutex
+	var got []Event
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Subscribe(func(ev Event) error {
+			mu.Lock()
+			got = append(got, ev)
+			mu.Unlock()
+			return nil
+		})
+	}()
+	waitFor(t, func() bool { return s.events.count() == 1 })
+
+	if err := client.PublishEvent(&Event{Type: EventSuppressed, Status: "question", Reason: "cooldown"}); err != nil {
+		t.Fatalf("PublishEvent failed: %v", err)
+	}
+	s.events.publish(Event{Type: EventClicked, NotificationID: 7})
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 2
+	})
+
+	if got[0].Type != EventSuppressed || got[0].Reason != "cooldown" || got[1].Type != EventClicked || got[1].NotificationID != 7 {
+		t.Errorf("unexpected events %+v", got)
+	}
+
+	// Shutdown ends the stream cleanly
+	s.mu.Lock()
+	s.shutdown = true
+	close(s.done)
+	s.mu.Unlock()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Subscribe returned %v, want nil when the daemon closes the stream", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Subscribe did not return after shutdown")
+	}
+	waitFor(t, func() bool { return s.events.count() == 0 })
+}
+
+func TestClosedReason(t *testing.T) {
+	if closedReason(1) != "expired" || closedReason(2) != "dismissed" || closedReason(3) != "closed" || closedReason(9) != "unknown" {
+		t.Error("unexpected close reasons")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Common errors
@@ -27,13 +28,42 @@ const (
 	MessageTypeNotify MessageType = "notify"
 	MessageTypePing   MessageType = "ping"
 	MessageTypeStop   MessageType = "stop"
+
+	MessageTypeRemind       MessageType = "remind"        // Schedule reminders for an unanswered prompt
+	MessageTypeCancelRemind MessageType = "cancel_remind" // Drop pending reminders of a session
+
+	MessageTypeSubscribe MessageType = "subscribe" // Keep the connection open and stream events (one JSON object per line)
+	MessageTypeEvent     MessageType = "event"     // Publish a hook's event to the subscribers
+
+	MessageTypePlay MessageType = "play" // Play a sound file; the response comes before playback ends
+
+	MessageTypeCheck  MessageType = "check"  // Phase 1 dedup: did the hook event fire for the session a moment ago?
+	MessageTypeClaim  MessageType = "claim"  // Check a notification against dedup and cooldowns, and record it if it may be sent
+	MessageTypeForget MessageType = "forget" // Drop the dedup and cooldown state of a session
+
+	MessageTypeAggregate MessageType = "aggregate" // Queue a notification for the aggregation window
+)
+
+// EventType identifies what happened to a notification
+type EventType string
+
+const (
+	EventSent       EventType = "sent"       // A hook sent a notification (published by the hook)
+	EventSuppressed EventType = "suppressed" // A hook suppressed a notification, see Reason (published by the hook)
+	EventClicked    EventType = "clicked"    // The user clicked a daemon notification
+	EventClosed     EventType = "closed"     // A daemon notification expired or was dismissed, see Reason
 )
 
 // Request is the wrapper for all IPC requests
 type Request struct {
-	Type    MessageType    `json:"type"`
-	Notify  *NotifyRequest `json:"notify,omitempty"`
-	Version string         `json:"version"`
+	Type      MessageType       `json:"type"`
+	Notify    *NotifyRequest    `json:"notify,omitempty"`
+	Remind    *RemindRequest    `json:"remind,omitempty"`
+	Event     *Event            `json:"event,omitempty"`
+	Play      *PlayRequest      `json:"play,omitempty"`
+	Claim     *ClaimRequest     `json:"claim,omitempty"`
+	Aggregate *AggregateRequest `json:"aggregate,omitempty"`
+	Version   string            `json:"version"`
 }
 
 // Response is the wrapper for all IPC responses
@@ -41,6 +71,7 @@ type Response struct {
 	Type   MessageType     `json:"type"`
 	Notify *NotifyResponse `json:"notify,omitempty"`
 	Ping   *PingResponse   `json:"ping,omitempty"`
+	Claim  *ClaimResponse  `json:"claim,omitempty"`
 	Error  string          `json:"error,omitempty"`
 }
 
@@ -51,6 +82,84 @@ type NotifyRequest struct {
 	FocusTarget string `json:"focus_target"`           // Terminal identifier (empty = auto-detect)
 	FocusFolder string `json:"focus_folder,omitempty"` // Project folder name for window-specific focus
 	Timeout     int    `json:"timeout"`                // Notification timeout in seconds
+	SessionID   string `json:"session_id,omitempty"`   // Passed on in clicked/closed events
+	Status      string `json:"status,omitempty"`       // Passed on in clicked/closed events
+}
+
+// PlayRequest asks the daemon to play a sound file
+type PlayRequest struct {
+	Path   string  `json:"path"`             // Absolute path of the sound file
+	Volume float64 `json:"volume"`           // 0.0-1.0
+	Device string  `json:"device,omitempty"` // Audio output device name (empty = system default)
+}
+
+// ClaimRequest describes a notification a hook is about to send.
+// For check only SessionID and HookEvent are used, for forget only SessionID.
+type ClaimRequest struct {
+	SessionID         string `json:"session_id"`
+	HookEvent         string `json:"hook_event,omitempty"`
+	Message           string `json:"message,omitempty"`
+	TaskComplete      bool   `json:"task_complete,omitempty"`       // Starts the cooldown after task completion
+	InputRequest      bool   `json:"input_request,omitempty"`       // Question-like status, subject to the cooldowns
+	CooldownAfterAny  int    `json:"cooldown_after_any,omitempty"`  // Seconds input requests are suppressed after any notification
+	CooldownAfterTask int    `json:"cooldown_after_task,omitempty"` // Seconds input requests are suppressed after task completion
+	DuplicateWindow   int    `json:"duplicate_window,omitempty"`    // Seconds the same message counts as a duplicate
+}
+
+// ClaimResponse is the daemon's answer to check and claim
+type ClaimResponse struct {
+	Allowed   bool   `json:"allowed"`             // claim: the notification was recorded and may be sent
+	Duplicate bool   `json:"duplicate,omitempty"` // Rejected as a duplicate rather than by a cooldown
+	Reason    string `json:"reason,omitempty"`
+}
+
+// AggregateRequest is a notification waiting for the aggregation window. The daemon
+// passes the batch to `claude-notifications deliver`; the JSON must stay in sync
+// with hooks.AggregatedNotification.
+type AggregateRequest struct {
+	SessionID   string        `json:"session_id"`
+	Status      string        `json:"status"`
+	Message     string        `json:"message"`                // Plain summary
+	Title       string        `json:"title,omitempty"`        // Rendered desktop title
+	Subtitle    string        `json:"subtitle,omitempty"`     // Rendered desktop subtitle
+	Body        string        `json:"body,omitempty"`         // Rendered desktop body
+	WebhookText string        `json:"webhook_text,omitempty"` // Rendered webhook text
+	CWD         string        `json:"cwd,omitempty"`
+	Desktop     bool          `json:"desktop,omitempty"`     // Routed to the desktop
+	Webhook     bool          `json:"webhook,omitempty"`     // Routed to the webhook
+	Webhooks    []string      `json:"webhooks,omitempty"`    // Webhook targets chosen by routes (empty = all)
+	Sound       *string       `json:"sound,omitempty"`       // Sound chosen by routes
+	Quiet       bool          `json:"quiet,omitempty"`       // Muted by quiet hours
+	PluginRoot  string        `json:"plugin_root,omitempty"` // Passed to the deliver process as CLAUDE_PLUGIN_ROOT
+	Window      time.Duration `json:"window"`                // Aggregation window; the first notification of a batch sets it
+}
+
+// Event is one line of the subscribe stream
+type Event struct {
+	Type           EventType         `json:"type"`
+	Time           time.Time         `json:"time"`
+	Event          string            `json:"event,omitempty"` // Hook event that produced it (Stop, Notification, reminder, ...)
+	SessionID      string            `json:"session_id,omitempty"`
+	Status         string            `json:"status,omitempty"`
+	Message        string            `json:"message,omitempty"`
+	Reason         string            `json:"reason,omitempty"` // suppressed: why; closed: expired, dismissed, ...
+	CWD            string            `json:"cwd,omitempty"`
+	Channels       map[string]string `json:"channels,omitempty"`        // sent: channel name -> outcome
+	NotificationID uint32            `json:"notification_id,omitempty"` // clicked, closed: the desktop notification
+}
+
+// RemindRequest asks the daemon to re-notify while a prompt stays unanswered.
+// For cancel_remind only SessionID is used.
+type RemindRequest struct {
+	SessionID      string          `json:"session_id"`
+	Status         string          `json:"status"`
+	Message        string          `json:"message"`                   // Original notification body (without session prefix)
+	CWD            string          `json:"cwd,omitempty"`             // Session working directory
+	TranscriptPath string          `json:"transcript_path,omitempty"` // Checked for activity after NotifiedAt
+	PluginRoot     string          `json:"plugin_root,omitempty"`     // Passed to the reminder process as CLAUDE_PLUGIN_ROOT
+	NotifiedAt     int64           `json:"notified_at"`               // Unix time of the original notification
+	Backoff        []time.Duration `json:"backoff"`                   // Delay before each reminder, from the previous notification
+	EscalateAfter  int             `json:"escalate_after"`            // From this reminder on (1-based) also use the webhook, 0 = never
 }
 
 // NotifyResponse contains the result of a notification request
@@ -63,7 +172,8 @@ type NotifyResponse struct {
 // PingResponse contains daemon status information
 type PingResponse struct {
 	Version string `json:"version"`
-	Uptime  int64  `json:"uptime"` // Seconds since daemon started
+	Uptime  int64  `json:"uptime"`            // Seconds since daemon started
+	Systemd bool   `json:"systemd,omitempty"` // Socket-activated by the systemd user service
 }
 
 // GetSocketPath returns the Unix socket path for the daemon.
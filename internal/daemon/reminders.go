@@ -0,0 +1,236 @@
+//go:build linux
+
+// ABOUTME: Reminder scheduler that re-notifies while a question or plan stays unanswered.
+// ABOUTME: Timers live in the daemon; delivery runs `claude-notifications remind` to reuse the hook pipeline.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/state"
+	"github.com/777genius/claude-notifications/pkg/jsonl"
+)
+
+// answerGracePeriod ignores transcript entries written right after the notification
+// (e.g. the tool_use line of the AskUserQuestion that triggered it)
+const answerGracePeriod = 10 * time.Second
+
+// reminderDelivery is the stdin payload of `claude-notifications remind`.
+// Must stay in sync with hooks.Reminder.
+type reminderDelivery struct {
+	SessionID string `json:"session_id"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	CWD       string `json:"cwd"`
+	Attempt   int    `json:"attempt"`
+	Total     int    `json:"total"`
+	Escalate  bool   `json:"escalate"`
+}
+
+// pendingReminder is a scheduled reminder chain for one session
+type pendingReminder struct {
+	req     RemindRequest
+	attempt int // Number of reminders already sent
+	timer   *time.Timer
+}
+
+// reminderScheduler keeps one reminder chain per session
+type reminderScheduler struct {
+	mu      sync.Mutex
+	pending map[string]*pendingReminder
+
+	// Replaceable for tests
+	answered func(req *RemindRequest) bool
+	deliver  func(req *RemindRequest, attempt int, escalate bool) error
+}
+
+// newReminderScheduler creates a scheduler that checks session state/transcript
+// and delivers reminders via the plugin binary
+func newReminderScheduler() *reminderScheduler {
+	return &reminderScheduler{
+		pending:  make(map[string]*pendingReminder),
+		answered: isPromptAnswered,
+		deliver:  deliverReminder,
+	}
+}
+
+// schedule starts (or restarts) the reminder chain for the request's session
+func (rs *reminderScheduler) schedule(req *RemindRequest) error {
+	if req.SessionID == "" {
+		return fmt.Errorf("missing session_id")
+	}
+	if len(req.Backoff) == 0 {
+		return fmt.Errorf("empty backoff")
+	}
+	for _, d := range req.Backoff {
+		if d <= 0 {
+			return fmt.Errorf("invalid backoff %v", d)
+		}
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if existing, ok := rs.pending[req.SessionID]; ok {
+		existing.timer.Stop()
+	}
+
+	p := &pendingReminder{req: *req}
+	p.timer = time.AfterFunc(req.Backoff[0], func() { rs.fire(p) })
+	rs.pending[req.SessionID] = p
+
+	log.Printf("[INFO] Reminders scheduled: session=%s, status=%s, backoff=%v", req.SessionID, req.Status, req.Backoff)
+	return nil
+}
+
+// cancel drops the pending reminder chain of a session
+func (rs *reminderScheduler) cancel(sessionID string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if p, ok := rs.pending[sessionID]; ok {
+		p.timer.Stop()
+		delete(rs.pending, sessionID)
+		log.Printf("[INFO] Reminders cancelled: session=%s", sessionID)
+	}
+}
+
+// count returns the number of sessions with pending reminders
+func (rs *reminderScheduler) count() int {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return len(rs.pending)
+}
+
+// stopAll stops all timers (daemon shutdown)
+func (rs *reminderScheduler) stopAll() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for id, p := range rs.pending {
+		p.timer.Stop()
+		delete(rs.pending, id)
+	}
+}
+
+// fire sends the next reminder unless the prompt was answered, then arms the next timer
+func (rs *reminderScheduler) fire(p *pendingReminder) {
+	rs.mu.Lock()
+	if rs.pending[p.req.SessionID] != p {
+		// Replaced or cancelled in the meantime
+		rs.mu.Unlock()
+		return
+	}
+	rs.mu.Unlock()
+
+	if rs.answered(&p.req) {
+		log.Printf("[INFO] Prompt answered, dropping reminders: session=%s", p.req.SessionID)
+		rs.remove(p)
+		return
+	}
+
+	attempt := p.attempt + 1
+	escalate := p.req.EscalateAfter > 0 && attempt >= p.req.EscalateAfter
+	if err := rs.deliver(&p.req, attempt, escalate); err != nil {
+		log.Printf("[ERROR] Reminder %d/%d failed: session=%s: %v", attempt, len(p.req.Backoff), p.req.SessionID, err)
+	} else {
+		log.Printf("[INFO] Reminder %d/%d sent: session=%s, escalate=%v", attempt, len(p.req.Backoff), p.req.SessionID, escalate)
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.pending[p.req.SessionID] != p {
+		return
+	}
+	p.attempt = attempt
+	if p.attempt >= len(p.req.Backoff) {
+		delete(rs.pending, p.req.SessionID)
+		return
+	}
+	p.timer = time.AfterFunc(p.req.Backoff[p.attempt], func() { rs.fire(p) })
+}
+
+// remove deletes p if it is still the current chain of its session
+func (rs *reminderScheduler) remove(p *pendingReminder) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.pending[p.req.SessionID] == p {
+		delete(rs.pending, p.req.SessionID)
+	}
+}
+
+// isPromptAnswered reports whether the user reacted since the notification:
+// the session ended, the pending flag was cleared by a hook, a new turn started,
+// or the transcript has moved on.
+func isPromptAnswered(req *RemindRequest) bool {
+	sessionState, err := state.NewManager().Load(req.SessionID)
+	if err != nil {
+		log.Printf("[WARN] Failed to load session state for %s: %v", req.SessionID, err)
+	} else {
+		if sessionState == nil || !sessionState.ReminderPending {
+			return true
+		}
+		if sessionState.LastTurnStartTime > req.NotifiedAt {
+			return true
+		}
+	}
+
+	if req.TranscriptPath == "" {
+		return false
+	}
+	messages, err := jsonl.ParseFile(req.TranscriptPath)
+	if err != nil || len(messages) == 0 {
+		return false
+	}
+	ts, err := time.Parse(time.RFC3339, messages[len(messages)-1].Timestamp)
+	if err != nil {
+		return false
+	}
+	return ts.After(time.Unix(req.NotifiedAt, 0).Add(answerGracePeriod))
+}
+
+// deliverReminder runs `<self> remind` with the reminder on stdin, so the reminder
+// goes through the same config, sounds and webhook code as hook notifications
+func deliverReminder(req *RemindRequest, attempt int, escalate bool) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate executable: %w", err)
+	}
+
+	payload, err := json.Marshal(reminderDelivery{
+		SessionID: req.SessionID,
+		Status:    req.Status,
+		Message:   req.Message,
+		CWD:       req.CWD,
+		Attempt:   attempt,
+		Total:     len(req.Backoff),
+		Escalate:  escalate,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, exe, "remind")
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = os.Environ()
+	if req.PluginRoot != "" {
+		cmd.Env = append(cmd.Env, "CLAUDE_PLUGIN_ROOT="+req.PluginRoot)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("remind command failed: %w (output: %s)", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+//go:build linux
+
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/state"
+)
+
+// delivery records one reminder sent by the scheduler
+type delivery struct {
+	attempt  int
+	escalate bool
+}
+
+// newTestScheduler returns a scheduler whose deliveries are recorded instead of executed
+func newTestScheduler(answered func(*RemindRequest) bool) (*reminderScheduler, func() []delivery) {
+	var mu sync.Mutex
+	var deliveries []delivery
+
+	rs := newReminderScheduler()
+	rs.answered = answered
+	rs.deliver = func(req *RemindRequest, attempt int, escalate bool) error {
+		mu.Lock()
+		defer mu.Unlock()
+		deliveries = append(deliveries, delivery{attempt: attempt, escalate: escalate})
+		return nil
+	}
+
+	return rs, func() []delivery {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]delivery(nil), deliveries...)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met within 2s")
+}
+
+// --- reminderScheduler tests ---
+
+func TestReminderScheduler_DeliversBackoffWithEscalation(t *testing.T) {
+	rs, deliveries := newTestScheduler(func(*RemindRequest) bool { return false })
+
+	err := rs.schedule(&RemindRequest{
+		SessionID:     "s1",
+		Status:        "question",
+		Backoff:       []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond},
+		EscalateAfter: 2,
+	})
+	if err != nil {
+		t.Fatalf("schedule() error: %v", err)
+	}
+
+	waitFor(t, func() bool { return len(deliveries()) == 3 && rs.count() == 0 })
+
+	got := deliveries()
+	want := []delivery{{1, false}, {2, true}, {3, true}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("delivery %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReminderScheduler_StopsWhenAnswered(t *testing.T) {
+	var mu sync.Mutex
+	answered := false
+	rs, deliveries := newTestScheduler(func(*RemindRequest) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return answered
+	})
+
+	err := rs.schedule(&RemindRequest{
+		SessionID: "s2",
+		Backoff:   []time.Duration{10 * time.Millisecond, 50 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("schedule() error: %v", err)
+	}
+
+	waitFor(t, func() bool { return len(deliveries()) == 1 })
+	mu.Lock()
+	answered = true
+	mu.Unlock()
+
+	waitFor(t, func() bool { return rs.count() == 0 })
+	if n := len(deliveries()); n != 1 {
+		t.Errorf("got %d deliveries, want 1 (answered before the second)", n)
+	}
+}
+
+func TestReminderScheduler_Cancel(t *testing.T) {
+	rs, deliveries := newTestScheduler(func(*RemindRequest) bool { return false })
+
+	err := rs.schedule(&RemindRequest{
+		SessionID: "s3",
+		Backoff:   []time.Duration{50 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("schedule() error: %v", err)
+	}
+	rs.cancel("s3")
+
+	time.Sleep(100 * time.Millisecond)
+	if n := len(deliveries()); n != 0 {
+		t.Errorf("got %d deliveries after cancel, want 0", n)
+	}
+	if rs.count() != 0 {
+		t.Errorf("count() = %d, want 0", rs.count())
+	}
+}
+
+func TestReminderScheduler_RescheduleReplaces(t *testing.T) {
+	rs, deliveries := newTestScheduler(func(*RemindRequest) bool { return false })
+
+	_ = rs.schedule(&RemindRequest{SessionID: "s4", Backoff: []time.Duration{50 * time.Millisecond}})
+	_ = rs.schedule(&RemindRequest{SessionID: "s4", Backoff: []time.Duration{10 * time.Millisecond}})
+
+	waitFor(t, func() bool { return rs.count() == 0 })
+	time.Sleep(80 * time.Millisecond)
+	if n := len(deliveries()); n != 1 {
+		t.Errorf("got %d deliveries, want 1 (first chain replaced)", n)
+	}
+}
+
+func TestReminderScheduler_InvalidRequest(t *testing.T) {
+	rs, _ := newTestScheduler(func(*RemindRequest) bool { return false })
+
+	if err := rs.schedule(&RemindRequest{Backoff: []time.Duration{time.Minute}}); err == nil {
+		t.Error("expected error for missing session_id")
+	}
+	if err := rs.schedule(&RemindRequest{SessionID: "s5"}); err == nil {
+		t.Error("expected error for empty backoff")
+	}
+	if err := rs.schedule(&RemindRequest{SessionID: "s5", Backoff: []time.Duration{0}}); err == nil {
+		t.Error("expected error for zero backoff")
+	}
+}
+
+// --- isPromptAnswered tests ---
+
+func TestIsPromptAnswered(t *testing.T) {
+	mgr := state.NewManager()
+	sessionID := "test-daemon-reminder-answered"
+	defer func() { _ = mgr.Delete(sessionID) }()
+
+	notifiedAt := time.Now().Add(-10 * time.Minute).Unix()
+	req := &RemindRequest{SessionID: sessionID, NotifiedAt: notifiedAt}
+
+	// No state: session ended or was cleaned up
+	if !isPromptAnswered(req) {
+		t.Error("missing state should count as answered")
+	}
+
+	if err := mgr.SetReminderPending(sessionID, analyzer.StatusQuestion, notifiedAt); err != nil {
+		t.Fatal(err)
+	}
+	if isPromptAnswered(req) {
+		t.Error("pending prompt without activity should not count as answered")
+	}
+
+	// Transcript activity after the notification
+	transcript := filepath.Join(t.TempDir(), "transcript.jsonl")
+	line := `{"type":"assistant","timestamp":"` + time.Now().UTC().Format(time.RFC3339) + `","message":{"role":"assistant","content":[{"type":"text","text":"ok"}]}}` + "\n"
+	if err := os.WriteFile(transcript, []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+	req.TranscriptPath = transcript
+	if !isPromptAnswered(req) {
+		t.Error("transcript activity after the notification should count as answered")
+	}
+
+	// Pending flag cleared by a hook
+	req.TranscriptPath = ""
+	if err := mgr.ClearReminderPending(sessionID); err != nil {
+		t.Fatal(err)
+	}
+	if !isPromptAnswered(req) {
+		t.Error("cleared pending flag should count as answered")
+	}
+}
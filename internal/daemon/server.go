@@ -7,6 +7,7 @@ package daemon
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
@@ -19,10 +20,13 @@ import (
 	"github.com/godbus/dbus/v5"
 )
 
-// focusInfo holds the focus target and folder for a notification.
+// focusInfo holds the focus target and folder for a notification, and the session and
+// status for its events.
 type focusInfo struct {
-	target string
-	folder string
+	target    string
+	folder    string
+	sessionID string
+	status    string
 }
 
 // Server is the notification daemon server
@@ -30,12 +34,28 @@ type Server struct {
 	conn      *dbus.Conn
 	notifier  notify.Notifier
 	listener  net.Listener
+	inherited bool // listener came from systemd, which owns the socket file
 	startTime time.Time
 
 	// Focus context mapping: notification ID -> focus info
 	focusCtx   map[uint32]focusInfo
 	focusCtxMu sync.RWMutex
 
+	// Reminders for unanswered prompts
+	reminders *reminderScheduler
+
+	// Event stream for `watch` clients
+	events *eventBroker
+
+	// Sound playback for hooks
+	sounds *soundPlayer
+
+	// Dedup and cooldown state for hooks (notifications.daemon.stateInMemory)
+	dedup *dedupStore
+
+	// Aggregation window for notifications (notifications.aggregate)
+	aggregator *aggregator
+
 	// Idle timeout for auto-shutdown
 	idleTimeout  time.Duration
 	lastActivity time.Time
@@ -51,6 +71,7 @@ type Server struct {
 // ServerConfig contains server configuration options
 type ServerConfig struct {
 	IdleTimeout time.Duration // Auto-shutdown after this duration of inactivity (0 = disabled)
+	Listener    net.Listener  // Inherited socket (systemd socket activation); nil = create the socket
 }
 
 // DefaultServerConfig returns the default server configuration
@@ -70,8 +91,15 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 
 	s := &Server{
 		conn:         conn,
+		listener:     cfg.Listener,
+		inherited:    cfg.Listener != nil,
 		startTime:    time.Now(),
 		focusCtx:     make(map[uint32]focusInfo),
+		reminders:    newReminderScheduler(),
+		events:       newEventBroker(),
+		sounds:       newSoundPlayer(),
+		dedup:        newDedupStore(),
+		aggregator:   newAggregator(),
 		idleTimeout:  cfg.IdleTimeout,
 		lastActivity: time.Now(),
 		done:         make(chan struct{}),
@@ -95,20 +123,24 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 func (s *Server) Run() error {
 	socketPath := GetSocketPath()
 
-	// Remove existing socket
-	os.Remove(socketPath)
+	if s.inherited {
+		socketPath = s.listener.Addr().String()
+	} else {
+		// Remove existing socket
+		os.Remove(socketPath)
 
-	// Create listener
-	listener, err := net.Listen("unix", socketPath)
-	if err != nil {
-		return fmt.Errorf("failed to create socket: %w", err)
-	}
-	s.listener = listener
+		// Create listener
+		listener, err := net.Listen("unix", socketPath)
+		if err != nil {
+			return fmt.Errorf("failed to create socket: %w", err)
+		}
+		s.listener = listener
 
-	// Set socket permissions
-	if err := os.Chmod(socketPath, 0600); err != nil {
-		listener.Close()
-		return fmt.Errorf("failed to set socket permissions: %w", err)
+		// Set socket permissions
+		if err := os.Chmod(socketPath, 0600); err != nil {
+			listener.Close()
+			return fmt.Errorf("failed to set socket permissions: %w", err)
+		}
 	}
 
 	// Write PID file
@@ -206,10 +238,79 @@ func (s *Server) handleConnection(conn net.Conn) {
 			resp.Notify = notifyResp
 		}
 
+	case MessageTypeRemind:
+		if req.Remind == nil {
+			s.sendError(conn, "missing remind payload")
+			return
+		}
+		if err := s.reminders.schedule(req.Remind); err != nil {
+			resp.Error = err.Error()
+		}
+
+	case MessageTypeCancelRemind:
+		if req.Remind == nil {
+			s.sendError(conn, "missing remind payload")
+			return
+		}
+		s.reminders.cancel(req.Remind.SessionID)
+
+	case MessageTypeSubscribe:
+		s.streamEvents(conn, resp)
+		return
+
+	case MessageTypeEvent:
+		if req.Event == nil {
+			s.sendError(conn, "missing event payload")
+			return
+		}
+		s.events.publish(*req.Event)
+
+	case MessageTypePlay:
+		if req.Play == nil {
+			s.sendError(conn, "missing play payload")
+			return
+		}
+		if err := s.sounds.play(req.Play); err != nil {
+			resp.Error = err.Error()
+		}
+
+	case MessageTypeCheck, MessageTypeClaim:
+		if req.Claim == nil {
+			s.sendError(conn, "missing claim payload")
+			return
+		}
+		handle := s.dedup.claim
+		if req.Type == MessageTypeCheck {
+			handle = s.dedup.check
+		}
+		claimResp, err := handle(req.Claim)
+		if err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Claim = claimResp
+		}
+
+	case MessageTypeForget:
+		if req.Claim == nil {
+			s.sendError(conn, "missing claim payload")
+			return
+		}
+		s.dedup.forget(req.Claim.SessionID)
+
+	case MessageTypeAggregate:
+		if req.Aggregate == nil {
+			s.sendError(conn, "missing aggregate payload")
+			return
+		}
+		if err := s.aggregator.add(req.Aggregate); err != nil {
+			resp.Error = err.Error()
+		}
+
 	case MessageTypePing:
 		resp.Ping = &PingResponse{
 			Version: ProtocolVersion,
 			Uptime:  int64(time.Since(s.startTime).Seconds()),
+			Systemd: s.inherited,
 		}
 
 	case MessageTypeStop:
@@ -240,6 +341,48 @@ func (s *Server) handleConnection(conn net.Conn) {
 	}
 }
 
+// streamEvents acknowledges a subscribe request and writes events to conn until the
+// client disconnects or the daemon shuts down
+func (s *Server) streamEvents(conn net.Conn, ack Response) {
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	encoder := json.NewEncoder(conn)
+	if err := encoder.Encode(ack); err != nil {
+		log.Printf("[ERROR] Failed to acknowledge subscription: %v", err)
+		return
+	}
+	log.Printf("[INFO] Subscriber connected (%d total)", s.events.count())
+
+	// The client sends nothing more, so a read only returns once it disconnects
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return
+	}
+	gone := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, conn)
+		close(gone)
+	}()
+
+	for {
+		select {
+		case ev := <-ch:
+			if err := conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
+				return
+			}
+			if err := encoder.Encode(ev); err != nil {
+				log.Printf("[INFO] Subscriber gone: %v", err)
+				return
+			}
+		case <-gone:
+			log.Printf("[INFO] Subscriber disconnected")
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
 // handleNotification processes a notification request
 func (s *Server) handleNotification(req *NotifyRequest) (*NotifyResponse, error) {
 	// Determine focus target
@@ -273,7 +416,7 @@ func (s *Server) handleNotification(req *NotifyRequest) (*NotifyResponse, error)
 
 	// Store focus context
 	s.focusCtxMu.Lock()
-	s.focusCtx[id] = focusInfo{target: focusTarget, folder: req.FocusFolder}
+	s.focusCtx[id] = focusInfo{target: focusTarget, folder: req.FocusFolder, sessionID: req.SessionID, status: req.Status}
 	s.focusCtxMu.Unlock()
 
 	log.Printf("[INFO] Notification sent: ID=%d, focus_target=%s, focus_folder=%s", id, focusTarget, req.FocusFolder)
@@ -303,6 +446,7 @@ func (s *Server) onActionInvoked(sig *notify.ActionInvokedSignal) {
 		log.Printf("[WARN] No focus context for notification %d", sig.ID)
 		return
 	}
+	s.events.publish(Event{Type: EventClicked, SessionID: info.sessionID, Status: info.status, NotificationID: sig.ID})
 
 	// Attempt to focus
 	log.Printf("[INFO] Attempting to focus: %s (folder: %s)", focusTarget, focusFolder)
@@ -322,8 +466,29 @@ func (s *Server) onActionInvoked(sig *notify.ActionInvokedSignal) {
 func (s *Server) onNotificationClosed(sig *notify.NotificationClosedSignal) {
 	// Clean up focus context
 	s.focusCtxMu.Lock()
+	info, exists := s.focusCtx[sig.ID]
 	delete(s.focusCtx, sig.ID)
 	s.focusCtxMu.Unlock()
+
+	// Other applications' notifications and clicked ones (already cleaned up) have no context
+	if exists {
+		s.events.publish(Event{Type: EventClosed, SessionID: info.sessionID, Status: info.status,
+			Reason: closedReason(sig.Reason), NotificationID: sig.ID})
+	}
+}
+
+// closedReason names why a notification was closed
+func closedReason(reason notify.Reason) string {
+	switch reason {
+	case notify.ReasonExpired:
+		return "expired"
+	case notify.ReasonDismissedByUser:
+		return "dismissed"
+	case notify.ReasonClosedByCall:
+		return "closed"
+	default:
+		return "unknown"
+	}
 }
 
 // updateActivity updates the last activity timestamp
@@ -347,7 +512,8 @@ func (s *Server) idleChecker() {
 			idle := time.Since(s.lastActivity)
 			s.activityMu.Unlock()
 
-			if idle >= s.idleTimeout {
+			// Pending reminders, watch clients and an open aggregation window keep the daemon alive
+			if idle >= s.idleTimeout && s.reminders.count() == 0 && s.events.count() == 0 && s.aggregator.count() == 0 {
 				log.Printf("[INFO] Idle timeout reached (%v), shutting down", s.idleTimeout)
 				s.mu.Lock()
 				if !s.shutdown {
@@ -375,6 +541,14 @@ func (s *Server) Shutdown() error {
 	close(s.done)
 	s.mu.Unlock()
 
+	// Drop pending reminders
+	s.reminders.stopAll()
+
+	// Deliver notifications waiting for the aggregation window instead of losing them
+	if s.aggregator != nil {
+		s.aggregator.flush()
+	}
+
 	// Close listener
 	if s.listener != nil {
 		s.listener.Close()
@@ -393,6 +567,11 @@ func (s *Server) Shutdown() error {
 		log.Printf("[WARN] Shutdown timeout, forcing exit")
 	}
 
+	// Let sounds in progress finish, then release the audio devices
+	if s.sounds != nil {
+		s.sounds.close()
+	}
+
 	// Close notifier
 	if s.notifier != nil {
 		s.notifier.Close()
@@ -403,8 +582,10 @@ func (s *Server) Shutdown() error {
 		s.conn.Close()
 	}
 
-	// Clean up socket and PID files
-	os.Remove(GetSocketPath())
+	// Clean up socket and PID files; systemd keeps its socket to start the next instance
+	if !s.inherited {
+		os.Remove(GetSocketPath())
+	}
 	os.Remove(GetPidFilePath())
 
 	log.Printf("[INFO] Daemon stopped")
@@ -0,0 +1,103 @@
+//go:build linux
+
+// ABOUTME: Sound playback in the daemon from one long-lived audio context per device.
+// ABOUTME: Decoded samples are cached by the player, so a hook hands over a path and exits.
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/audio"
+)
+
+// soundOutput plays sound files on one device (audio.Player)
+type soundOutput interface {
+	PlayWithVolume(soundPath string, volume float64) error
+	Close() error
+}
+
+// soundPlayer keeps one audio player per output device
+type soundPlayer struct {
+	mu      sync.Mutex
+	players map[string]soundOutput // By device name ("" = system default)
+	wg      sync.WaitGroup
+
+	// Replaceable for tests
+	newOutput func(device string) (soundOutput, error)
+}
+
+func newSoundPlayer() *soundPlayer {
+	return &soundPlayer{
+		players: make(map[string]soundOutput),
+		newOutput: func(device string) (soundOutput, error) {
+			return audio.NewPlayer(device, 1.0)
+		},
+	}
+}
+
+// play starts playing req's file and returns without waiting for it to finish.
+// Errors are returned only for requests that can't be played at all, so the
+// hook can fall back to playing the sound itself.
+func (sp *soundPlayer) play(req *PlayRequest) error {
+	if !filepath.IsAbs(req.Path) {
+		return fmt.Errorf("sound path must be absolute: %s", req.Path)
+	}
+	if _, err := os.Stat(req.Path); err != nil {
+		return fmt.Errorf("sound file not found: %s", req.Path)
+	}
+	volume := req.Volume
+	if volume < 0 || volume > 1 {
+		return fmt.Errorf("volume must be between 0.0 and 1.0, got %v", volume)
+	}
+
+	sp.mu.Lock()
+	if sp.players == nil {
+		sp.mu.Unlock()
+		return fmt.Errorf("daemon is shutting down")
+	}
+	output, ok := sp.players[req.Device]
+	if !ok {
+		var err error
+		if output, err = sp.newOutput(req.Device); err != nil {
+			sp.mu.Unlock()
+			return fmt.Errorf("failed to open audio device: %w", err)
+		}
+		sp.players[req.Device] = output
+	}
+	sp.wg.Add(1)
+	sp.mu.Unlock()
+
+	go func() {
+		defer sp.wg.Done()
+		if err := output.PlayWithVolume(req.Path, volume); err != nil {
+			log.Printf("[ERROR] Failed to play %s: %v", req.Path, err)
+		}
+	}()
+	return nil
+}
+
+// close waits briefly for sounds in progress and releases the audio devices
+func (sp *soundPlayer) close() {
+	done := make(chan struct{})
+	go func() {
+		sp.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		log.Printf("[WARN] Sounds still playing at shutdown")
+	}
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	for _, output := range sp.players {
+		_ = output.Close()
+	}
+	sp.players = nil
+}
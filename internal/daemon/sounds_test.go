@@ -0,0 +1,115 @@
+//go:build linux
+
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// fakeOutput records what it played
+type fakeOutput struct {
+	device string
+	mu     sync.Mutex
+	played []string
+	closed bool
+}
+
+func (f *fakeOutput) PlayWithVolume(soundPath string, volume float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.played = append(f.played, soundPath)
+	return nil
+}
+
+func (f *fakeOutput) Close() error {
+	f.closed = true
+	return nil
+}
+
+func newTestSoundPlayer() (*soundPlayer, map[string]*fakeOutput) {
+	outputs := make(map[string]*fakeOutput)
+	sp := newSoundPlayer()
+	sp.newOutput = func(device string) (soundOutput, error) {
+		out := &fakeOutput{device: device}
+		outputs[device] = out
+		return out, nil
+	}
+	return sp, outputs
+}
+
+func TestSoundPlayer_ReusesOutputPerDevice(t *testing.T) {
+	sp, outputs := newTestSoundPlayer()
+	path := filepath.Join(t.TempDir(), "ding.mp3")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, req := range []*PlayRequest{
+		{Path: path, Volume: 0.5},
+		{Path: path, Volume: 1},
+		{Path: path, Volume: 0.3, Device: "USB Headset"},
+	} {
+		if err := sp.play(req); err != nil {
+			t.Fatalf("play(%+v) error: %v", req, err)
+		}
+	}
+	sp.close()
+
+	if len(outputs) != 2 {
+		t.Fatalf("opened %d outputs, want one per device", len(outputs))
+	}
+	if got := len(outputs[""].played); got != 2 {
+		t.Errorf("default device played %d sounds, want 2", got)
+	}
+	if !outputs["USB Headset"].closed || !outputs[""].closed {
+		t.Error("close should release every output")
+	}
+	if err := sp.play(&PlayRequest{Path: path, Volume: 1}); err == nil {
+		t.Error("play after close should fail")
+	}
+}
+
+func TestSoundPlayer_RejectsUnplayableRequests(t *testing.T) {
+	sp, outputs := newTestSoundPlayer()
+	path := filepath.Join(t.TempDir(), "ding.mp3")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, req := range []*PlayRequest{
+		{Path: "sounds/ding.mp3", Volume: 1},
+		{Path: filepath.Join(t.TempDir(), "missing.mp3"), Volume: 1},
+		{Path: path, Volume: 1.5},
+	} {
+		if err := sp.play(req); err == nil {
+			t.Errorf("play(%+v) should fail so the hook plays the sound itself", req)
+		}
+	}
+	if len(outputs) != 0 {
+		t.Errorf("no output should be opened for rejected requests")
+	}
+}
+
+func TestPlaySound_OverSocket(t *testing.T) {
+	s, client := startTestServer(t)
+	sp, outputs := newTestSoundPlayer()
+	s.sounds = sp
+	path := filepath.Join(t.TempDir(), "ding.mp3")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.PlaySound(&PlayRequest{Path: path, Volume: 0.8}); err != nil {
+		t.Fatalf("PlaySound error: %v", err)
+	}
+	if err := client.PlaySound(&PlayRequest{Path: "/missing.mp3", Volume: 0.8}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	sp.close()
+	if len(outputs[""].played) != 1 {
+		t.Errorf("played %v, want the one sound", outputs[""].played)
+	}
+}
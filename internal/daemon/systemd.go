@@ -0,0 +1,336 @@
+//go:build linux
+
+// ABOUTME: systemd user service support: socket activation (LISTEN_FDS) and the unit files.
+// ABOUTME: Installs, removes and reports a claude-notifications.socket/.service pair via systemctl --user.
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Unit names of the systemd user service
+const (
+	SocketUnitName  = "claude-notifications.socket"
+	ServiceUnitName = "claude-notifications.service"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd (SD_LISTEN_FDS_START)
+const listenFDsStart = 3
+
+// SystemdListener returns the listening socket passed by systemd socket activation,
+// or nil when the process wasn't socket-activated. The LISTEN_* variables are
+// removed so child processes (reminders) don't pick them up.
+func SystemdListener() (net.Listener, error) {
+	n := listenFDs(os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), os.Getpid())
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+	if n == 0 {
+		return nil, nil
+	}
+	if n > 1 {
+		return nil, fmt.Errorf("expected one socket from systemd, got %d", n)
+	}
+
+	f := os.NewFile(uintptr(listenFDsStart), "systemd-socket")
+	defer f.Close()
+	listener, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use socket from systemd: %w", err)
+	}
+	return listener, nil
+}
+
+// listenFDs returns the number of sockets passed to process pid, 0 if the variables are
+// missing or meant for another process
+func listenFDs(listenPID, listenFDs string, pid int) int {
+	if p, err := strconv.Atoi(listenPID); err != nil || p != pid {
+		return 0
+	}
+	n, err := strconv.Atoi(listenFDs)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// UserUnitDir returns the directory for systemd user units ($XDG_CONFIG_HOME/systemd/user)
+func UserUnitDir() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find home directory: %w", err)
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "systemd", "user"), nil
+}
+
+// SocketUnit returns the .socket unit; %t is the user's runtime dir, which GetSocketPath
+// also uses
+func SocketUnit() string {
+	return `[Unit]
+Description=claude-notifications daemon socket
+
+[Socket]
+ListenStream=%t/claude-notifications.sock
+SocketMode=0600
+
+[Install]
+WantedBy=sockets.target
+`
+}
+
+// ServiceUnit returns the .service unit that runs binary as the daemon
+func ServiceUnit(binary string) string {
+	return fmt.Sprintf(`[Unit]
+Description=claude-notifications daemon (desktop notifications with click-to-focus)
+Requires=%s
+After=%s graphical-session.target
+
+[Service]
+Type=simple
+ExecStart=%s daemon
+Restart=on-failure
+RestartSec=2
+`, SocketUnitName, SocketUnitName, systemdQuote(binary))
+}
+
+// systemdQuote quotes a path for ExecStart when it contains spaces, quotes or specifiers
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// ServiceBinaryLink returns the stable path the service runs the daemon from
+// ($XDG_DATA_HOME/claude-notifications/claude-notifications). It is a symlink to the
+// binary in the versioned plugin directory, which a plugin update replaces.
+func ServiceBinaryLink() (string, error) {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find home directory: %w", err)
+		}
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataDir, "claude-notifications", "claude-notifications"), nil
+}
+
+// linkBinary points link at binary, replacing the old link atomically
+func linkBinary(link, binary string) error {
+	if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(link), err)
+	}
+	tmp := link + ".tmp"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(binary, tmp); err != nil {
+		return fmt.Errorf("failed to link %s: %w", binary, err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to link %s: %w", binary, err)
+	}
+	return nil
+}
+
+// RefreshServiceBinary points the service's binary link at binary if a plugin update
+// moved it. Does nothing when the service isn't installed (no link).
+func RefreshServiceBinary(binary string) error {
+	link, err := ServiceBinaryLink()
+	if err != nil {
+		return err
+	}
+	current, err := os.Readlink(link)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err == nil && current == binary {
+		return nil
+	}
+	return linkBinary(link, binary)
+}
+
+// InstallService links binary to ServiceBinaryLink, writes the units, stops a daemon
+// started on demand and enables the socket. The service starts with the next connection.
+func InstallService(binary string) error {
+	dir, err := UserUnitDir()
+	if err != nil {
+		return err
+	}
+	link, err := ServiceBinaryLink()
+	if err != nil {
+		return err
+	}
+	if err := linkBinary(link, binary); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SocketUnitName), []byte(SocketUnit()), 0644); err != nil {
+		return fmt.Errorf("failed to write socket unit: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ServiceUnitName), []byte(ServiceUnit(link)), 0644); err != nil {
+		return fmt.Errorf("failed to write service unit: %w", err)
+	}
+
+	// A forked daemon would keep serving the old socket file
+	if IsDaemonRunning() {
+		_ = StopDaemon()
+	}
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	return systemctl("enable", "--now", SocketUnitName)
+}
+
+// UninstallService stops and disables the units and removes their files. Hooks go back
+// to starting the daemon on demand.
+func UninstallService() error {
+	dir, err := UserUnitDir()
+	if err != nil {
+		return err
+	}
+	// Ignore errors: the units may already be stopped or unknown to systemd
+	_ = systemctl("disable", "--now", SocketUnitName)
+	_ = systemctl("stop", ServiceUnitName)
+
+	for _, name := range []string{SocketUnitName, ServiceUnitName} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", name, err)
+		}
+	}
+	if link, err := ServiceBinaryLink(); err == nil {
+		if err := os.Remove(link); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", link, err)
+		}
+	}
+	return systemctl("daemon-reload")
+}
+
+// ServiceBinary returns the binary in ExecStart of the installed service unit and an
+// error if it can't be run, e.g. a unit written before the binary link pointing into a
+// plugin directory that an update removed. installed is false without a service unit.
+func ServiceBinary() (binary string, installed bool, err error) {
+	dir, err := UserUnitDir()
+	if err != nil {
+		return "", false, err
+	}
+	unit, err := os.ReadFile(filepath.Join(dir, ServiceUnitName))
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", true, err
+	}
+
+	binary = execStartBinary(string(unit))
+	if binary == "" {
+		return "", true, fmt.Errorf("no ExecStart in %s", ServiceUnitName)
+	}
+	info, err := os.Stat(binary)
+	if err != nil {
+		return binary, true, fmt.Errorf("daemon binary is missing: %w", err)
+	}
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		return binary, true, fmt.Errorf("daemon binary %s is not executable", binary)
+	}
+	return binary, true, nil
+}
+
+// execStartBinary returns the program of the ExecStart line written by ServiceUnit
+func execStartBinary(unit string) string {
+	for _, line := range strings.Split(unit, "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "ExecStart=")
+		if !ok {
+			continue
+		}
+		var binary string
+		if strings.HasPrefix(value, `"`) {
+			// Quoted by systemdQuote: find the closing quote, skipping escaped ones
+			var b strings.Builder
+			for i := 1; i < len(value); i++ {
+				switch c := value[i]; {
+				case c == '\\' && i+1 < len(value):
+					i++
+					b.WriteByte(value[i])
+				case c == '"':
+					i = len(value)
+				default:
+					b.WriteByte(c)
+				}
+			}
+			binary = b.String()
+		} else {
+			binary, _, _ = strings.Cut(value, " ")
+		}
+		return strings.ReplaceAll(binary, "%%", "%")
+	}
+	return ""
+}
+
+// ServiceStatus describes the systemd user service
+type ServiceStatus struct {
+	UnitDir       string `json:"unit_dir"`
+	Installed     bool   `json:"installed"`      // Both unit files exist
+	Binary        string `json:"binary"`         // Program in ExecStart of the service unit
+	BinaryError   string `json:"binary_error"`   // Why Binary can't be run ("" = ok)
+	SocketEnabled string `json:"socket_enabled"` // systemctl is-enabled of the socket
+	SocketActive  string `json:"socket_active"`  // systemctl is-active of the socket
+	ServiceActive string `json:"service_active"` // systemctl is-active of the service
+}
+
+// GetServiceStatus reports the unit files and what systemctl says about them
+func GetServiceStatus() (*ServiceStatus, error) {
+	dir, err := UserUnitDir()
+	if err != nil {
+		return nil, err
+	}
+	status := &ServiceStatus{UnitDir: dir, Installed: true}
+	for _, name := range []string{SocketUnitName, ServiceUnitName} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			status.Installed = false
+		}
+	}
+	if binary, installed, err := ServiceBinary(); installed {
+		status.Binary = binary
+		if err != nil {
+			status.BinaryError = err.Error()
+		}
+	}
+	status.SocketEnabled = systemctlQuery("is-enabled", SocketUnitName)
+	status.SocketActive = systemctlQuery("is-active", SocketUnitName)
+	status.ServiceActive = systemctlQuery("is-active", ServiceUnitName)
+	return status, nil
+}
+
+// systemctl runs systemctl --user with args
+func systemctl(args ...string) error {
+	out, err := exec.Command("systemctl", append([]string{"--user"}, args...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl --user %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// systemctlQuery returns the one-word answer of systemctl --user is-enabled/is-active,
+// which exits non-zero for "disabled" or "inactive"
+func systemctlQuery(args ...string) string {
+	out, _ := exec.Command("systemctl", append([]string{"--user"}, args...)...).Output()
+	if answer := strings.TrimSpace(string(out)); answer != "" {
+		return answer
+	}
+	return "unknown"
+}
@@ -0,0 +1,180 @@
+//go:build linux
+
+package daemon
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListenFDs(t *testing.T) {
+	tests := []struct {
+		pid, fds string
+		want     int
+	}{
+		{"100", "1", 1},
+		{"100", "2", 2},
+		{"101", "1", 0}, // Meant for another process
+		{"", "1", 0},
+		{"100", "", 0},
+		{"100", "-1", 0},
+	}
+	for _, tt := range tests {
+		if got := listenFDs(tt.pid, tt.fds, 100); got != tt.want {
+			t.Errorf("listenFDs(%q, %q) = %d, want %d", tt.pid, tt.fds, got, tt.want)
+		}
+	}
+}
+
+func TestSystemdListener_NotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	t.Setenv("LISTEN_FDS", "1")
+
+	listener, err := SystemdListener()
+	if listener != nil || err != nil {
+		t.Fatalf("expected no listener for another PID, got %v, %v", listener, err)
+	}
+	if _, ok := os.LookupEnv("LISTEN_FDS"); ok {
+		t.Error("LISTEN_FDS should be unset for child processes")
+	}
+}
+
+func TestServiceUnit(t *testing.T) {
+	unit := ServiceUnit("/home/me/my plugins/bin/claude-notifications")
+	if !strings.Contains(unit, `ExecStart="/home/me/my plugins/bin/claude-notifications" daemon`) {
+		t.Errorf("path with a space should be quoted:\n%s", unit)
+	}
+	if !strings.Contains(unit, "Requires="+SocketUnitName) {
+		t.Errorf("service should require the socket:\n%s", unit)
+	}
+
+	if unit := ServiceUnit("/opt/100%/claude-notifications"); !strings.Contains(unit, "ExecStart=/opt/100%%/claude-notifications daemon") {
+		t.Errorf("%% should be escaped:\n%s", unit)
+	}
+}
+
+func TestSocketUnit_MatchesSocketPath(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	want := "ListenStream=%t/" + filepath.Base(GetSocketPath())
+	if !strings.Contains(SocketUnit(), want) {
+		t.Errorf("socket unit should listen on %s:\n%s", want, SocketUnit())
+	}
+}
+
+func TestUserUnitDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/cfg")
+	dir, err := UserUnitDir()
+	if err != nil || dir != "/tmp/cfg/systemd/user" {
+		t.Errorf("UserUnitDir() = %q, %v", dir, err)
+	}
+}
+
+func TestExecStartBinary(t *testing.T) {
+	tests := []string{
+		"/home/me/.local/share/claude-notifications/claude-notifications",
+		"/home/me/my plugins/bin/claude-notifications",
+		`/opt/"quoted"\dir/claude-notifications`,
+		"/opt/100%/claude-notifications",
+	}
+	for _, binary := range tests {
+		if got := execStartBinary(ServiceUnit(binary)); got != binary {
+			t.Errorf("execStartBinary(ServiceUnit(%q)) = %q", binary, got)
+		}
+	}
+	if got := execStartBinary(SocketUnit()); got != "" {
+		t.Errorf("execStartBinary(socket unit) = %q, want empty", got)
+	}
+}
+
+func TestServiceBinary_DetectsMissingBinary(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if _, installed, err := ServiceBinary(); installed || err != nil {
+		t.Fatalf("ServiceBinary() without units = installed %v, %v", installed, err)
+	}
+
+	dir, _ := UserUnitDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeUnit := func(binary string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, ServiceUnitName), []byte(ServiceUnit(binary)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A unit from before the binary link, pointing into a removed plugin version
+	removed := filepath.Join(t.TempDir(), "1.27.0", "bin", "claude-notifications")
+	writeUnit(removed)
+	binary, installed, err := ServiceBinary()
+	if !installed || binary != removed || err == nil {
+		t.Errorf("ServiceBinary() = %q, installed %v, %v; want an error for the missing binary", binary, installed, err)
+	}
+
+	current := filepath.Join(t.TempDir(), "claude-notifications")
+	if err := os.WriteFile(current, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeUnit(current)
+	if _, _, err := ServiceBinary(); err != nil {
+		t.Errorf("ServiceBinary() error for an existing binary: %v", err)
+	}
+}
+
+func TestRefreshServiceBinary(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	link, err := ServiceBinaryLink()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the service there is no link to keep up to date
+	if err := RefreshServiceBinary("/plugins/1.28.0/bin/claude-notifications"); err != nil {
+		t.Fatalf("RefreshServiceBinary() error: %v", err)
+	}
+	if _, err := os.Lstat(link); !os.IsNotExist(err) {
+		t.Errorf("link should not be created when the service isn't installed: %v", err)
+	}
+
+	if err := linkBinary(link, "/plugins/1.27.0/bin/claude-notifications"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RefreshServiceBinary("/plugins/1.28.0/bin/claude-notifications"); err != nil {
+		t.Fatalf("RefreshServiceBinary() error: %v", err)
+	}
+	if target, _ := os.Readlink(link); target != "/plugins/1.28.0/bin/claude-notifications" {
+		t.Errorf("link points at %q, want the updated binary", target)
+	}
+}
+
+func TestIsDaemonStarted_DoesNotActivateSocket(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	// A socket unit holds the socket before the daemon runs; connecting would start it
+	listener, err := net.Listen("unix", GetSocketPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	accepted := make(chan struct{}, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+			accepted <- struct{}{}
+		}
+	}()
+
+	if IsDaemonStarted() {
+		t.Error("IsDaemonStarted() = true without a daemon process")
+	}
+	select {
+	case <-accepted:
+		t.Error("IsDaemonStarted() connected to the socket")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
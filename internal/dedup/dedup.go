@@ -20,6 +20,14 @@ func NewManager() *Manager {
 	}
 }
 
+// NewManagerWithDir creates a deduplication manager that keeps its files in dir
+// instead of the system temp directory (used by replay to stay isolated)
+func NewManagerWithDir(dir string) *Manager {
+	return &Manager{
+		tempDir: dir,
+	}
+}
+
 // getLockPath returns the path to the lock file for a session and hook event
 // If hookEvent is empty, uses a global lock for the session (backward compatibility)
 func (m *Manager) getLockPath(sessionID string, hookEvent ...string) string {
@@ -111,6 +119,28 @@ func (m *Manager) CleanupForSession(sessionID string) error {
 	return nil
 }
 
+// CleanupSessionLocks removes every lock file belonging to a session
+// (global, per-hook and content locks). Called when the session ends.
+func (m *Manager) CleanupSessionLocks(sessionID string) error {
+	patterns := []string{
+		fmt.Sprintf("claude-notification-%s.lock", sessionID),
+		fmt.Sprintf("claude-notification-%s-*.lock", sessionID),
+	}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(m.tempDir, pattern))
+		if err != nil {
+			return err
+		}
+		for _, path := range matches {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // AcquireContentLock acquires a lock for content-based deduplication
 // Uses a separate lock file with longer TTL (5s) to prevent race conditions
 // between different hook types (Stop, Notification) with same content
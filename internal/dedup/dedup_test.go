@@ -188,6 +188,33 @@ func TestCleanupForSession(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCleanupSessionLocks(t *testing.T) {
+	mgr := NewManager()
+	sessionID := "test-session-end-789"
+
+	// Global, per-hook and content locks for this session
+	_, err := mgr.AcquireLock(sessionID)
+	require.NoError(t, err)
+	_, err = mgr.AcquireLock(sessionID, "Stop")
+	require.NoError(t, err)
+	_, err = mgr.AcquireContentLock(sessionID)
+	require.NoError(t, err)
+
+	// Lock for a different session
+	_, err = mgr.AcquireLock("other-session-789", "Stop")
+	require.NoError(t, err)
+	defer func() { _ = mgr.ReleaseLock("other-session-789", "Stop") }()
+
+	err = mgr.CleanupSessionLocks(sessionID)
+	require.NoError(t, err)
+
+	matches, err := filepath.Glob(filepath.Join(mgr.tempDir, "claude-notification-"+sessionID+"*.lock"))
+	require.NoError(t, err)
+	assert.Empty(t, matches, "all locks of the session should be removed")
+
+	assert.FileExists(t, mgr.getLockPath("other-session-789", "Stop"))
+}
+
 func TestGetLockPath_WithHookEvent(t *testing.T) {
 	mgr := NewManager()
 	sessionID := "test-session-456"
@@ -0,0 +1,281 @@
+// ABOUTME: Health checks for every notification path: config, icon, sounds, audio device, daemon, focus, terminal, webhook.
+// ABOUTME: Backs `claude-notifications doctor`; platform checks live in doctor_linux.go and doctor_other.go.
+package doctor
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/audio"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/platform"
+	"github.com/777genius/claude-notifications/internal/webhook"
+)
+
+// Check results
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn" // Works, but not as configured or with reduced features
+	StatusFail = "fail" // Notifications on this path won't arrive
+	StatusSkip = "skip" // Not enabled in the config
+)
+
+// Check is the result of one check
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Hint   string `json:"hint,omitempty"` // How to fix a warning or failure
+}
+
+// Options controls how the checks run
+type Options struct {
+	PluginRoot  string
+	WebhookTest bool // Send a test notification to every enabled webhook target
+}
+
+// Failed reports whether any check failed
+func Failed(checks []Check) bool {
+	for _, c := range checks {
+		if c.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs every check in order, loading the config from opts.PluginRoot
+func Run(opts Options) []Check {
+	return newRunner(opts).run()
+}
+
+// runner holds the checks so far and the system calls, which tests replace
+type runner struct {
+	opts   Options
+	checks []Check
+
+	listDevices func() ([]audio.DeviceInfo, error)
+	checkSound  func(path string) (time.Duration, error)
+	sendWebhook func(cfg *config.Config, wh config.WebhookConfig) error
+}
+
+func newRunner(opts Options) *runner {
+	return &runner{
+		opts:        opts,
+		listDevices: audio.ListDevices,
+		checkSound:  audio.CheckFile,
+		sendWebhook: sendTestWebhook,
+	}
+}
+
+func (r *runner) run() []Check {
+	cfg := r.checkConfig()
+	r.checkIcon(cfg)
+	r.checkSounds(cfg)
+	r.checkAudioDevice(cfg)
+	r.checkPlatform(cfg)
+	r.checkWebhook(cfg)
+	return r.checks
+}
+
+func (r *runner) add(name, status, detail, hint string) {
+	r.checks = append(r.checks, Check{Name: name, Status: status, Detail: detail, Hint: hint})
+}
+
+// checkConfig validates the config file and loads the effective config; on failure the defaults are used
+func (r *runner) checkConfig() *config.Config {
+	path := config.ActiveConfigPath(r.opts.PluginRoot)
+	var fileDetail string
+	if !platform.FileExists(path) {
+		fileDetail = "no config file, using defaults"
+	} else {
+		problems, err := config.ValidateFile(path)
+		if err != nil {
+			r.add("config", StatusFail, err.Error(), "check the file permissions")
+			return config.DefaultConfig()
+		}
+		if config.HasErrors(problems) {
+			r.add("config", StatusFail, fmt.Sprintf("%s: %d problem(s), first: %s", path, len(problems), problems[0]),
+				"run `claude-notifications config validate` for the full list")
+			return config.DefaultConfig()
+		}
+		fileDetail = "loaded " + path
+		if len(problems) > 0 {
+			fileDetail += fmt.Sprintf(" (%d warning(s))", len(problems))
+		}
+	}
+
+	cfg, err := config.LoadFromPluginRoot(r.opts.PluginRoot)
+	if err != nil {
+		r.add("config", StatusFail, err.Error(), "")
+		return config.DefaultConfig()
+	}
+	if n := len(cfg.EnvOverrides()); n > 0 {
+		fileDetail += fmt.Sprintf(", %d CLAUDE_NOTIFICATIONS_* override(s)", n)
+	}
+	if err := cfg.Validate(); err != nil {
+		r.add("config", StatusFail, fmt.Sprintf("%s; effective config is invalid: %v", fileDetail, err),
+			"check the CLAUDE_NOTIFICATIONS_* environment variables (`claude-notifications config show`)")
+		return config.DefaultConfig()
+	}
+	r.add("config", StatusPass, fileDetail, "")
+	return cfg
+}
+
+func (r *runner) checkIcon(cfg *config.Config) {
+	icon := cfg.Notifications.Desktop.AppIcon
+	switch {
+	case !cfg.IsDesktopEnabled():
+		r.add("icon", StatusSkip, "desktop notifications disabled", "")
+	case icon == "":
+		r.add("icon", StatusPass, "no icon configured", "")
+	case !platform.FileExists(icon):
+		r.add("icon", StatusWarn, icon+": file not found (notifications are shown without an icon)",
+			"fix notifications.desktop.appIcon or leave it empty")
+	default:
+		r.add("icon", StatusPass, icon, "")
+	}
+}
+
+// checkSounds decodes each distinct sound file of the enabled statuses
+func (r *runner) checkSounds(cfg *config.Config) {
+	if !cfg.IsDesktopEnabled() || !cfg.Notifications.Desktop.Sound {
+		r.add("sounds", StatusSkip, "desktop sound disabled", "")
+		return
+	}
+
+	statusesByPath := make(map[string][]string)
+	var paths []string
+	for name, info := range cfg.Statuses {
+		if info.Sound == "" || !cfg.IsStatusEnabled(name) {
+			continue
+		}
+		if _, seen := statusesByPath[info.Sound]; !seen {
+			paths = append(paths, info.Sound)
+		}
+		statusesByPath[info.Sound] = append(statusesByPath[info.Sound], name)
+	}
+	sort.Strings(paths)
+	if len(paths) == 0 {
+		r.add("sounds", StatusPass, "no sounds configured", "")
+		return
+	}
+
+	for _, path := range paths {
+		statuses := statusesByPath[path]
+		sort.Strings(statuses)
+		used := "used by " + strings.Join(statuses, ", ")
+		name := "sound " + filepath.Base(path)
+
+		if !platform.FileExists(path) {
+			r.add(name, StatusFail, fmt.Sprintf("%s: file not found (%s)", path, used),
+				"fix the sound path in statuses, or run `bin/list-sounds` for the built-in and system sounds")
+			continue
+		}
+		duration, err := r.checkSound(path)
+		if err != nil {
+			r.add(name, StatusFail, fmt.Sprintf("%s: %v (%s)", path, err, used),
+				"use an MP3, WAV, FLAC, OGG or AIFF file that plays in other players")
+			continue
+		}
+		r.add(name, StatusPass, fmt.Sprintf("%s: %.1fs (%s)", path, duration.Seconds(), used), "")
+	}
+}
+
+func (r *runner) checkAudioDevice(cfg *config.Config) {
+	if !cfg.IsDesktopEnabled() || !cfg.Notifications.Desktop.Sound {
+		r.add("audio device", StatusSkip, "desktop sound disabled", "")
+		return
+	}
+
+	devices, err := r.listDevices()
+	if err != nil {
+		r.add("audio device", StatusWarn, fmt.Sprintf("cannot list audio devices: %v", err),
+			"check that an audio server (PulseAudio, PipeWire, CoreAudio) is running")
+		return
+	}
+
+	wanted := cfg.Notifications.Desktop.AudioDevice
+	names := make([]string, 0, len(devices))
+	defaultName := ""
+	for _, d := range devices {
+		if wanted != "" && d.Name == wanted {
+			r.add("audio device", StatusPass, wanted, "")
+			return
+		}
+		names = append(names, d.Name)
+		if d.IsDefault {
+			defaultName = d.Name
+		}
+	}
+
+	switch {
+	case wanted != "":
+		r.add("audio device", StatusFail, fmt.Sprintf("%q not found; available: %s", wanted, strings.Join(names, ", ")),
+			"set notifications.desktop.audioDevice to one of the available devices (see `bin/list-devices`) or leave it empty")
+	case len(devices) == 0:
+		r.add("audio device", StatusWarn, "no output devices found", "connect or enable an audio output device")
+	case defaultName != "":
+		r.add("audio device", StatusPass, "system default ("+defaultName+")", "")
+	default:
+		r.add("audio device", StatusPass, "system default", "")
+	}
+}
+
+// checkWebhook checks every enabled webhook target; "default" is reported as "webhook"
+func (r *runner) checkWebhook(cfg *config.Config) {
+	targets := cfg.WebhookTargets()
+	if len(targets) == 0 {
+		r.add("webhook", StatusSkip, "webhook disabled", "")
+		return
+	}
+	for _, wh := range targets {
+		r.checkWebhookTarget(cfg, wh)
+	}
+}
+
+func (r *runner) checkWebhookTarget(cfg *config.Config, wh config.WebhookConfig) {
+	name, hint := "webhook", "set notifications.webhook.url to the full http(s) URL of the webhook"
+	if wh.Name != config.DefaultWebhookTarget {
+		name = "webhook " + wh.Name
+		hint = fmt.Sprintf("set the url of webhook target %q (notifications.webhooks) to the full http(s) URL of the webhook", wh.Name)
+	}
+
+	if err := webhook.ValidateURL(wh.URL); err != nil {
+		r.add(name, StatusFail, fmt.Sprintf("%s: %v", wh.Preset, err), hint)
+		return
+	}
+	// Only the host: webhook URLs often embed their secret
+	target := wh.Preset
+	if u, err := url.Parse(wh.URL); err == nil {
+		target = fmt.Sprintf("%s, %s", wh.Preset, u.Host)
+	}
+
+	if !r.opts.WebhookTest {
+		r.add(name, StatusPass, target+" (URL valid; --webhook-test sends a test message)", "")
+		return
+	}
+	if err := r.sendWebhook(cfg, wh); err != nil {
+		r.add(name, StatusFail, fmt.Sprintf("%s: test message failed: %v", target, err),
+			"check the URL, headers, chat_id, room_id and token; the debug log has the response body")
+		return
+	}
+	r.add(name, StatusPass, target+": test message delivered", "")
+}
+
+// sendTestWebhook sends one notification to wh through the regular webhook sender,
+// with a status the target accepts
+func sendTestWebhook(cfg *config.Config, wh config.WebhookConfig) error {
+	status := analyzer.StatusTaskComplete
+	if !wh.AcceptsStatus(string(status)) {
+		status = analyzer.Status(wh.Statuses[0])
+	}
+	sender := webhook.New(cfg)
+	defer func() { _ = sender.Shutdown(5 * time.Second) }()
+	return sender.SendTo([]string{wh.Name}, status, "Test notification from `claude-notifications doctor`", "doctor", "")
+}
@@ -0,0 +1,100 @@
+//go:build linux
+
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/daemon"
+	"github.com/777genius/claude-notifications/internal/notifier"
+)
+
+// checkPlatform checks the D-Bus session bus, the click-to-focus daemon, the focus tools and the terminal
+func (r *runner) checkPlatform(cfg *config.Config) {
+	if !cfg.IsDesktopEnabled() {
+		r.add("dbus", StatusSkip, "desktop notifications disabled", "")
+	} else if err := daemon.CheckSessionBus(); err != nil {
+		r.add("dbus", StatusFail, fmt.Sprintf("cannot connect to the session bus: %v", err),
+			"run inside a desktop session, or export DBUS_SESSION_BUS_ADDRESS (e.g. unix:path=/run/user/$UID/bus)")
+	} else {
+		r.add("dbus", StatusPass, "session bus reachable", "")
+	}
+
+	r.checkDaemon(cfg)
+	r.checkService()
+	r.checkFocusTools(cfg)
+
+	terminal := daemon.GetTerminalName()
+	if terminal == "" {
+		terminal = "unknown"
+	}
+	detail := "terminal " + terminal
+	if mux := notifier.DetectMultiplexer(); mux != "" {
+		detail += ", multiplexer " + mux
+	}
+	r.add("terminal", StatusPass, detail, "")
+}
+
+func (r *runner) checkDaemon(cfg *config.Config) {
+	if !cfg.IsDesktopEnabled() || !cfg.Notifications.Desktop.ClickToFocus {
+		r.add("daemon", StatusSkip, "click-to-focus disabled", "")
+		return
+	}
+
+	client, err := daemon.NewClient()
+	if err == nil {
+		var ping *daemon.PingResponse
+		if ping, err = client.Ping(); err == nil {
+			mode := "started on demand"
+			if ping.Systemd {
+				mode = "systemd service"
+			}
+			r.add("daemon", StatusPass, fmt.Sprintf("running (version %s, up %ds, %s)", ping.Version, ping.Uptime, mode), "")
+			return
+		}
+	}
+	// The hook starts the daemon on demand, so a stopped daemon is not an error
+	r.add("daemon", StatusWarn, fmt.Sprintf("not running: %v", err),
+		"it starts with the next notification; if it keeps failing, run `claude-notifications daemon` to see why, "+
+			"or `claude-notifications service install` to keep it running")
+}
+
+// checkService checks that the systemd user service, if installed, can start the daemon
+func (r *runner) checkService() {
+	binary, installed, err := daemon.ServiceBinary()
+	switch {
+	case !installed && err != nil:
+		r.add("service", StatusWarn, err.Error(), "")
+	case !installed:
+		r.add("service", StatusSkip, "systemd user service not installed", "")
+	case err != nil:
+		r.add("service", StatusWarn, fmt.Sprintf("the service can't start the daemon: %v", err),
+			"run `claude-notifications service install` again; until then hooks start the daemon themselves")
+	default:
+		r.add("service", StatusPass, "daemon binary "+binary, "")
+	}
+}
+
+func (r *runner) checkFocusTools(cfg *config.Config) {
+	if !cfg.IsDesktopEnabled() || !cfg.Notifications.Desktop.ClickToFocus {
+		r.add("focus tools", StatusSkip, "click-to-focus disabled", "")
+		return
+	}
+
+	var available []string
+	for tool, ok := range daemon.DetectFocusTools() {
+		if ok {
+			available = append(available, tool)
+		}
+	}
+	sort.Strings(available)
+	if len(available) == 0 {
+		r.add("focus tools", StatusWarn, "none found; clicking a notification won't focus the terminal",
+			"install xdotool (X11), kdotool (KDE Wayland), wlrctl (wlroots) or the GNOME activate-window-by-title extension")
+		return
+	}
+	r.add("focus tools", StatusPass, strings.Join(available, ", "), "")
+}
@@ -0,0 +1,34 @@
+//go:build linux
+
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/daemon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckService(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	r := newRunner(Options{})
+	r.checkService()
+	assert.Equal(t, StatusSkip, find(t, r.checks, "service").Status)
+
+	// The unit still runs a binary from a plugin version an update removed
+	dir, err := daemon.UserUnitDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	removed := filepath.Join(t.TempDir(), "1.27.0", "bin", "claude-notifications")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, daemon.ServiceUnitName), []byte(daemon.ServiceUnit(removed)), 0644))
+
+	r = newRunner(Options{})
+	r.checkService()
+	check := find(t, r.checks, "service")
+	assert.Equal(t, StatusWarn, check.Status)
+	assert.Contains(t, check.Detail, removed)
+	assert.Contains(t, check.Hint, "service install")
+}
@@ -0,0 +1,38 @@
+//go:build !linux
+
+package doctor
+
+import (
+	"runtime"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/notifier"
+)
+
+// checkPlatform checks terminal-notifier on macOS and the terminal
+func (r *runner) checkPlatform(cfg *config.Config) {
+	if runtime.GOOS == "darwin" {
+		if !cfg.IsDesktopEnabled() || !cfg.Notifications.Desktop.ClickToFocus {
+			r.add("terminal-notifier", StatusSkip, "click-to-focus disabled", "")
+		} else if path, err := notifier.GetTerminalNotifierPath(); err != nil {
+			r.add("terminal-notifier", StatusWarn, "not found; notifications are sent without click-to-focus",
+				"run /claude-notifications-go:notifications-init to install it, or `brew install terminal-notifier`")
+		} else {
+			r.add("terminal-notifier", StatusPass, path, "")
+		}
+	}
+
+	detail := "terminal " + terminalName(cfg)
+	if mux := notifier.DetectMultiplexer(); mux != "" {
+		detail += ", multiplexer " + mux
+	}
+	r.add("terminal", StatusPass, detail, "")
+}
+
+// terminalName returns the macOS bundle ID of the terminal, or "unknown" elsewhere
+func terminalName(cfg *config.Config) string {
+	if id := notifier.GetTerminalBundleID(cfg.Notifications.Desktop.TerminalBundleID); id != "" {
+		return id
+	}
+	return "unknown"
+}
@@ -0,0 +1,188 @@
+package doctor
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/audio"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setup points HOME at a temp dir and writes config to a temp plugin root
+func setup(t *testing.T, configJSON string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if runtime.GOOS == "windows" {
+		t.Setenv("USERPROFILE", home)
+	}
+	pluginRoot := t.TempDir()
+	if configJSON != "" {
+		require.NoError(t, os.MkdirAll(filepath.Join(pluginRoot, "config"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(pluginRoot, "config", "config.json"), []byte(configJSON), 0644))
+	}
+	return pluginRoot
+}
+
+// testRunner replaces the audio calls so tests don't need an audio server
+func testRunner(opts Options, devices []audio.DeviceInfo) *runner {
+	r := newRunner(opts)
+	r.listDevices = func() ([]audio.DeviceInfo, error) { return devices, nil }
+	r.checkSound = func(path string) (time.Duration, error) { return 1500 * time.Millisecond, nil }
+	return r
+}
+
+func find(t *testing.T, checks []Check, name string) Check {
+	t.Helper()
+	for _, c := range checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("no %q check in %+v", name, checks)
+	return Check{}
+}
+
+func TestRun_ReportsBrokenPaths(t *testing.T) {
+	dir := t.TempDir()
+	sound := filepath.Join(dir, "done.mp3")
+	require.NoError(t, os.WriteFile(sound, []byte("stub"), 0644))
+	missing := filepath.Join(dir, "missing.mp3")
+
+	pluginRoot := setup(t, `{
+		"notifications": {
+			"desktop": {"enabled": true, "sound": true, "clickToFocus": false,
+				"audioDevice": "USB Speakers", "appIcon": "`+filepath.ToSlash(filepath.Join(dir, "icon.png"))+`"},
+			"webhook": {"enabled": true, "preset": "slack", "url": "hooks.example.com/no-scheme"}
+		},
+		"statuses": {
+			"task_complete": {"title": "Done", "sound": "`+filepath.ToSlash(sound)+`"},
+			"question": {"title": "Question", "sound": "`+filepath.ToSlash(missing)+`"}
+		}
+	}`)
+
+	checks := testRunner(Options{PluginRoot: pluginRoot}, []audio.DeviceInfo{
+		{Name: "Built-in Output", IsDefault: true},
+	}).run()
+
+	assert.Equal(t, StatusPass, find(t, checks, "config").Status)
+	assert.Equal(t, StatusWarn, find(t, checks, "icon").Status)
+
+	ok := find(t, checks, "sound done.mp3")
+	assert.Equal(t, StatusPass, ok.Status)
+	assert.Contains(t, ok.Detail, "1.5s (used by task_complete)")
+	broken := find(t, checks, "sound missing.mp3")
+	assert.Equal(t, StatusFail, broken.Status)
+	assert.Contains(t, broken.Detail, "file not found (used by question)")
+	assert.NotEmpty(t, broken.Hint)
+
+	device := find(t, checks, "audio device")
+	assert.Equal(t, StatusFail, device.Status)
+	assert.Contains(t, device.Detail, `"USB Speakers" not found; available: Built-in Output`)
+
+	webhookCheck := find(t, checks, "webhook")
+	assert.Equal(t, StatusFail, webhookCheck.Status)
+	assert.NotContains(t, webhookCheck.Detail, "no-scheme", "the URL is never printed")
+
+	assert.True(t, Failed(checks))
+}
+
+func TestRun_InvalidConfigFile(t *testing.T) {
+	pluginRoot := setup(t, `{"notifications": {"desktop": {"volume": 3}}}`)
+
+	checks := testRunner(Options{PluginRoot: pluginRoot}, nil).run()
+
+	cfgCheck := find(t, checks, "config")
+	assert.Equal(t, StatusFail, cfgCheck.Status)
+	assert.Contains(t, cfgCheck.Detail, "notifications.desktop.volume")
+	assert.Contains(t, cfgCheck.Hint, "config validate")
+	// The remaining checks run against the defaults
+	assert.Equal(t, StatusSkip, find(t, checks, "webhook").Status)
+}
+
+func TestRun_DisabledPathsAreSkipped(t *testing.T) {
+	pluginRoot := setup(t, `{"notifications": {"desktop": {"enabled": false}, "webhook": {"enabled": false}}}`)
+
+	r := testRunner(Options{PluginRoot: pluginRoot}, nil)
+	r.listDevices = func() ([]audio.DeviceInfo, error) { return nil, errors.New("must not be called") }
+	checks := r.run()
+
+	for _, name := range []string{"icon", "sounds", "audio device", "webhook"} {
+		assert.Equal(t, StatusSkip, find(t, checks, name).Status, name)
+	}
+	assert.False(t, Failed(checks))
+}
+
+func TestRun_WebhookTestPost(t *testing.T) {
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pluginRoot := setup(t, `{"notifications": {"desktop": {"enabled": false},
+		"webhook": {"enabled": true, "preset": "custom", "url": "`+server.URL+`/hook/secret"}}}`)
+
+	checks := testRunner(Options{PluginRoot: pluginRoot}, nil).run()
+	assert.Equal(t, StatusPass, find(t, checks, "webhook").Status)
+	assert.Zero(t, atomic.LoadInt32(&posts), "no POST without WebhookTest")
+
+	checks = testRunner(Options{PluginRoot: pluginRoot, WebhookTest: true}, nil).run()
+	webhookCheck := find(t, checks, "webhook")
+	assert.Equal(t, StatusPass, webhookCheck.Status)
+	assert.Contains(t, webhookCheck.Detail, "test message delivered")
+	assert.NotContains(t, webhookCheck.Detail, "secret")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts))
+}
+
+func TestRun_WebhookTargets(t *testing.T) {
+	var paths []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pluginRoot := setup(t, `{"notifications": {"desktop": {"enabled": false},
+		"webhooks": [
+			{"name": "team", "url": "`+server.URL+`/team", "statuses": ["question"]},
+			{"name": "ops", "preset": "slack", "url": "ops.example.com/no-scheme"},
+			{"name": "off", "enabled": false, "url": "`+server.URL+`/off"}
+		]}}`)
+
+	checks := testRunner(Options{PluginRoot: pluginRoot, WebhookTest: true}, nil).run()
+
+	assert.Equal(t, StatusPass, find(t, checks, "webhook team").Status)
+	ops := find(t, checks, "webhook ops")
+	assert.Equal(t, StatusFail, ops.Status)
+	assert.Contains(t, ops.Hint, `"ops"`)
+	for _, c := range checks {
+		assert.NotEqual(t, "webhook", c.Name, "notifications.webhook is disabled and not reported")
+		assert.NotEqual(t, "webhook off", c.Name, "disabled targets are not checked")
+	}
+	assert.Equal(t, []string{"/team"}, paths, "only the tested target receives a message")
+}
+
+func TestCheckAudioDevice_DefaultDevice(t *testing.T) {
+	r := testRunner(Options{}, []audio.DeviceInfo{{Name: "HDMI"}, {Name: "Speakers", IsDefault: true}})
+	r.checkAudioDevice(config.DefaultConfig())
+
+	require.Len(t, r.checks, 1)
+	assert.Equal(t, StatusPass, r.checks[0].Status)
+	assert.Equal(t, "system default (Speakers)", r.checks[0].Detail)
+}
@@ -0,0 +1,226 @@
+// ABOUTME: Exec channel: runs a user command per notification with a JSON document on stdin.
+// ABOUTME: Commands are time-limited and share a cross-process concurrency limit via slot lock files.
+package execchannel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/errorhandler"
+	"github.com/777genius/claude-notifications/internal/logging"
+	"github.com/777genius/claude-notifications/internal/platform"
+)
+
+// ErrNoSlot is returned when maxConcurrent commands are already running
+var ErrNoSlot = errors.New("exec concurrency limit reached")
+
+// slotGrace is added to the command timeout before a slot lock is considered stale
+// (its process was killed before it could release the slot)
+const slotGrace = 5 * time.Second
+
+// outputGrace bounds the wait for stdout/stderr after the command exits or is killed.
+// A child the command left in the background (`notify-send ... &`) keeps the pipes
+// open and would otherwise hold up the hook until it exits.
+const outputGrace = 2 * time.Second
+
+// Notification is the JSON document the command receives on stdin
+type Notification struct {
+	Status    string `json:"status"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	SessionID string `json:"session_id"`
+	CWD       string `json:"cwd"`
+	Branch    string `json:"branch"`
+	Folder    string `json:"folder"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Env returns the notification as CLAUDE_NOTIF_* environment variables
+func (n Notification) Env() []string {
+	return []string{
+		"CLAUDE_NOTIF_STATUS=" + n.Status,
+		"CLAUDE_NOTIF_TITLE=" + n.Title,
+		"CLAUDE_NOTIF_MESSAGE=" + n.Message,
+		"CLAUDE_NOTIF_SESSION_ID=" + n.SessionID,
+		"CLAUDE_NOTIF_CWD=" + n.CWD,
+		"CLAUDE_NOTIF_BRANCH=" + n.Branch,
+		"CLAUDE_NOTIF_FOLDER=" + n.Folder,
+		"CLAUDE_NOTIF_TIMESTAMP=" + n.Timestamp,
+	}
+}
+
+// Stats counts the results of async runs
+type Stats struct {
+	Succeeded int
+	Failed    int
+	LastError string // Error of the most recent failed run
+}
+
+// Runner runs the configured command for notifications
+type Runner struct {
+	cfg     *config.Config
+	slotDir string // Directory of the concurrency slot lock files
+	wg      sync.WaitGroup
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// New creates an exec channel runner
+func New(cfg *config.Config) *Runner {
+	return &Runner{
+		cfg:     cfg,
+		slotDir: platform.TempDir(),
+	}
+}
+
+// Send builds the notification document and runs the command synchronously
+func (r *Runner) Send(status analyzer.Status, message, sessionID, cwd string) error {
+	statusInfo, _ := r.cfg.GetStatusInfo(string(status))
+
+	folder := ""
+	if cwd != "" {
+		folder = filepath.Base(cwd)
+	}
+
+	return r.Run(Notification{
+		Status:    string(status),
+		Title:     statusInfo.Title,
+		Message:   message,
+		SessionID: sessionID,
+		CWD:       cwd,
+		Branch:    platform.GetGitBranch(cwd),
+		Folder:    folder,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
+}
+
+// Run runs the command for n. It returns ErrNoSlot instead of waiting when all slots are busy.
+func (r *Runner) Run(n Notification) error {
+	command := r.cfg.Notifications.Exec.Command
+	if len(command) == 0 {
+		return fmt.Errorf("exec command is empty")
+	}
+
+	timeout := r.cfg.GetExecTimeout()
+	release, err := r.acquireSlot(timeout)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal exec payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), n.Env()...)
+	if n.CWD != "" && platform.FileExists(n.CWD) {
+		cmd.Dir = n.CWD
+	}
+	cmd.WaitDelay = outputGrace
+	killProcessGroupOnCancel(cmd)
+
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("exec command timed out after %v", timeout)
+	}
+	if errors.Is(err, exec.ErrWaitDelay) {
+		// The command succeeded; a background child still holds its output
+		logging.Debug("Exec command left a background process holding its output")
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("exec command failed: %w (output: %s)", err, bytes.TrimSpace(out))
+	}
+
+	logging.Debug("Exec command finished: status=%s, latency=%v", n.Status, time.Since(start))
+	return nil
+}
+
+// SendAsync runs the command in the background; Shutdown waits for it
+func (r *Runner) SendAsync(status analyzer.Status, message, sessionID, cwd string) {
+	r.wg.Add(1)
+	errorhandler.SafeGo(func() {
+		defer r.wg.Done()
+
+		err := r.Send(status, message, sessionID, cwd)
+
+		r.mu.Lock()
+		if err != nil {
+			r.stats.Failed++
+			r.stats.LastError = err.Error()
+		} else {
+			r.stats.Succeeded++
+		}
+		r.mu.Unlock()
+
+		if err != nil {
+			errorhandler.HandleError(err, "Exec channel failed")
+		}
+	})
+}
+
+// GetStats returns the results of async runs so far
+func (r *Runner) GetStats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// Shutdown waits for running commands (each is bounded by its own timeout)
+func (r *Runner) Shutdown(timeout time.Duration) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("exec shutdown timeout after %v", timeout)
+	}
+}
+
+// acquireSlot takes one of maxConcurrent slot lock files shared by all hook processes.
+// Slots older than the command timeout (plus grace) belong to dead processes and are reclaimed.
+func (r *Runner) acquireSlot(timeout time.Duration) (func(), error) {
+	maxAge := int64((timeout + slotGrace) / time.Second)
+
+	for i := 0; i < r.cfg.GetExecMaxConcurrent(); i++ {
+		path := filepath.Join(r.slotDir, fmt.Sprintf("claude-exec-slot-%d.lock", i))
+
+		created, err := platform.AtomicCreateFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exec slot: %w", err)
+		}
+		if !created && platform.FileAge(path) > maxAge {
+			_ = os.Remove(path)
+			if created, err = platform.AtomicCreateFile(path); err != nil {
+				return nil, fmt.Errorf("failed to create exec slot: %w", err)
+			}
+		}
+		if created {
+			return func() { _ = os.Remove(path) }, nil
+		}
+	}
+	return nil, ErrNoSlot
+}
@@ -0,0 +1,205 @@
+package execchannel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHelperProcess is not a real test: it is the command run by the runner in tests.
+// Behaviour is selected with EXEC_HELPER_MODE.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	switch os.Getenv("EXEC_HELPER_MODE") {
+	case "record":
+		stdin, _ := io.ReadAll(os.Stdin)
+		out := fmt.Sprintf("%s\n%s|%s|%s", stdin,
+			os.Getenv("CLAUDE_NOTIF_STATUS"), os.Getenv("CLAUDE_NOTIF_TITLE"), os.Getenv("CLAUDE_NOTIF_FOLDER"))
+		_ = os.WriteFile(os.Getenv("EXEC_HELPER_OUT"), []byte(out), 0600)
+	case "sleep":
+		time.Sleep(5 * time.Second)
+	case "background", "background-sleep":
+		// Leave a child behind that inherits stdout/stderr, like `notify-send ... &`
+		child := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+		child.Env = append(os.Environ(), "EXEC_HELPER_MODE=sleep")
+		child.Stdout, child.Stderr = os.Stdout, os.Stderr
+		_ = child.Start()
+		if os.Getenv("EXEC_HELPER_MODE") == "background-sleep" {
+			time.Sleep(5 * time.Second)
+		}
+	case "fail":
+		fmt.Fprint(os.Stderr, "boom")
+		os.Exit(3)
+	}
+	os.Exit(0)
+}
+
+// newTestRunner returns a runner that executes TestHelperProcess in the given mode
+func newTestRunner(t *testing.T, mode string, timeout string) *Runner {
+	t.Helper()
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	t.Setenv("EXEC_HELPER_MODE", mode)
+
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Exec = config.ExecConfig{
+		Enabled: true,
+		Command: []string{os.Args[0], "-test.run=TestHelperProcess"},
+		Timeout: timeout,
+	}
+
+	r := New(cfg)
+	r.slotDir = t.TempDir()
+	return r
+}
+
+func TestRun_PassesPayloadAndEnv(t *testing.T) {
+	r := newTestRunner(t, "record", "10s")
+	out := filepath.Join(t.TempDir(), "out.txt")
+	t.Setenv("EXEC_HELPER_OUT", out)
+
+	n := Notification{
+		Status:    "task_complete",
+		Title:     "✅ Completed",
+		Message:   "Done",
+		SessionID: "s1",
+		CWD:       t.TempDir(),
+		Branch:    "main",
+		Folder:    "project",
+	}
+	require.NoError(t, r.Run(n))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	var stdinLine, envLine string
+	for i, b := range data {
+		if b == '\n' {
+			stdinLine, envLine = string(data[:i]), string(data[i+1:])
+			break
+		}
+	}
+
+	var got Notification
+	require.NoError(t, json.Unmarshal([]byte(stdinLine), &got))
+	assert.Equal(t, n, got)
+	assert.Equal(t, "task_complete|✅ Completed|project", envLine)
+}
+
+func TestRun_Timeout(t *testing.T) {
+	r := newTestRunner(t, "sleep", "200ms")
+
+	start := time.Now()
+	err := r.Run(Notification{Status: "question"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Less(t, time.Since(start), 4*time.Second)
+}
+
+func TestRun_BackgroundChildDoesNotBlock(t *testing.T) {
+	r := newTestRunner(t, "background", "10s")
+
+	start := time.Now()
+	err := r.Run(Notification{Status: "question"})
+
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 4*time.Second)
+}
+
+func TestRun_TimeoutWithBackgroundChild(t *testing.T) {
+	r := newTestRunner(t, "background-sleep", "200ms")
+
+	start := time.Now()
+	err := r.Run(Notification{Status: "question"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Less(t, time.Since(start), 4*time.Second)
+}
+
+func TestRun_Failure(t *testing.T) {
+	r := newTestRunner(t, "fail", "10s")
+
+	err := r.Run(Notification{Status: "question"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestSendAsync_Shutdown(t *testing.T) {
+	r := newTestRunner(t, "record", "10s")
+	out := filepath.Join(t.TempDir(), "out.txt")
+	t.Setenv("EXEC_HELPER_OUT", out)
+
+	r.SendAsync("plan_ready", "Plan ready", "s2", "")
+	require.NoError(t, r.Shutdown(10*time.Second))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"status":"plan_ready"`)
+	assert.Contains(t, string(data), `"title":"📋 Plan"`)
+	assert.Equal(t, Stats{Succeeded: 1}, r.GetStats())
+}
+
+func TestSendAsync_RecordsFailure(t *testing.T) {
+	r := newTestRunner(t, "fail", "10s")
+
+	r.SendAsync("task_complete", "Done", "s3", "")
+	require.NoError(t, r.Shutdown(10*time.Second))
+
+	stats := r.GetStats()
+	assert.Equal(t, 0, stats.Succeeded)
+	assert.Equal(t, 1, stats.Failed)
+	assert.Contains(t, stats.LastError, "boom")
+}
+
+func TestAcquireSlot_Limit(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Exec.MaxConcurrent = 2
+	r := New(cfg)
+	r.slotDir = t.TempDir()
+
+	release1, err := r.acquireSlot(time.Minute)
+	require.NoError(t, err)
+	release2, err := r.acquireSlot(time.Minute)
+	require.NoError(t, err)
+
+	_, err = r.acquireSlot(time.Minute)
+	assert.True(t, errors.Is(err, ErrNoSlot))
+
+	release1()
+	release3, err := r.acquireSlot(time.Minute)
+	require.NoError(t, err, "released slot can be reused")
+
+	release2()
+	release3()
+}
+
+func TestAcquireSlot_ReclaimsStale(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Exec.MaxConcurrent = 1
+	r := New(cfg)
+	r.slotDir = t.TempDir()
+
+	stale := filepath.Join(r.slotDir, "claude-exec-slot-0.lock")
+	require.NoError(t, os.WriteFile(stale, nil, 0644))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	release, err := r.acquireSlot(time.Second)
+	require.NoError(t, err)
+	release()
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package execchannel
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroupOnCancel runs the command in its own process group and kills the
+// whole group on timeout, so children it started in the background die with it
+func killProcessGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
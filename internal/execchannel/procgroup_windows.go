@@ -0,0 +1,9 @@
+//go:build windows
+
+package execchannel
+
+import "os/exec"
+
+// killProcessGroupOnCancel is a no-op on Windows; the context kills the command itself
+// and WaitDelay stops waiting for children that inherited its output
+func killProcessGroupOnCancel(cmd *exec.Cmd) {}
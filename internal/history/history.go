@@ -0,0 +1,175 @@
+// ABOUTME: Notification history: one JSON line per sent or suppressed notification.
+// ABOUTME: Appends are process-safe (O_APPEND), the file rotates to a single .1 backup by size.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Outcome values of an entry
+const (
+	OutcomeSent         = "sent"
+	OutcomeQueued       = "queued" // Waiting for the aggregation window; `deliver` adds the sent entry
+	OutcomeSuppressed   = "suppressed"
+	OutcomeDeduplicated = "deduplicated"
+)
+
+// Channel outcome values
+const (
+	ChannelSent     = "sent"
+	ChannelFailed   = "failed"
+	ChannelDisabled = "disabled"
+	ChannelQueued   = "queued" // Handed to the daemon's aggregation window
+)
+
+// maxLineSize bounds a single history line when reading
+const maxLineSize = 1 << 20
+
+// Channel is the result of one delivery channel for a notification
+type Channel struct {
+	Name    string `json:"name"`    // desktop, webhook, exec
+	Outcome string `json:"outcome"` // sent, failed, disabled
+	Error   string `json:"error,omitempty"`
+}
+
+// Entry is one history record
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Event     string    `json:"event"` // Hook event, or "reminder"
+	Status    string    `json:"status"`
+	Outcome   string    `json:"outcome"`
+	Reason    string    `json:"reason,omitempty"` // Why the notification was suppressed/deduplicated
+	Message   string    `json:"message,omitempty"`
+	SessionID string    `json:"session_id"`
+	CWD       string    `json:"cwd,omitempty"`
+	Branch    string    `json:"branch,omitempty"`
+	Channels  []Channel `json:"channels,omitempty"`
+}
+
+// Project returns the folder name of the entry's working directory
+func (e Entry) Project() string {
+	if e.CWD == "" {
+		return ""
+	}
+	return filepath.Base(e.CWD)
+}
+
+// Append writes entry as one line to the history file at path.
+// When the file has grown beyond maxBytes it is first moved to path.1 (replacing the old backup).
+func Append(path string, entry Entry, maxBytes int64) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if info, err := os.Stat(path); err == nil && maxBytes > 0 && info.Size()+int64(len(line)) > maxBytes {
+		if err := os.Rename(path, path+".1"); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate history: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return f.Close()
+}
+
+// Load reads the history at path, including its rotated backup, oldest first.
+// Malformed lines (e.g. a write cut short by a crash) are skipped.
+func Load(path string) ([]Entry, error) {
+	var entries []Entry
+	for _, p := range []string{path + ".1", path} {
+		loaded, err := loadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, loaded...)
+	}
+	return entries, nil
+}
+
+func loadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return entries, nil
+}
+
+// Filter selects history entries. Zero fields match everything.
+type Filter struct {
+	SessionID string    // Exact session ID or a prefix of it
+	Project   string    // Folder name, or a substring of the working directory
+	Status    string    // Exact status
+	Outcome   string    // Exact outcome
+	Since     time.Time // Inclusive lower bound
+	Until     time.Time // Exclusive upper bound
+}
+
+// Match reports whether entry passes the filter
+func (f Filter) Match(entry Entry) bool {
+	if f.SessionID != "" && !strings.HasPrefix(entry.SessionID, f.SessionID) {
+		return false
+	}
+	if f.Project != "" && entry.Project() != f.Project && !strings.Contains(entry.CWD, f.Project) {
+		return false
+	}
+	if f.Status != "" && entry.Status != f.Status {
+		return false
+	}
+	if f.Outcome != "" && entry.Outcome != f.Outcome {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the entries that pass the filter, in their original order
+func (f Filter) Apply(entries []Entry) []Entry {
+	var matched []Entry
+	for _, entry := range entries {
+		if f.Match(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,124 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "history.jsonl")
+
+	first := Entry{
+		Time:      time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+		Event:     "Stop",
+		Status:    "task_complete",
+		Outcome:   OutcomeSent,
+		Message:   "Done",
+		SessionID: "abc",
+		CWD:       "/work/app",
+		Branch:    "main",
+		Channels: []Channel{
+			{Name: "desktop", Outcome: ChannelSent},
+			{Name: "webhook", Outcome: ChannelFailed, Error: "request failed"},
+		},
+	}
+	second := Entry{Time: first.Time.Add(time.Minute), Event: "Notification", Status: "question", Outcome: OutcomeSuppressed, Reason: "cooldown", SessionID: "abc"}
+
+	require.NoError(t, Append(path, first, 0))
+	require.NoError(t, Append(path, second, 0))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	entries, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, first, entries[0])
+	assert.Equal(t, second, entries[1])
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	entries, err := Load(filepath.Join(t.TempDir(), "history.jsonl"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLoad_SkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	content := `{"status":"question","outcome":"sent","session_id":"a"}
+{"status":"task_com
+{"status":"plan_ready","outcome":"sent","session_id":"b"}
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	entries, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].SessionID)
+	assert.Equal(t, "b", entries[1].SessionID)
+}
+
+func TestAppend_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	entry := Entry{Status: "task_complete", Outcome: OutcomeSent, SessionID: "s", Message: "0123456789"}
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, Append(path, entry, 250))
+	}
+
+	_, err := os.Stat(path + ".1")
+	require.NoError(t, err, "history should have rotated")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(250))
+
+	// Load reads the backup too, but the oldest entries are gone
+	entries, err := Load(path)
+	require.NoError(t, err)
+	assert.Less(t, len(entries), 5)
+	assert.NotEmpty(t, entries)
+}
+
+func TestFilter(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Time: base, Status: "task_complete", Outcome: OutcomeSent, SessionID: "aaa-111", CWD: "/work/api"},
+		{Time: base.Add(time.Hour), Status: "question", Outcome: OutcomeSuppressed, SessionID: "bbb-222", CWD: "/work/web"},
+		{Time: base.Add(2 * time.Hour), Status: "question", Outcome: OutcomeSent, SessionID: "aaa-111", CWD: "/home/me/api-tools"},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []int
+	}{
+		{"empty filter", Filter{}, []int{0, 1, 2}},
+		{"session prefix", Filter{SessionID: "aaa"}, []int{0, 2}},
+		{"project folder", Filter{Project: "web"}, []int{1}},
+		{"project path substring", Filter{Project: "/home/me"}, []int{2}},
+		{"status", Filter{Status: "question"}, []int{1, 2}},
+		{"outcome", Filter{Outcome: OutcomeSuppressed}, []int{1}},
+		{"since inclusive", Filter{Since: base.Add(time.Hour)}, []int{1, 2}},
+		{"until exclusive", Filter{Until: base.Add(time.Hour)}, []int{0}},
+		{"combined", Filter{SessionID: "aaa-111", Status: "question"}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []Entry
+			for _, i := range tt.want {
+				want = append(want, entries[i])
+			}
+			assert.Equal(t, want, tt.filter.Apply(entries))
+		})
+	}
+}
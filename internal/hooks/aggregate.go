@@ -0,0 +1,320 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/errorhandler"
+	"github.com/777genius/claude-notifications/internal/history"
+	"github.com/777genius/claude-notifications/internal/logging"
+	"github.com/777genius/claude-notifications/internal/notifier"
+	"github.com/777genius/claude-notifications/internal/sessionname"
+)
+
+// AggregatedNotification is one notification of the batch the daemon passes to
+// `claude-notifications deliver` when an aggregation window closes.
+// Keep the JSON fields in sync with daemon.AggregateRequest.
+type AggregatedNotification struct {
+	SessionID   string   `json:"session_id"`
+	Status      string   `json:"status"`
+	Message     string   `json:"message"`
+	Title       string   `json:"title,omitempty"`
+	Subtitle    string   `json:"subtitle,omitempty"`
+	Body        string   `json:"body"`
+	WebhookText string   `json:"webhook_text,omitempty"`
+	CWD         string   `json:"cwd,omitempty"`
+	Desktop     bool     `json:"desktop,omitempty"`
+	Webhook     bool     `json:"webhook,omitempty"`
+	Webhooks    []string `json:"webhooks,omitempty"`
+	Sound       *string  `json:"sound,omitempty"`
+	Quiet       bool     `json:"quiet,omitempty"`
+}
+
+// statusPriority orders statuses by how urgently they need the user; the merged
+// notification takes the status and sound of the most important one
+var statusPriority = []analyzer.Status{
+	analyzer.StatusAPIError,
+	analyzer.StatusAPIErrorOverloaded,
+	analyzer.StatusSessionLimitReached,
+	analyzer.StatusPermissionRequest,
+	analyzer.StatusQuestion,
+	analyzer.StatusIdlePrompt,
+	analyzer.StatusPlanReady,
+	analyzer.StatusReviewComplete,
+	analyzer.StatusTaskComplete,
+	analyzer.StatusContextCompacting,
+}
+
+// statusRank returns the position of status in statusPriority; unknown statuses rank last
+func statusRank(status string) int {
+	for i, s := range statusPriority {
+		if string(s) == status {
+			return i
+		}
+	}
+	return len(statusPriority)
+}
+
+// topNotification returns the most important notification; the earliest wins a tie
+func topNotification(items []AggregatedNotification) AggregatedNotification {
+	return items[topIndex(items)]
+}
+
+// topIndex returns the index of the most important notification in items
+func topIndex(items []AggregatedNotification) int {
+	top := 0
+	for i, item := range items[1:] {
+		if statusRank(item.Status) < statusRank(items[top].Status) {
+			top = i + 1
+		}
+	}
+	return top
+}
+
+// HandleAggregate delivers a batch of notifications collected by the daemon's
+// aggregation window: one desktop notification and one message per webhook
+// target set. A batch of one is sent as it was rendered by its hook.
+// Webhooks are sent with the project config of each notification's folder, and each
+// notification gets a history entry of its own with the result of its delivery.
+func (h *Handler) HandleAggregate(input io.Reader) error {
+	defer errorhandler.HandlePanic()
+
+	h.outcome = Outcome{Kind: OutcomeSkipped}
+	h.message, h.channels, h.mutedBy = "", nil, ""
+
+	defer func() {
+		if err := h.notifierSvc.Close(); err != nil {
+			logging.Warn("Failed to close notifier: %v", err)
+		}
+	}()
+
+	defer func() {
+		if err := h.webhookSvc.Shutdown(5 * time.Second); err != nil {
+			logging.Warn("Failed to shutdown webhook sender: %v", err)
+		}
+	}()
+
+	logging.SetPrefix(fmt.Sprintf("PID:%d", os.Getpid()))
+
+	var batch []AggregatedNotification
+	if err := json.NewDecoder(input).Decode(&batch); err != nil {
+		return fmt.Errorf("failed to parse aggregated notifications: %w", err)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	logging.Debug("=== Aggregation window closed: %d notification(s) ===", len(batch))
+
+	channels := make([][]history.Channel, len(batch))
+	defer h.recordDelivery(batch, channels)
+
+	configs := h.projectConfigs(batch)
+	var desktop, webhook []int
+	for i, item := range batch {
+		if item.Desktop {
+			desktop = append(desktop, i)
+		} else {
+			channels[i] = append(channels[i], history.Channel{Name: channelDesktop, Outcome: history.ChannelDisabled})
+		}
+		if item.Webhook {
+			webhook = append(webhook, i)
+		} else {
+			channels[i] = append(channels[i], history.Channel{Name: channelWebhook, Outcome: history.ChannelDisabled})
+		}
+	}
+
+	if len(desktop) > 0 {
+		items := pick(batch, desktop)
+		h.useConfig(configs[desktop[topIndex(items)]])
+		channel := h.sendMergedDesktop(items)
+		for _, i := range desktop {
+			channels[i] = append(channels[i], channel)
+		}
+	}
+
+	// One sender per project config; each is shut down before the next so the
+	// results of its targets can be told apart
+	for _, group := range groupByConfig(configs, webhook) {
+		h.useConfig(configs[group[0]])
+		h.sendMergedWebhooks(pick(batch, group))
+		if err := h.webhookSvc.Shutdown(5 * time.Second); err != nil {
+			logging.Warn("Failed to shutdown webhook sender: %v", err)
+		}
+		results := h.webhookChannels()
+		for _, i := range group {
+			channels[i] = append(channels[i], targetChannels(results, batch[i].Webhooks)...)
+		}
+	}
+
+	return nil
+}
+
+// projectConfigs returns the effective config of each notification. Notifications whose
+// folders share the same project config files share one config.
+func (h *Handler) projectConfigs(batch []AggregatedNotification) []*config.Config {
+	configs := make([]*config.Config, len(batch))
+	byFiles := make(map[string]*config.Config)
+	for i, item := range batch {
+		key := strings.Join(config.FindProjectConfigs(item.CWD), string(os.PathListSeparator))
+		cfg, ok := byFiles[key]
+		if !ok {
+			cfg = h.projectConfig(item.CWD)
+			byFiles[key] = cfg
+		}
+		configs[i] = cfg
+	}
+	return configs
+}
+
+// groupByConfig splits the notifications at indexes into groups that share a config,
+// in order of first appearance
+func groupByConfig(configs []*config.Config, indexes []int) [][]int {
+	var groups [][]int
+	position := make(map[*config.Config]int)
+	for _, i := range indexes {
+		g, ok := position[configs[i]]
+		if !ok {
+			g = len(groups)
+			position[configs[i]] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// pick returns the notifications at indexes
+func pick(batch []AggregatedNotification, indexes []int) []AggregatedNotification {
+	items := make([]AggregatedNotification, len(indexes))
+	for n, i := range indexes {
+		items[n] = batch[i]
+	}
+	return items
+}
+
+// targetChannels keeps the webhook channels of the named targets; all of them when
+// targets is empty or the sender has a single target
+func targetChannels(channels []history.Channel, targets []string) []history.Channel {
+	if len(targets) == 0 {
+		return channels
+	}
+	var kept []history.Channel
+	for _, channel := range channels {
+		if channel.Name == channelWebhook {
+			kept = append(kept, channel)
+			continue
+		}
+		for _, target := range targets {
+			if channel.Name == channelWebhook+":"+target {
+				kept = append(kept, channel)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// recordDelivery writes the history entry and watch event of each delivered
+// notification with its own session, folder and channels. Their hooks recorded
+// them as queued.
+func (h *Handler) recordDelivery(batch []AggregatedNotification, channels [][]history.Channel) {
+	for i, item := range batch {
+		h.outcome = Outcome{Kind: OutcomeSent, Status: analyzer.Status(item.Status)}
+		h.message, h.channels = item.Message, channels[i]
+		h.recordHistory("aggregate", item.SessionID, item.CWD)
+		h.publishEvent("aggregate", item.SessionID, item.CWD)
+	}
+}
+
+// sendMergedDesktop shows one desktop notification for items, with the sound of the
+// most important one. Clicking it focuses that session's terminal.
+func (h *Handler) sendMergedDesktop(items []AggregatedNotification) history.Channel {
+	top := topNotification(items)
+	msg := notifier.Message{Title: top.Title, Subtitle: top.Subtitle, Body: top.Body}
+	quiet := true
+	for _, item := range items {
+		quiet = quiet && item.Quiet
+	}
+	if len(items) > 1 {
+		msg.Title, msg.Subtitle, msg.Body = h.mergedTitle(items, top), "", h.mergedBody(items)
+	}
+
+	h.notifierSvc.SetQuiet(quiet)
+	h.notifierSvc.SetSound(top.Sound)
+	channel := history.Channel{Name: channelDesktop, Outcome: history.ChannelSent}
+	if err := h.notifierSvc.SendDesktop(analyzer.Status(top.Status), msg, top.SessionID, top.CWD); err != nil {
+		errorhandler.HandleError(err, "Failed to send aggregated desktop notification")
+		channel.Outcome, channel.Error = history.ChannelFailed, err.Error()
+	}
+	return channel
+}
+
+// sendMergedWebhooks sends one message per set of webhook targets chosen by routes
+func (h *Handler) sendMergedWebhooks(items []AggregatedNotification) {
+	var keys []string
+	groups := make(map[string][]AggregatedNotification)
+	for _, item := range items {
+		key := strings.Join(item.Webhooks, ",")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		top := topNotification(group)
+		text := top.WebhookText
+		if len(group) > 1 {
+			text = h.mergedTitle(group, top) + "\n\n" + h.mergedBody(group)
+		}
+		h.webhookSvc.SendAsyncTo(top.Webhooks, analyzer.Status(top.Status), text, top.SessionID, top.CWD)
+	}
+}
+
+// mergedTitle summarizes items, e.g. "2 sessions finished: bold 06ddb8f7, calm 3a1c92e0"
+func (h *Handler) mergedTitle(items []AggregatedNotification, top AggregatedNotification) string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if !seen[item.SessionID] {
+			seen[item.SessionID] = true
+			names = append(names, sessionname.GenerateSessionLabel(item.SessionID))
+		}
+	}
+
+	if len(names) == 1 {
+		return fmt.Sprintf("%d notifications from %s", len(items), names[0])
+	}
+	verb := "finished"
+	if statusRank(top.Status) < statusRank(string(analyzer.StatusPlanReady)) {
+		verb = "need attention"
+	}
+	return fmt.Sprintf("%d sessions %s: %s", len(names), verb, strings.Join(names, ", "))
+}
+
+// mergedBody lists each notification on its own line:
+// "<status title> <session> (<folder>): <message>"
+func (h *Handler) mergedBody(items []AggregatedNotification) string {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		title := item.Status
+		if info, ok := h.cfg.GetStatusInfo(item.Status); ok && info.Title != "" {
+			title = info.Title
+		}
+		line := fmt.Sprintf("%s %s", title, sessionname.GenerateSessionLabel(item.SessionID))
+		if item.CWD != "" {
+			line += fmt.Sprintf(" (%s)", filepath.Base(item.CWD))
+		}
+		lines = append(lines, line+": "+item.Message)
+	}
+	return strings.Join(lines, "\n")
+}
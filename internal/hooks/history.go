@@ -0,0 +1,173 @@
+package hooks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/history"
+	"github.com/777genius/claude-notifications/internal/logging"
+	"github.com/777genius/claude-notifications/internal/notifier"
+	"github.com/777genius/claude-notifications/internal/platform"
+	"github.com/777genius/claude-notifications/internal/webhook"
+)
+
+// Delivery channel names used in the history
+const (
+	channelDesktop = "desktop"
+	channelWebhook = "webhook"
+	channelExec    = "exec"
+)
+
+// recordChannel notes the result of one channel for the history.
+// Async channels are recorded with an empty outcome and resolved after shutdown.
+func (h *Handler) recordChannel(name, outcome string, err error) {
+	channel := history.Channel{Name: name, Outcome: outcome}
+	if err != nil {
+		channel.Outcome = history.ChannelFailed
+		channel.Error = err.Error()
+	}
+	h.channels = append(h.channels, channel)
+}
+
+// resolveAsyncChannels fills in the outcome of webhook and exec sends from their stats.
+// Must run after the channels are shut down.
+func (h *Handler) resolveAsyncChannels() {
+	resolved := make([]history.Channel, 0, len(h.channels))
+	webhookResolved := false
+	for _, channel := range h.channels {
+		if channel.Outcome != "" {
+			resolved = append(resolved, channel)
+			continue
+		}
+
+		switch channel.Name {
+		case channelWebhook:
+			// Several sends by one call share the per-target metrics; record them once
+			if !webhookResolved {
+				resolved = append(resolved, h.webhookChannels()...)
+				webhookResolved = true
+			}
+		case channelExec:
+			stats := h.execSvc.GetStats()
+			switch {
+			case stats.Failed > 0:
+				channel.Outcome, channel.Error = history.ChannelFailed, stats.LastError
+			case stats.Succeeded > 0:
+				channel.Outcome = history.ChannelSent
+			default:
+				channel.Outcome, channel.Error = history.ChannelFailed, "no result before shutdown"
+			}
+			resolved = append(resolved, channel)
+		default:
+			resolved = append(resolved, channel)
+		}
+	}
+	h.channels = resolved
+}
+
+// webhookChannels returns the outcome of every webhook target that was sent to. With a
+// single target the channel keeps the name "webhook"; with several, each target gets its
+// own "webhook:<name>" entry, so a dead target shows up even when the others succeed.
+func (h *Handler) webhookChannels() []history.Channel {
+	metrics := h.webhookSvc.TargetMetrics()
+	var names []string
+	for name, stats := range metrics {
+		if stats.TotalRequests+stats.RateLimitedRequests+stats.CircuitOpenRequests > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return []history.Channel{{Name: channelWebhook, Outcome: history.ChannelFailed, Error: "no result before shutdown"}}
+	}
+	if len(metrics) == 1 {
+		return []history.Channel{webhookChannel(channelWebhook, metrics[names[0]])}
+	}
+
+	sort.Strings(names)
+	channels := make([]history.Channel, 0, len(names))
+	for _, name := range names {
+		channels = append(channels, webhookChannel(channelWebhook+":"+name, metrics[name]))
+	}
+	return channels
+}
+
+// webhookChannel turns the metrics of one webhook target into a history channel
+func webhookChannel(name string, stats webhook.Stats) history.Channel {
+	channel := history.Channel{Name: name}
+	switch {
+	case stats.SuccessfulRequests > 0:
+		channel.Outcome = history.ChannelSent
+	case stats.RateLimitedRequests > 0:
+		channel.Outcome, channel.Error = history.ChannelFailed, "rate limit exceeded"
+	case stats.CircuitOpenRequests > 0:
+		channel.Outcome, channel.Error = history.ChannelFailed, "circuit breaker open"
+	case stats.FailedRequests > 0:
+		channel.Outcome, channel.Error = history.ChannelFailed, "request failed after retries"
+	default:
+		channel.Outcome, channel.Error = history.ChannelFailed, "no result before shutdown"
+	}
+	return channel
+}
+
+// recordHistory appends the outcome of the last notification to the history file (if enabled).
+// Only notifications with a known status are recorded: lifecycle events and unknown
+// statuses never were notifications. Deferred so the async channels have finished.
+func (h *Handler) recordHistory(event, sessionID, cwd string) {
+	if h.historyPath == "" || h.outcome.Kind == OutcomeSkipped || h.outcome.Status == "" {
+		return
+	}
+
+	h.resolveAsyncChannels()
+
+	entry := history.Entry{
+		Time:      time.Now(),
+		Event:     event,
+		Status:    string(h.outcome.Status),
+		Outcome:   string(h.outcome.Kind),
+		Reason:    h.outcome.Reason,
+		Message:   h.message,
+		SessionID: sessionID,
+		CWD:       cwd,
+		Branch:    platform.GetGitBranch(cwd),
+		Channels:  h.channels,
+	}
+
+	if err := history.Append(h.historyPath, entry, h.cfg.GetHistoryMaxBytes()); err != nil {
+		logging.Warn("Failed to write notification history: %v", err)
+	}
+}
+
+// publishEvent passes the outcome of the last notification on to `watch` clients.
+// Like the history it only covers notifications with a known status; duplicates are
+// not notifications of their own.
+func (h *Handler) publishEvent(event, sessionID, cwd string) {
+	if h.eventSvc == nil || h.outcome.Status == "" {
+		return
+	}
+	if h.outcome.Kind != OutcomeSent && h.outcome.Kind != OutcomeSuppressed {
+		return
+	}
+
+	h.resolveAsyncChannels()
+	var channels map[string]string
+	if len(h.channels) > 0 {
+		channels = make(map[string]string, len(h.channels))
+		for _, c := range h.channels {
+			channels[c.Name] = c.Outcome
+		}
+	}
+
+	ev := notifier.Event{
+		Type:      string(h.outcome.Kind),
+		Event:     event,
+		SessionID: sessionID,
+		Status:    string(h.outcome.Status),
+		Message:   h.message,
+		Reason:    h.outcome.Reason,
+		CWD:       cwd,
+		Channels:  channels,
+	}
+	if err := h.eventSvc.Publish(ev); err != nil {
+		logging.Debug("Failed to publish event for watch clients: %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package hooks
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/history"
+	"github.com/777genius/claude-notifications/internal/webhook"
+)
+
+// newHistoryTestHandler returns a test handler that writes its history to a temp file
+func newHistoryTestHandler(t *testing.T, cfg *config.Config) (*Handler, *mockNotifier) {
+	t.Helper()
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+	handler.historyPath = filepath.Join(t.TempDir(), "history.jsonl")
+	return handler, mockNotif
+}
+
+// loadHistory reads the handler's history file
+func loadHistory(t *testing.T, h *Handler) []history.Entry {
+	t.Helper()
+	entries, err := history.Load(h.historyPath)
+	if err != nil {
+		t.Fatalf("history.Load() error: %v", err)
+	}
+	return entries
+}
+
+func TestHandler_History_RecordsSentNotification(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Webhook.Enabled = true
+	handler, _ := newHistoryTestHandler(t, cfg)
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 50))
+
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{
+		SessionID:      "test-history-sent",
+		TranscriptPath: transcriptPath,
+		CWD:            "/work/app",
+	}))
+	if err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+
+	entries := loadHistory(t, handler)
+	if len(entries) != 1 {
+		t.Fatalf("got %d history entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Event != "Stop" || e.Status != string(analyzer.StatusTaskComplete) || e.Outcome != history.OutcomeSent {
+		t.Errorf("entry = %s %s %s, want Stop task_complete sent", e.Event, e.Status, e.Outcome)
+	}
+	if e.SessionID != "test-history-sent" || e.CWD != "/work/app" || e.Message == "" {
+		t.Errorf("entry = %+v, want session, cwd and message", e)
+	}
+	if strings.HasPrefix(e.Message, "[") {
+		t.Errorf("message = %q, want the plain message without the session prefix", e.Message)
+	}
+
+	want := map[string]string{
+		channelDesktop: history.ChannelSent,
+		channelWebhook: history.ChannelSent,
+		channelExec:    history.ChannelDisabled,
+	}
+	if len(e.Channels) != len(want) {
+		t.Fatalf("channels = %+v, want %d channels", e.Channels, len(want))
+	}
+	for _, c := range e.Channels {
+		if c.Outcome != want[c.Name] {
+			t.Errorf("channel %s outcome = %q, want %q", c.Name, c.Outcome, want[c.Name])
+		}
+	}
+}
+
+func TestHandler_History_RecordsEachWebhookTarget(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Webhook.Enabled = true
+	handler, _ := newHistoryTestHandler(t, cfg)
+	handler.webhookSvc.(*mockWebhook).targetStats = map[string]webhook.Stats{
+		"default": {TotalRequests: 1, SuccessfulRequests: 1},
+		"pager":   {TotalRequests: 1, FailedRequests: 1},
+		"unused":  {}, // Not chosen for this notification
+	}
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 50))
+
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{
+		SessionID:      "test-history-targets",
+		TranscriptPath: transcriptPath,
+		CWD:            "/work/app",
+	}))
+	if err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+
+	entries := loadHistory(t, handler)
+	if len(entries) != 1 {
+		t.Fatalf("got %d history entries, want 1", len(entries))
+	}
+	got := make(map[string]history.Channel)
+	for _, c := range entries[0].Channels {
+		got[c.Name] = c
+	}
+	if c := got["webhook:default"]; c.Outcome != history.ChannelSent {
+		t.Errorf("webhook:default = %+v, want sent", c)
+	}
+	if c := got["webhook:pager"]; c.Outcome != history.ChannelFailed || c.Error != "request failed after retries" {
+		t.Errorf("webhook:pager = %+v, want failed after retries", c)
+	}
+	for _, name := range []string{channelWebhook, "webhook:unused"} {
+		if _, ok := got[name]; ok {
+			t.Errorf("unexpected channel %s in %+v", name, entries[0].Channels)
+		}
+	}
+}
+
+func TestHandler_History_RecordsDesktopFailure(t *testing.T) {
+	handler, mockNotif := newHistoryTestHandler(t, config.DefaultConfig())
+	mockNotif.shouldFail = true
+
+	err := handler.HandleHook("PreToolUse", buildHookDataJSON(HookData{
+		SessionID: "test-history-fail",
+		ToolName:  "ExitPlanMode",
+		CWD:       "/tmp",
+	}))
+	if err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+
+	entries := loadHistory(t, handler)
+	if len(entries) != 1 || len(entries[0].Channels) == 0 {
+		t.Fatalf("got %+v, want one entry with channels", entries)
+	}
+	desktop := entries[0].Channels[0]
+	if desktop.Name != channelDesktop || desktop.Outcome != history.ChannelFailed || desktop.Error != "mock error" {
+		t.Errorf("desktop channel = %+v, want failed with mock error", desktop)
+	}
+}
+
+func TestHandler_History_RecordsSuppression(t *testing.T) {
+	status := "plan_ready"
+	cfg := config.DefaultConfig()
+	cfg.Notifications.SuppressFilters = []config.SuppressFilter{{Status: &status}}
+	handler, _ := newHistoryTestHandler(t, cfg)
+
+	err := handler.HandleHook("PreToolUse", buildHookDataJSON(HookData{
+		SessionID: "test-history-filter",
+		ToolName:  "ExitPlanMode",
+		CWD:       "/tmp",
+	}))
+	if err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+
+	entries := loadHistory(t, handler)
+	if len(entries) != 1 {
+		t.Fatalf("got %d history entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Outcome != history.OutcomeSuppressed || e.Status != status {
+		t.Errorf("entry = %s %s, want suppressed plan_ready", e.Outcome, e.Status)
+	}
+	if !strings.Contains(e.Reason, "filter") {
+		t.Errorf("reason = %q, want the filter reason", e.Reason)
+	}
+	if e.Message == "" {
+		t.Error("message is empty, want the message that would have been sent")
+	}
+	if len(e.Channels) != 0 {
+		t.Errorf("channels = %+v, want none for a suppressed notification", e.Channels)
+	}
+}
+
+func TestHandler_History_RecordsAggregatedDelivery(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Aggregate = config.AggregateConfig{Enabled: true, Window: "5s"}
+	handler, _ := newHistoryTestHandler(t, cfg)
+	handler.aggregateSvc = &mockAggregate{}
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 50))
+
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{SessionID: "test-history-a", TranscriptPath: transcriptPath, CWD: "/work/api"}))
+	if err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+	err = handler.HandleAggregate(buildAggregateJSON([]AggregatedNotification{
+		{SessionID: "test-history-a", Status: "task_complete", Message: "Created factorial function", CWD: "/work/api", Desktop: true, Webhook: true},
+		{SessionID: "test-history-b", Status: "question", Message: "Which database?", CWD: "/work/web", Desktop: true},
+	}))
+	if err != nil {
+		t.Fatalf("HandleAggregate() error: %v", err)
+	}
+
+	entries := loadHistory(t, handler)
+	if len(entries) != 3 {
+		t.Fatalf("got %d history entries, want the queued hook and one per delivered notification", len(entries))
+	}
+	if queued := entries[0]; queued.Event != "Stop" || queued.Outcome != history.OutcomeQueued {
+		t.Errorf("hook entry = %s %s, want Stop queued", queued.Event, queued.Outcome)
+	}
+
+	want := []struct {
+		sessionID, cwd, message string
+		channels                map[string]string
+	}{
+		{"test-history-a", "/work/api", "Created factorial function", map[string]string{channelDesktop: history.ChannelSent, channelWebhook: history.ChannelSent}},
+		{"test-history-b", "/work/web", "Which database?", map[string]string{channelDesktop: history.ChannelSent, channelWebhook: history.ChannelDisabled}},
+	}
+	for i, w := range want {
+		e := entries[i+1]
+		if e.Event != "aggregate" || e.Outcome != history.OutcomeSent || e.SessionID != w.sessionID || e.CWD != w.cwd || e.Message != w.message {
+			t.Errorf("entry %d = %+v, want %s sent from %s", i+1, e, w.sessionID, w.cwd)
+		}
+		if len(e.Channels) != len(w.channels) {
+			t.Errorf("entry %d channels = %+v, want %v", i+1, e.Channels, w.channels)
+		}
+		for _, c := range e.Channels {
+			if c.Outcome != w.channels[c.Name] {
+				t.Errorf("entry %d channel %s = %q, want %q", i+1, c.Name, c.Outcome, w.channels[c.Name])
+			}
+		}
+	}
+}
+
+func TestHandler_History_SkipsNonNotifications(t *testing.T) {
+	handler, _ := newHistoryTestHandler(t, config.DefaultConfig())
+
+	if err := handler.HandleHook("UserPromptSubmit", buildHookDataJSON(HookData{SessionID: "test-history-lifecycle"})); err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+	if err := handler.HandleHook("PreToolUse", buildHookDataJSON(HookData{SessionID: "test-history-unknown", ToolName: "Read"})); err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+
+	if entries := loadHistory(t, handler); len(entries) != 0 {
+		t.Errorf("got %d history entries, want 0", len(entries))
+	}
+}
+
+func TestHandler_PublishesWatchEvents(t *testing.T) {
+	status := "plan_ready"
+	cfg := config.DefaultConfig()
+	cfg.Notifications.SuppressFilters = []config.SuppressFilter{{Status: &status}}
+	handler, _, _ := newTestHandler(t, cfg)
+	events := handler.eventSvc.(*mockEvents)
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 50))
+
+	hooks := []struct {
+		event string
+		data  HookData
+	}{
+		{"Stop", HookData{SessionID: "test-watch-sent", TranscriptPath: transcriptPath, CWD: "/work/app"}},
+		{"PreToolUse", HookData{SessionID: "test-watch-filter", ToolName: "ExitPlanMode", CWD: "/tmp"}},
+		{"UserPromptSubmit", HookData{SessionID: "test-watch-lifecycle"}},
+	}
+	for _, hook := range hooks {
+		if err := handler.HandleHook(hook.event, buildHookDataJSON(hook.data)); err != nil {
+			t.Fatalf("HandleHook(%s) error: %v", hook.event, err)
+		}
+	}
+
+	if len(events.published) != 2 {
+		t.Fatalf("got %d events, want sent and suppressed (lifecycle events are not notifications): %+v", len(events.published), events.published)
+	}
+	sent, suppressed := events.published[0], events.published[1]
+	if sent.Type != "sent" || sent.Event != "Stop" || sent.Status != string(analyzer.StatusTaskComplete) ||
+		sent.SessionID != "test-watch-sent" || sent.CWD != "/work/app" || sent.Message == "" {
+		t.Errorf("sent event = %+v", sent)
+	}
+	if sent.Channels["desktop"] != history.ChannelSent {
+		t.Errorf("channels = %v, want desktop sent", sent.Channels)
+	}
+	if suppressed.Type != "suppressed" || suppressed.Status != status || !strings.Contains(suppressed.Reason, "filter") {
+		t.Errorf("suppressed event = %+v, want plan_ready with the filter reason", suppressed)
+	}
+}
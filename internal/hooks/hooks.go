@@ -13,9 +13,13 @@ import (
 	"github.com/777genius/claude-notifications/internal/config"
 	"github.com/777genius/claude-notifications/internal/dedup"
 	"github.com/777genius/claude-notifications/internal/errorhandler"
+	"github.com/777genius/claude-notifications/internal/execchannel"
+	"github.com/777genius/claude-notifications/internal/history"
 	"github.com/777genius/claude-notifications/internal/logging"
+	"github.com/777genius/claude-notifications/internal/msgtemplate"
 	"github.com/777genius/claude-notifications/internal/notifier"
 	"github.com/777genius/claude-notifications/internal/platform"
+	"github.com/777genius/claude-notifications/internal/quiethours"
 	"github.com/777genius/claude-notifications/internal/sessionname"
 	"github.com/777genius/claude-notifications/internal/state"
 	"github.com/777genius/claude-notifications/internal/summary"
@@ -29,28 +33,146 @@ type HookData struct {
 	CWD            string `json:"cwd"`
 	ToolName       string `json:"tool_name,omitempty"`
 	HookEventName  string `json:"hook_event_name,omitempty"`
+	Prompt         string `json:"prompt,omitempty"`  // UserPromptSubmit: the submitted prompt text
+	Source         string `json:"source,omitempty"`  // SessionStart: startup, resume, clear, compact
+	Reason         string `json:"reason,omitempty"`  // SessionEnd: clear, logout, prompt_input_exit, other
+	Trigger        string `json:"trigger,omitempty"` // PreCompact: manual, auto
+
+	// Notification: human-readable text and its kind (permission_prompt, idle_prompt, ...)
+	Message          string `json:"message,omitempty"`
+	NotificationType string `json:"notification_type,omitempty"`
 }
 
+// staleStateMaxAge is the age (in seconds) after which session state files are
+// removed by cleanup. State must outlive a whole turn (UserPromptSubmit → Stop),
+// and SessionEnd removes it explicitly, so this only catches abandoned sessions.
+const staleStateMaxAge = 24 * 60 * 60
+
+// duplicateMessageWindow is the time (in seconds) within which the same message
+// from a session is sent only once
+const duplicateMessageWindow = 180
+
 // notifierInterface defines the interface for sending desktop notifications
 type notifierInterface interface {
-	SendDesktop(status analyzer.Status, message, sessionID, cwd string) error
+	SendDesktop(status analyzer.Status, msg notifier.Message, sessionID, cwd string) error
+	SetQuiet(quiet bool)
+	SetSound(sound *string)
 	Close() error
 }
 
 // webhookInterface defines the interface for sending webhook notifications
 type webhookInterface interface {
-	SendAsync(status analyzer.Status, message, sessionID string)
+	SendAsyncTo(targets []string, status analyzer.Status, message, sessionID, cwd string) // nil targets = every target
+	Shutdown(timeout time.Duration) error
+	TargetMetrics() map[string]webhook.Stats // Per target name
+}
+
+// execInterface defines the interface for running the user's exec channel command
+type execInterface interface {
+	SendAsync(status analyzer.Status, message, sessionID, cwd string)
 	Shutdown(timeout time.Duration) error
+	GetStats() execchannel.Stats
+}
+
+// reminderInterface defines the interface for scheduling reminders of unanswered prompts
+type reminderInterface interface {
+	Schedule(req notifier.ReminderRequest) error
+	Cancel(sessionID string) error
+}
+
+// daemonReminders schedules reminders in the notification daemon
+type daemonReminders struct{}
+
+func (daemonReminders) Schedule(req notifier.ReminderRequest) error {
+	return notifier.ScheduleReminder(req)
+}
+
+func (daemonReminders) Cancel(sessionID string) error {
+	return notifier.CancelReminders(sessionID)
+}
+
+// eventInterface defines the interface for passing notification outcomes to `watch` clients
+type eventInterface interface {
+	Publish(ev notifier.Event) error
+}
+
+// daemonEvents publishes events through the notification daemon
+type daemonEvents struct{}
+
+func (daemonEvents) Publish(ev notifier.Event) error {
+	return notifier.PublishEvent(ev)
+}
+
+// claimInterface defines the interface for dedup and cooldown state kept outside the temp files
+type claimInterface interface {
+	CheckDuplicate(sessionID, hookEvent string) (notifier.ClaimResult, error)
+	Claim(claim notifier.NotificationClaim) (notifier.ClaimResult, error)
+	Forget(sessionID string) error
+}
+
+// daemonClaims keeps dedup and cooldown state in the notification daemon's memory
+type daemonClaims struct{}
+
+func (daemonClaims) CheckDuplicate(sessionID, hookEvent string) (notifier.ClaimResult, error) {
+	return notifier.CheckDuplicate(sessionID, hookEvent)
+}
+
+func (daemonClaims) Claim(claim notifier.NotificationClaim) (notifier.ClaimResult, error) {
+	return notifier.ClaimNotification(claim)
+}
+
+func (daemonClaims) Forget(sessionID string) error {
+	return notifier.ForgetSession(sessionID)
+}
+
+// aggregateInterface defines the interface for the aggregation window that merges notifications
+type aggregateInterface interface {
+	Queue(req notifier.AggregateRequest) error
+}
+
+// daemonAggregate queues notifications in the notification daemon
+type daemonAggregate struct{}
+
+func (daemonAggregate) Queue(req notifier.AggregateRequest) error {
+	return notifier.QueueAggregate(req)
+}
+
+// Reminder is the payload the daemon passes to `claude-notifications remind`
+// when a reminder for an unanswered prompt is due
+type Reminder struct {
+	SessionID string `json:"session_id"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	CWD       string `json:"cwd"`
+	Attempt   int    `json:"attempt"`  // 1-based reminder number
+	Total     int    `json:"total"`    // Number of reminders in the backoff
+	Escalate  bool   `json:"escalate"` // Also send via webhook
 }
 
 // Handler handles hook events
 type Handler struct {
-	cfg         *config.Config
-	dedupMgr    *dedup.Manager
-	stateMgr    *state.Manager
-	notifierSvc notifierInterface
-	webhookSvc  webhookInterface
-	pluginRoot  string
+	cfg          *config.Config // Effective config: user config plus project overrides for the current event
+	globalCfg    *config.Config // User config without project overrides (nil until first used)
+	dedupMgr     *dedup.Manager
+	stateMgr     *state.Manager
+	notifierSvc  notifierInterface
+	webhookSvc   webhookInterface
+	execSvc      execInterface
+	reminderSvc  reminderInterface
+	eventSvc     eventInterface     // nil = no `watch` events (replay)
+	claimSvc     claimInterface     // nil = dedup and cooldown state in temp files
+	aggregateSvc aggregateInterface // nil = deliver right away (replay)
+	pluginRoot   string
+	ownServices  bool    // Delivery services were built from cfg here and must be rebuilt when it changes
+	journalDir   string  // Hook journal directory ("" = journal disabled)
+	historyPath  string  // Notification history file ("" = history disabled)
+	dndPath      string  // Manual do-not-disturb override file ("" = no override)
+	outcome      Outcome // What the last HandleHook call did
+	mutedBy      string  // Quiet hours reason when the current notification is delivered silently
+
+	// Notification dispatched by the current call, for the history
+	message  string
+	channels []history.Channel
 }
 
 // NewHandler creates a new hook handler
@@ -66,14 +188,48 @@ func NewHandler(pluginRoot string) (*Handler, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	return &Handler{
-		cfg:         cfg,
-		dedupMgr:    dedup.NewManager(),
-		stateMgr:    state.NewManager(),
-		notifierSvc: notifier.New(cfg),
-		webhookSvc:  webhook.New(cfg),
-		pluginRoot:  pluginRoot,
-	}, nil
+	h := &Handler{
+		cfg:          cfg,
+		dedupMgr:     dedup.NewManager(),
+		stateMgr:     state.NewManager(),
+		notifierSvc:  notifier.New(cfg),
+		webhookSvc:   webhook.New(cfg),
+		execSvc:      execchannel.New(cfg),
+		reminderSvc:  daemonReminders{},
+		eventSvc:     daemonEvents{},
+		aggregateSvc: daemonAggregate{},
+		pluginRoot:   pluginRoot,
+		ownServices:  true,
+	}
+
+	// Chosen once from the user config: project configs can't change daemon settings
+	if cfg.Notifications.Daemon.StateInMemory {
+		h.claimSvc = daemonClaims{}
+	}
+
+	if cfg.Notifications.Journal.Enabled {
+		if dir, err := cfg.GetJournalDir(); err != nil {
+			logging.Warn("Hook journal disabled: %v", err)
+		} else {
+			h.journalDir = dir
+		}
+	}
+
+	if cfg.IsHistoryEnabled() {
+		if path, err := cfg.GetHistoryPath(); err != nil {
+			logging.Warn("Notification history disabled: %v", err)
+		} else {
+			h.historyPath = path
+		}
+	}
+
+	if path, err := cfg.GetDNDPath(); err != nil {
+		logging.Warn("Do-not-disturb override unavailable: %v", err)
+	} else {
+		h.dndPath = path
+	}
+
+	return h, nil
 }
 
 // HandleHook handles a hook event
@@ -81,14 +237,32 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 	// Add panic recovery for robustness
 	defer errorhandler.HandlePanic()
 
+	h.outcome = Outcome{Kind: OutcomeSkipped}
+	h.message, h.channels, h.mutedBy = "", nil, ""
+
+	// Read the raw payload once so it can be journaled before parsing
+	payload, err := io.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("failed to read hook data: %w", err)
+	}
+	h.recordJournal(hookEvent, payload)
+
 	// Skip notifications when running in background judge mode (e.g., double-shot-latte plugin)
 	// The CLAUDE_HOOK_JUDGE_MODE env var is set by plugins that spawn background Claude instances
 	// to evaluate context/decide on continuation - we don't want notifications from these
 	// Can be disabled via config: "respectJudgeMode": false
 	if h.cfg.ShouldRespectJudgeMode() && os.Getenv("CLAUDE_HOOK_JUDGE_MODE") == "true" {
+		h.outcome = Outcome{Kind: OutcomeSuppressed, Reason: "judge mode (CLAUDE_HOOK_JUDGE_MODE=true)"}
 		return nil
 	}
 
+	// Record the outcome in the history and for `watch` last, once the async channels below have shut down
+	var hookData HookData
+	defer func() {
+		h.recordHistory(hookEvent, hookData.SessionID, hookData.CWD)
+		h.publishEvent(hookEvent, hookData.SessionID, hookData.CWD)
+	}()
+
 	// Ensure notifier resources are cleaned up when function exits
 	defer func() {
 		if err := h.notifierSvc.Close(); err != nil {
@@ -103,17 +277,23 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		}
 	}()
 
+	// Ensure exec commands finish (each is bounded by exec.timeout)
+	defer func() {
+		if err := h.execSvc.Shutdown(h.cfg.GetExecTimeout() + time.Second); err != nil {
+			logging.Warn("Failed to shutdown exec channel: %v", err)
+		}
+	}()
+
 	logging.SetPrefix(fmt.Sprintf("PID:%d", os.Getpid()))
 	logging.Debug("=== Hook triggered: %s ===", hookEvent)
 
 	// Parse hook data
-	var hookData HookData
-	if err := json.NewDecoder(input).Decode(&hookData); err != nil {
+	if err := json.Unmarshal(payload, &hookData); err != nil {
 		return fmt.Errorf("failed to parse hook data: %w", err)
 	}
 
-	logging.Debug("Hook data: session=%s, transcript=%s, tool=%s",
-		hookData.SessionID, hookData.TranscriptPath, hookData.ToolName)
+	logging.Debug("Hook data: session=%s, transcript=%s, tool=%s, notification_type=%s",
+		hookData.SessionID, hookData.TranscriptPath, hookData.ToolName, hookData.NotificationType)
 
 	// Validate session ID
 	if hookData.SessionID == "" {
@@ -121,21 +301,34 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		logging.Warn("Session ID is empty, using 'unknown'")
 	}
 
+	// Lifecycle events only record session state, they never notify
+	h.outcome.Reason = "lifecycle event"
+	switch hookEvent {
+	case "SessionStart":
+		return h.handleSessionStart(&hookData)
+	case "SessionEnd":
+		return h.handleSessionEnd(&hookData)
+	case "UserPromptSubmit":
+		return h.handleUserPromptSubmit(&hookData)
+	case "PostToolUse":
+		return h.handlePostToolUse(&hookData)
+	}
+
+	// Per-project .claude-notifications.json overrides the user config from here on
+	h.applyProjectConfig(hookData.CWD)
+
 	// Phase 1: Early duplicate check (per hook event type)
-	if h.dedupMgr.CheckEarlyDuplicate(hookData.SessionID, hookEvent) {
-		logging.Debug("Early duplicate detected, skipping")
-		return nil
+	if h.checkEarlyDuplicate(hookData.SessionID, hookEvent) {
+		return h.skip(OutcomeDeduplicated, "", "Early duplicate detected, skipping")
 	}
 
 	// Check if any notification method is enabled
 	if !h.cfg.IsAnyNotificationEnabled() {
-		logging.Debug("All notifications disabled, exiting")
-		return nil
+		return h.skip(OutcomeSuppressed, "", "All notifications disabled, exiting")
 	}
 
 	// Determine status based on hook type
 	var status analyzer.Status
-	var err error
 
 	switch hookEvent {
 	case "PreToolUse":
@@ -149,8 +342,7 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 	case "Stop":
 		// Check if this is a subagent transcript and should be suppressed
 		if h.cfg.ShouldSuppressForSubagents() && isSubagentTranscript(hookData.TranscriptPath) {
-			logging.Debug("Stop: subagent transcript detected (%s), suppressing (config: suppressForSubagents)", hookData.TranscriptPath)
-			return nil
+			return h.skip(OutcomeSuppressed, "", "Stop: subagent transcript detected (%s), suppressing (config: suppressForSubagents)", hookData.TranscriptPath)
 		}
 		// Analyze the transcript to determine status
 		status, err = h.handleStopEvent(&hookData)
@@ -164,13 +356,11 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		// Check config: should we suppress subagent notifications?
 		// First check path-based suppression (covers subagents and teammates)
 		if h.cfg.ShouldSuppressForSubagents() && isSubagentTranscript(hookData.TranscriptPath) {
-			logging.Debug("SubagentStop: subagent transcript detected (%s), suppressing (config: suppressForSubagents)", hookData.TranscriptPath)
-			return nil
+			return h.skip(OutcomeSuppressed, "", "SubagentStop: subagent transcript detected (%s), suppressing (config: suppressForSubagents)", hookData.TranscriptPath)
 		}
 		// Then check the legacy notifyOnSubagentStop flag
 		if !h.cfg.Notifications.NotifyOnSubagentStop {
-			logging.Debug("SubagentStop: notifications disabled (config: notifyOnSubagentStop), skipping")
-			return nil
+			return h.skip(OutcomeSuppressed, "", "SubagentStop: notifications disabled (config: notifyOnSubagentStop), skipping")
 		}
 		// If enabled, handle like Stop
 		logging.Debug("SubagentStop: notifications enabled (config), processing")
@@ -179,41 +369,138 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 			return err
 		}
 		defer h.cleanupOldLocks()
+	case "PreCompact":
+		if !h.cfg.Notifications.NotifyOnPreCompact {
+			return h.skip(OutcomeSuppressed, "", "PreCompact: notifications disabled (config: notifyOnPreCompact), skipping")
+		}
+		status = analyzer.StatusContextCompacting
 	default:
 		return fmt.Errorf("unknown hook event: %s", hookEvent)
 	}
 
 	// If status is unknown, skip
 	if status == analyzer.StatusUnknown {
-		logging.Debug("Status is unknown, skipping notification")
-		return nil
+		return h.skip(OutcomeSkipped, status, "Status is unknown, skipping notification")
 	}
 
+	// Generate message (read-only; suppress filters may match its text)
+	message := h.generateMessage(&hookData, status)
+	h.message = message
+
 	// Check suppress-filters before any state mutations (dedup lock, cooldowns)
-	{
-		gitBranch := platform.GetGitBranch(hookData.CWD)
-		folderName := filepath.Base(hookData.CWD)
-		if h.cfg.ShouldFilter(string(status), gitBranch, folderName) {
-			logging.Debug("Notification suppressed by filter: status=%s branch=%q folder=%s", status, gitBranch, folderName)
-			return nil
+	match := h.matchInput(hookEvent, &hookData, status, message)
+	if filter := h.cfg.MatchingFilter(match); filter != nil {
+		by := "filter"
+		if filter.Name != "" {
+			by = fmt.Sprintf("filter %q", filter.Name)
 		}
+		return h.skip(OutcomeSuppressed, status, "Notification suppressed by %s: status=%s branch=%q folder=%s", by, status, match.GitBranch, match.Folder)
 	}
 
-	// Phase 2: Acquire lock before sending (per hook event type)
+	// Quiet hours / do-not-disturb: drop the notification or deliver it silently
+	if quiet := h.quietHours(status); quiet.Quiet {
+		if h.cfg.GetQuietHoursMode() == config.QuietModeSuppress {
+			return h.skip(OutcomeSuppressed, status, "Notification suppressed by %s", quiet.Reason())
+		}
+		h.mutedBy = "muted by " + quiet.Reason()
+		logging.Debug("Notification %s", h.mutedBy)
+	}
+
+	// Skip quick turns the user was most likely watching (minTurnDurationSeconds)
+	if h.isShortTurn(&hookData, status) {
+		h.outcome = Outcome{Kind: OutcomeSuppressed, Status: status, Reason: "turn shorter than minTurnDurationSeconds"}
+		return nil
+	}
+
+	// Phase 2: check the dedup lock, question cooldowns and duplicate content, and record the notification
+	claimed, release, err := h.claimNotification(&hookData, hookEvent, status, message)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if !claimed {
+		return nil
+	}
+
+	// Send notifications
+	kind := OutcomeSent
+	if h.sendNotifications(status, message, match, h.turnDetails(&hookData)) {
+		kind = OutcomeQueued
+	}
+	h.outcome = Outcome{Kind: kind, Status: status, Reason: h.mutedBy}
+
+	// Repeat the notification later if nobody answers it
+	h.scheduleReminders(&hookData, status, message)
+
+	logging.Debug("=== Hook completed: %s ===", hookEvent)
+	return nil
+}
+
+// checkEarlyDuplicate is phase 1 of the dedup: true if the hook event fired for the session
+// less than 2s ago. With notifications.daemon.stateInMemory the daemon answers; if it
+// isn't available, the lock files do.
+func (h *Handler) checkEarlyDuplicate(sessionID, hookEvent string) bool {
+	if h.claimSvc != nil {
+		result, err := h.claimSvc.CheckDuplicate(sessionID, hookEvent)
+		if err == nil {
+			return result.Duplicate
+		}
+		logging.Warn("Daemon dedup state unavailable, using lock files: %v", err)
+	}
+	return h.dedupMgr.CheckEarlyDuplicate(sessionID, hookEvent)
+}
+
+// noRelease is the release func of a notification that holds no lock
+func noRelease() {}
+
+// claimNotification is phase 2 of the dedup: it checks the notification against the
+// per-event lock, the question cooldowns and the last message, and records it if it may
+// be sent. claimed is false if it was skipped (the outcome is set); call release in
+// either case once done. The daemon does this in one atomic call; without it the lock and state files
+// in the temp dir are used.
+func (h *Handler) claimNotification(hookData *HookData, hookEvent string, status analyzer.Status, message string) (claimed bool, release func(), err error) {
+	if h.claimSvc != nil {
+		result, err := h.claimSvc.Claim(notifier.NotificationClaim{
+			SessionID:         hookData.SessionID,
+			HookEvent:         hookEvent,
+			Message:           message,
+			TaskComplete:      status == analyzer.StatusTaskComplete,
+			InputRequest:      isInputRequestStatus(status),
+			CooldownAfterAny:  h.cfg.GetSuppressQuestionAfterAnyNotificationSeconds(),
+			CooldownAfterTask: h.cfg.GetSuppressQuestionAfterTaskCompleteSeconds(),
+			DuplicateWindow:   duplicateMessageWindow,
+		})
+		if err == nil {
+			if result.Allowed {
+				logging.Debug("Notification claimed in the daemon")
+				return true, noRelease, nil
+			}
+			kind := OutcomeSuppressed
+			if result.Duplicate {
+				kind = OutcomeDeduplicated
+			}
+			return false, noRelease, h.skip(kind, status, "%s", result.Reason)
+		}
+		logging.Warn("Daemon dedup state unavailable, using state files: %v", err)
+	}
+	return h.claimWithFiles(hookData, hookEvent, status, message)
+}
+
+// claimWithFiles is claimNotification on the lock and state files in the temp dir
+func (h *Handler) claimWithFiles(hookData *HookData, hookEvent string, status analyzer.Status, message string) (bool, func(), error) {
 	acquired, err := h.dedupMgr.AcquireLock(hookData.SessionID, hookEvent)
 	if err != nil {
-		return fmt.Errorf("failed to acquire lock: %w", err)
+		return false, noRelease, fmt.Errorf("failed to acquire lock: %w", err)
 	}
 	if !acquired {
-		logging.Debug("Failed to acquire lock (duplicate), skipping")
-		return nil
+		return false, noRelease, h.skip(OutcomeDeduplicated, status, "Failed to acquire lock (duplicate), skipping")
 	}
 
 	logging.Debug("Lock acquired, proceeding with notification")
 	// Note: Lock is NOT released - it ages out naturally after 2s to prevent rapid duplicates
 
-	// Check cooldown for question status BEFORE updating notification time
-	if status == analyzer.StatusQuestion {
+	// Check cooldown for question-like statuses BEFORE updating notification time
+	if isInputRequestStatus(status) {
 		logging.Debug("Checking question cooldown: cooldownSeconds=%d", h.cfg.GetSuppressQuestionAfterAnyNotificationSeconds())
 
 		// Load state to log its contents
@@ -235,9 +522,7 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		if err != nil {
 			logging.Warn("Failed to check cooldown after any notification: %v", err)
 		} else if suppressAfterAny {
-			logging.Debug("Question suppressed due to recent notification from this session")
-			// Lock will be released by defer
-			return nil
+			return false, noRelease, h.skip(OutcomeSuppressed, status, "Question suppressed due to recent notification from this session")
 		} else {
 			logging.Debug("Question NOT suppressed (cooldown check passed)")
 		}
@@ -250,9 +535,7 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		if err != nil {
 			logging.Warn("Failed to check cooldown: %v", err)
 		} else if suppress {
-			logging.Debug("Question suppressed due to cooldown after task complete")
-			// Lock will be released by defer
-			return nil
+			return false, noRelease, h.skip(OutcomeSuppressed, status, "Question suppressed due to cooldown after task complete")
 		}
 	}
 
@@ -263,9 +546,6 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		}
 	}
 
-	// Generate message
-	message := h.generateMessage(&hookData, status)
-
 	// Acquire content lock to prevent race between different hooks (Stop vs Notification)
 	// This ensures only one process can check and update duplicate state at a time
 	contentLockAcquired, err := h.dedupMgr.AcquireContentLock(hookData.SessionID)
@@ -274,26 +554,24 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		// Error (not "lock busy") - continue without lock as fallback
 	} else if !contentLockAcquired {
 		// Lock is held by another process - it's already handling this notification
-		logging.Debug("Content lock held by another process, skipping to prevent duplicate")
-		return nil
+		return false, noRelease, h.skip(OutcomeDeduplicated, status, "Content lock held by another process, skipping to prevent duplicate")
 	}
 
-	// Release lock on exit if acquired
-	defer func() {
+	// Release the content lock once the notification is sent
+	release := func() {
 		if contentLockAcquired {
 			if err := h.dedupMgr.ReleaseContentLock(hookData.SessionID); err != nil {
 				logging.Warn("Failed to release content lock: %v", err)
 			}
 		}
-	}()
+	}
 
 	// Check for duplicate message content (3 minutes = 180 seconds window)
-	isDuplicate, err := h.stateMgr.IsDuplicateMessage(hookData.SessionID, message, 180)
+	isDuplicate, err := h.stateMgr.IsDuplicateMessage(hookData.SessionID, message, duplicateMessageWindow)
 	if err != nil {
 		logging.Warn("Failed to check duplicate message: %v", err)
 	} else if isDuplicate {
-		logging.Debug("Duplicate message content detected within 3 minutes, skipping")
-		return nil
+		return false, release, h.skip(OutcomeDeduplicated, status, "Duplicate message content detected within 3 minutes, skipping")
 	}
 
 	// Update last notification time and message
@@ -301,11 +579,7 @@ func (h *Handler) HandleHook(hookEvent string, input io.Reader) error {
 		logging.Warn("Failed to update last notification: %v", err)
 	}
 
-	// Send notifications
-	h.sendNotifications(status, message, hookData.SessionID, hookData.CWD)
-
-	logging.Debug("=== Hook completed: %s ===", hookEvent)
-	return nil
+	return true, release, nil
 }
 
 // handlePreToolUse handles PreToolUse hook
@@ -328,11 +602,68 @@ func (h *Handler) handlePreToolUse(hookData *HookData) analyzer.Status {
 }
 
 // handleNotificationEvent handles Notification hook
-// Always returns StatusQuestion as per design: Notification hook is triggered
-// when Claude needs user input (e.g., permission dialogs, questions)
+// The hook is triggered when Claude needs user input: permission dialogs map to
+// StatusPermissionRequest, idle reminders to StatusIdlePrompt, anything else to StatusQuestion
 func (h *Handler) handleNotificationEvent(hookData *HookData) (analyzer.Status, error) {
-	logging.Debug("Notification event received → question status")
-	return analyzer.StatusQuestion, nil
+	status := analyzer.GetStatusForNotification(hookData.NotificationType, hookData.Message)
+	logging.Debug("Notification event received (type=%q, message=%q) → %s status",
+		hookData.NotificationType, hookData.Message, status)
+	return status, nil
+}
+
+// handleSessionStart registers a new (or resumed) session
+func (h *Handler) handleSessionStart(hookData *HookData) error {
+	logging.Debug("SessionStart: source=%s, cwd=%s", hookData.Source, hookData.CWD)
+
+	if err := h.stateMgr.RegisterSession(hookData.SessionID, hookData.CWD, hookData.Source); err != nil {
+		logging.Warn("Failed to register session: %v", err)
+	}
+	return nil
+}
+
+// handleSessionEnd removes all state and lock files of a finished session
+func (h *Handler) handleSessionEnd(hookData *HookData) error {
+	logging.Debug("SessionEnd: reason=%s, cleaning up session state", hookData.Reason)
+
+	if err := h.stateMgr.Delete(hookData.SessionID); err != nil {
+		logging.Warn("Failed to delete session state: %v", err)
+	}
+	if err := h.dedupMgr.CleanupSessionLocks(hookData.SessionID); err != nil {
+		logging.Warn("Failed to cleanup session locks: %v", err)
+	}
+	if h.claimSvc != nil {
+		if err := h.claimSvc.Forget(hookData.SessionID); err != nil {
+			logging.Warn("Failed to drop daemon dedup state: %v", err)
+		}
+	}
+	h.cancelReminders(hookData.SessionID)
+	return nil
+}
+
+// handleUserPromptSubmit records the start time of the new turn
+func (h *Handler) handleUserPromptSubmit(hookData *HookData) error {
+	logging.Debug("UserPromptSubmit: recording turn start (prompt length=%d)", len(hookData.Prompt))
+
+	if err := h.stateMgr.UpdateTurnStart(hookData.SessionID, hookData.CWD); err != nil {
+		logging.Warn("Failed to update turn start: %v", err)
+	}
+	h.cancelReminders(hookData.SessionID)
+	return nil
+}
+
+// handlePostToolUse marks an interactive tool (AskUserQuestion/ExitPlanMode) as answered
+func (h *Handler) handlePostToolUse(hookData *HookData) error {
+	logging.Debug("PostToolUse: tool_name='%s'", hookData.ToolName)
+
+	if analyzer.GetStatusForPreToolUse(hookData.ToolName) == analyzer.StatusUnknown {
+		return nil
+	}
+
+	if err := h.stateMgr.ClearInteractiveTool(hookData.SessionID); err != nil {
+		logging.Warn("Failed to clear interactive tool state: %v", err)
+	}
+	h.cancelReminders(hookData.SessionID)
+	return nil
 }
 
 // handleStopEvent handles Stop/SubagentStop hooks
@@ -359,6 +690,18 @@ func (h *Handler) handleStopEvent(hookData *HookData) (analyzer.Status, error) {
 
 // generateMessage generates a notification message
 func (h *Handler) generateMessage(hookData *HookData, status analyzer.Status) string {
+	if status == analyzer.StatusContextCompacting {
+		if hookData.Trigger == "manual" {
+			return "Compacting conversation context (/compact)"
+		}
+		return "Context window full, auto-compacting conversation"
+	}
+
+	// Permission/idle prompts carry their own text, e.g. "Claude needs your permission to use Bash"
+	if (status == analyzer.StatusPermissionRequest || status == analyzer.StatusIdlePrompt) && hookData.Message != "" {
+		return hookData.Message
+	}
+
 	if hookData.TranscriptPath != "" && platform.FileExists(hookData.TranscriptPath) {
 		msg := summary.GenerateFromTranscript(hookData.TranscriptPath, status, h.cfg)
 		if msg != "" {
@@ -369,43 +712,440 @@ func (h *Handler) generateMessage(hookData *HookData, status analyzer.Status) st
 	return summary.GenerateSimple(status, h.cfg)
 }
 
-// sendNotifications sends desktop and webhook notifications
-func (h *Handler) sendNotifications(status analyzer.Status, message, sessionID, cwd string) {
+// renderMessage renders the desktop notification and webhook text for status with
+// the message templates (notifications.templates). A template that fails to render
+// falls back to the built-in layout.
+func (h *Handler) renderMessage(status analyzer.Status, message string, match config.MatchInput, details summary.TurnDetails) (notifier.Message, string) {
+	ctx := msgtemplate.Context{
+		Status:      string(status),
+		Title:       string(status),
+		Summary:     message,
+		SessionID:   match.SessionID,
+		SessionName: match.SessionName,
+		Branch:      match.GitBranch,
+		Folder:      match.Folder,
+		CWD:         match.CWD,
+		Duration:    details.Duration,
+		ToolCounts:  details.ToolCounts,
+		Question:    details.Question,
+	}
+	if info, ok := h.cfg.GetStatusInfo(string(status)); ok {
+		ctx.Title = info.Title
+	}
+	logging.Debug("Session name: %s, git branch: %s, folder: %s", ctx.SessionName, ctx.Branch, ctx.Folder)
+
+	templates := h.cfg.GetTemplates(string(status))
+	desktop := notifier.Message{
+		Title:    renderTemplate("desktop.title", templates.Desktop.Title, msgtemplate.DefaultDesktopTitle, ctx),
+		Subtitle: renderTemplate("desktop.subtitle", templates.Desktop.Subtitle, msgtemplate.DefaultDesktopSubtitle, ctx),
+		Body:     renderTemplate("desktop.body", templates.Desktop.Body, msgtemplate.DefaultDesktopBody, ctx),
+	}
+	return desktop, renderTemplate("webhook.text", templates.Webhook.Text, msgtemplate.DefaultWebhookText, ctx)
+}
+
+// renderTemplate renders text with ctx, or fallback if text fails
+func renderTemplate(name, text, fallback string, ctx msgtemplate.Context) string {
+	out, err := msgtemplate.Render(text, ctx)
+	if err != nil {
+		logging.Warn("Template %s failed, using the default: %v", name, err)
+		out, _ = msgtemplate.Render(fallback, ctx)
+	}
+	return strings.TrimSpace(out)
+}
+
+// turnDetails collects the turn's duration, tool counts and question for message templates
+func (h *Handler) turnDetails(hookData *HookData) summary.TurnDetails {
+	var details summary.TurnDetails
+	if hookData.TranscriptPath != "" && platform.FileExists(hookData.TranscriptPath) {
+		details = summary.TurnDetailsFromTranscript(hookData.TranscriptPath)
+	}
+	if duration, ok := h.turnDuration(hookData); ok {
+		details.Duration = duration
+	}
+	return details
+}
+
+// matchInput collects what suppress filters and routing rules match against
+func (h *Handler) matchInput(hookEvent string, hookData *HookData, status analyzer.Status, message string) config.MatchInput {
+	return config.MatchInput{
+		Status:      string(status),
+		GitBranch:   platform.GetGitBranch(hookData.CWD),
+		Folder:      filepath.Base(hookData.CWD),
+		CWD:         hookData.CWD,
+		Message:     message,
+		SessionID:   hookData.SessionID,
+		SessionName: sessionname.GenerateSessionLabel(hookData.SessionID),
+		Subagent:    hookEvent == "SubagentStop" || isSubagentTranscript(hookData.TranscriptPath),
+		Time:        time.Now(),
+	}
+}
+
+// sendNotifications sends the notification to the channels chosen by the routing rules
+// (or the per-status settings when no rule matches). queued is true if desktop and webhook
+// wait for the daemon's aggregation window; `deliver` records their result.
+func (h *Handler) sendNotifications(status analyzer.Status, message string, match config.MatchInput, details summary.TurnDetails) (queued bool) {
 	// Add panic recovery to prevent notification failures from crashing the plugin
 	defer errorhandler.HandlePanic()
 
-	// Add session name, git branch and folder name to message
-	sessionName := sessionname.GenerateSessionLabel(sessionID)
-	gitBranch := platform.GetGitBranch(cwd)
-	folderName := filepath.Base(cwd)
+	sessionID, cwd := match.SessionID, match.CWD
+	desktopMessage, webhookMessage := h.renderMessage(status, message, match, details)
+
+	statusStr := string(status)
+	route := h.cfg.Route(match)
+	if len(route.Rules) > 0 {
+		logging.Debug("Routing: matched %s -> desktop=%v webhook=%v exec=%v",
+			strings.Join(route.Rules, ", "), route.Desktop, route.Webhook, route.Exec)
+	}
+
+	// Desktop and webhook may wait for the aggregation window; exec always runs right away
+	aggregated := (route.Desktop || route.Webhook) && h.queueAggregate(status, message, match, route, desktopMessage, webhookMessage)
+
+	// Send desktop notification
+	if route.Desktop && aggregated {
+		h.recordChannel(channelDesktop, history.ChannelQueued, nil)
+	} else if route.Desktop {
+		h.notifierSvc.SetQuiet(h.mutedBy != "")
+		h.notifierSvc.SetSound(route.Sound)
+		err := h.notifierSvc.SendDesktop(status, desktopMessage, sessionID, cwd)
+		if err != nil {
+			errorhandler.HandleError(err, "Failed to send desktop notification")
+		}
+		h.recordChannel(channelDesktop, history.ChannelSent, err)
+	} else {
+		logging.Debug("Desktop notification disabled for status: %s", statusStr)
+		h.recordChannel(channelDesktop, history.ChannelDisabled, nil)
+	}
+
+	// Send webhook notification (async)
+	if route.Webhook && aggregated {
+		h.recordChannel(channelWebhook, history.ChannelQueued, nil)
+	} else if route.Webhook {
+		h.webhookSvc.SendAsyncTo(route.Webhooks, status, webhookMessage, sessionID, cwd)
+		h.recordChannel(channelWebhook, "", nil)
+	} else {
+		logging.Debug("Webhook notification disabled for status: %s", statusStr)
+		h.recordChannel(channelWebhook, history.ChannelDisabled, nil)
+	}
 
-	// Format: "[sessionname|branch folder] message" or "[sessionname folder] message"
-	var enhancedMessage string
-	if gitBranch != "" {
-		enhancedMessage = fmt.Sprintf("[%s|%s %s] %s", sessionName, gitBranch, folderName, message)
+	// Run exec channel command (async)
+	// The command gets the plain message: session, branch and folder are separate fields
+	if route.Exec {
+		h.execSvc.SendAsync(status, message, sessionID, cwd)
+		h.recordChannel(channelExec, "", nil)
 	} else {
-		enhancedMessage = fmt.Sprintf("[%s %s] %s", sessionName, folderName, message)
+		logging.Debug("Exec channel disabled for status: %s", statusStr)
+		h.recordChannel(channelExec, history.ChannelDisabled, nil)
+	}
+	return aggregated
+}
+
+// queueAggregate hands the desktop and webhook delivery to the daemon's aggregation window
+// (notifications.aggregate). Returns false if the status doesn't wait or the daemon isn't
+// available; the caller then sends right away.
+func (h *Handler) queueAggregate(status analyzer.Status, message string, match config.MatchInput, route config.Route, desktop notifier.Message, webhookText string) bool {
+	if h.aggregateSvc == nil || !h.cfg.IsAggregateEnabledFor(string(status)) {
+		return false
+	}
+
+	window := h.cfg.GetAggregateWindow()
+	err := h.aggregateSvc.Queue(notifier.AggregateRequest{
+		SessionID:   match.SessionID,
+		Status:      string(status),
+		Message:     message,
+		Rendered:    desktop,
+		WebhookText: webhookText,
+		CWD:         match.CWD,
+		Desktop:     route.Desktop,
+		Webhook:     route.Webhook,
+		Webhooks:    route.Webhooks,
+		Sound:       route.Sound,
+		Quiet:       h.mutedBy != "",
+		PluginRoot:  h.pluginRoot,
+		Window:      window,
+	})
+	if err != nil {
+		logging.Debug("Aggregation unavailable, sending right away: %v", err)
+		return false
+	}
+	logging.Debug("Notification queued for the aggregation window (%v)", window)
+	return true
+}
+
+// scheduleReminders marks the prompt as pending and asks the daemon to repeat
+// the notification with backoff until the user answers it
+func (h *Handler) scheduleReminders(hookData *HookData, status analyzer.Status, message string) {
+	if !h.cfg.IsReminderEnabledFor(string(status)) {
+		return
+	}
+	backoff := h.cfg.GetReminderBackoff()
+	if len(backoff) == 0 {
+		return
+	}
+
+	notifiedAt := platform.CurrentTimestamp()
+	if err := h.stateMgr.SetReminderPending(hookData.SessionID, status, notifiedAt); err != nil {
+		logging.Warn("Failed to mark reminder pending: %v", err)
+		return
+	}
+
+	err := h.reminderSvc.Schedule(notifier.ReminderRequest{
+		SessionID:      hookData.SessionID,
+		Status:         string(status),
+		Message:        message,
+		CWD:            hookData.CWD,
+		TranscriptPath: hookData.TranscriptPath,
+		PluginRoot:     h.pluginRoot,
+		NotifiedAt:     notifiedAt,
+		Backoff:        backoff,
+		EscalateAfter:  h.cfg.Notifications.Reminders.EscalateToWebhookAfter,
+	})
+	if err != nil {
+		logging.Debug("Reminders not scheduled: %v", err)
+		return
+	}
+	logging.Debug("Reminders scheduled: status=%s, backoff=%v", status, backoff)
+}
+
+// cancelReminders marks the pending prompt as answered and drops the daemon timers.
+// Cancels even when reminders are disabled now: timers scheduled before the config
+// changed would otherwise fire. Cancel is a no-op when no daemon is running.
+func (h *Handler) cancelReminders(sessionID string) {
+	if err := h.stateMgr.ClearReminderPending(sessionID); err != nil {
+		logging.Warn("Failed to clear pending reminder: %v", err)
+	}
+	if err := h.reminderSvc.Cancel(sessionID); err != nil {
+		logging.Debug("Failed to cancel reminders: %v", err)
+	}
+}
+
+// HandleReminder sends a reminder for a prompt that is still unanswered.
+// Called through the `remind` command when a daemon reminder timer fires.
+func (h *Handler) HandleReminder(input io.Reader) error {
+	defer errorhandler.HandlePanic()
+
+	h.outcome = Outcome{Kind: OutcomeSkipped}
+	h.message, h.channels, h.mutedBy = "", nil, ""
+
+	var reminder Reminder
+	defer func() {
+		h.recordHistory("reminder", reminder.SessionID, reminder.CWD)
+		h.publishEvent("reminder", reminder.SessionID, reminder.CWD)
+	}()
+
+	defer func() {
+		if err := h.notifierSvc.Close(); err != nil {
+			logging.Warn("Failed to close notifier: %v", err)
+		}
+	}()
+
+	defer func() {
+		if err := h.webhookSvc.Shutdown(5 * time.Second); err != nil {
+			logging.Warn("Failed to shutdown webhook sender: %v", err)
+		}
+	}()
+
+	defer func() {
+		if err := h.execSvc.Shutdown(h.cfg.GetExecTimeout() + time.Second); err != nil {
+			logging.Warn("Failed to shutdown exec channel: %v", err)
+		}
+	}()
+
+	logging.SetPrefix(fmt.Sprintf("PID:%d", os.Getpid()))
+
+	if err := json.NewDecoder(input).Decode(&reminder); err != nil {
+		return fmt.Errorf("failed to parse reminder: %w", err)
 	}
 
-	logging.Debug("Session name: %s, git branch: %s, folder: %s", sessionName, gitBranch, folderName)
+	logging.Debug("=== Reminder triggered: session=%s, status=%s, attempt=%d/%d, escalate=%v ===",
+		reminder.SessionID, reminder.Status, reminder.Attempt, reminder.Total, reminder.Escalate)
 
+	// A hook may have cleared the flag after the daemon checked it
+	sessionState, err := h.stateMgr.Load(reminder.SessionID)
+	if err != nil {
+		logging.Warn("Failed to load session state: %v", err)
+	} else if sessionState == nil || !sessionState.ReminderPending {
+		logging.Debug("Prompt already answered, skipping reminder")
+		return nil
+	}
+
+	h.applyProjectConfig(reminder.CWD)
+
+	status := analyzer.Status(reminder.Status)
 	statusStr := string(status)
 
-	// Send desktop notification (check per-status enabled)
+	if quiet := h.quietHours(status); quiet.Quiet {
+		if h.cfg.GetQuietHoursMode() == config.QuietModeSuppress {
+			return h.skip(OutcomeSuppressed, status, "Reminder suppressed by %s", quiet.Reason())
+		}
+		h.mutedBy = "muted by " + quiet.Reason()
+	}
+
+	message := fmt.Sprintf("Reminder %d/%d: %s", reminder.Attempt, reminder.Total, reminder.Message)
+	desktopMessage, webhookMessage := h.renderMessage(status, message, config.MatchInput{
+		Status:      statusStr,
+		GitBranch:   platform.GetGitBranch(reminder.CWD),
+		Folder:      filepath.Base(reminder.CWD),
+		CWD:         reminder.CWD,
+		SessionID:   reminder.SessionID,
+		SessionName: sessionname.GenerateSessionLabel(reminder.SessionID),
+	}, summary.TurnDetails{})
+	h.message = message
+	h.outcome = Outcome{Kind: OutcomeSent, Status: status, Reason: h.mutedBy}
+
 	if h.cfg.IsStatusDesktopEnabled(statusStr) {
-		if err := h.notifierSvc.SendDesktop(status, enhancedMessage, sessionID, cwd); err != nil {
-			errorhandler.HandleError(err, "Failed to send desktop notification")
+		h.notifierSvc.SetQuiet(h.mutedBy != "")
+		err := h.notifierSvc.SendDesktop(status, desktopMessage, reminder.SessionID, reminder.CWD)
+		if err != nil {
+			errorhandler.HandleError(err, "Failed to send desktop reminder")
 		}
+		h.recordChannel(channelDesktop, history.ChannelSent, err)
 	} else {
-		logging.Debug("Desktop notification disabled for status: %s", statusStr)
+		h.recordChannel(channelDesktop, history.ChannelDisabled, nil)
 	}
 
-	// Send webhook notification (async, check per-status enabled)
-	if h.cfg.IsStatusWebhookEnabled(statusStr) {
-		h.webhookSvc.SendAsync(status, enhancedMessage, sessionID)
+	if h.cfg.IsStatusExecEnabled(statusStr) {
+		h.execSvc.SendAsync(status, message, reminder.SessionID, reminder.CWD)
+		h.recordChannel(channelExec, "", nil)
 	} else {
-		logging.Debug("Webhook notification disabled for status: %s", statusStr)
+		h.recordChannel(channelExec, history.ChannelDisabled, nil)
+	}
+
+	// Reminders only go to the webhook once they escalate
+	if reminder.Escalate && h.cfg.IsStatusWebhookEnabled(statusStr) {
+		h.webhookSvc.SendAsyncTo(nil, status, webhookMessage, reminder.SessionID, reminder.CWD)
+		h.recordChannel(channelWebhook, "", nil)
+	} else {
+		h.recordChannel(channelWebhook, history.ChannelDisabled, nil)
+	}
+
+	return nil
+}
+
+// applyProjectConfig makes the user config, deep-merged with the project config files
+// for cwd, the effective config. Invalid project configs are ignored with a warning.
+func (h *Handler) applyProjectConfig(cwd string) {
+	h.useConfig(h.projectConfig(cwd))
+}
+
+// projectConfig returns the user config deep-merged with the project config files found
+// from cwd, or the user config when there are none or they are invalid
+func (h *Handler) projectConfig(cwd string) *config.Config {
+	if h.globalCfg == nil {
+		h.globalCfg = h.cfg
+	}
+
+	cfg, paths, err := h.globalCfg.WithProjectConfig(cwd)
+	if err != nil {
+		logging.Warn("Ignoring project config: %v", err)
+		return h.globalCfg
+	}
+	if len(paths) > 0 {
+		if err := cfg.Validate(); err != nil {
+			logging.Warn("Ignoring invalid project config %v: %v", paths, err)
+			return h.globalCfg
+		}
+		logging.Debug("Project config applied: %v", paths)
+	}
+	return cfg
+}
+
+// useConfig switches the effective config. Services built by NewHandler captured the
+// old config, so they are closed and rebuilt; injected services (tests, replay) are kept.
+func (h *Handler) useConfig(cfg *config.Config) {
+	if cfg == h.cfg {
+		return
+	}
+	if h.ownServices {
+		h.closeServices()
+		h.notifierSvc = notifier.New(cfg)
+		h.webhookSvc = webhook.New(cfg)
+		h.execSvc = execchannel.New(cfg)
+	}
+	h.cfg = cfg
+}
+
+// closeServices releases the delivery services before they are replaced, waiting for
+// sends still in flight
+func (h *Handler) closeServices() {
+	if err := h.notifierSvc.Close(); err != nil {
+		logging.Warn("Failed to close notifier: %v", err)
+	}
+	if err := h.webhookSvc.Shutdown(5 * time.Second); err != nil {
+		logging.Warn("Failed to shutdown webhook sender: %v", err)
+	}
+	if err := h.execSvc.Shutdown(h.cfg.GetExecTimeout() + time.Second); err != nil {
+		logging.Warn("Failed to shutdown exec channel: %v", err)
+	}
+}
+
+// quietHours returns the do-not-disturb state for status: the manual `dnd` override,
+// else the quietHours schedule. Break-through statuses are never quiet.
+func (h *Handler) quietHours(status analyzer.Status) quiethours.State {
+	if h.cfg.IsQuietHoursBreakThrough(string(status)) {
+		return quiethours.State{}
+	}
+
+	var override *quiethours.Override
+	if h.dndPath != "" {
+		o, err := quiethours.LoadOverride(h.dndPath)
+		if err != nil {
+			logging.Warn("Ignoring do-not-disturb override: %v", err)
+		} else {
+			override = o
+		}
+	}
+	return quiethours.Evaluate(h.cfg, override, time.Now())
+}
+
+// isShortTurn returns true if the current turn is shorter than the configured
+// minimum duration for this status. Unknown turn length never suppresses.
+func (h *Handler) isShortTurn(hookData *HookData, status analyzer.Status) bool {
+	minSeconds := h.cfg.GetMinTurnDurationSeconds(string(status))
+	if minSeconds <= 0 {
+		return false
+	}
+
+	duration, ok := h.turnDuration(hookData)
+	if !ok {
+		logging.Debug("Turn duration unknown, not applying minTurnDurationSeconds=%d", minSeconds)
+		return false
+	}
+
+	if duration < time.Duration(minSeconds)*time.Second {
+		logging.Debug("Notification suppressed: turn took %s, below minTurnDurationSeconds=%d for status=%s",
+			duration, minSeconds, status)
+		return true
+	}
+	return false
+}
+
+// turnDuration returns how long the current turn has been running.
+// Prefers the UserPromptSubmit timestamp from session state, falls back to transcript timestamps.
+func (h *Handler) turnDuration(hookData *HookData) (time.Duration, bool) {
+	sessionState, err := h.stateMgr.Load(hookData.SessionID)
+	if err != nil {
+		logging.Warn("Failed to load state for turn duration: %v", err)
+	} else if sessionState != nil && sessionState.LastTurnStartTime > 0 {
+		elapsed := platform.CurrentTimestamp() - sessionState.LastTurnStartTime
+		if elapsed >= 0 {
+			return time.Duration(elapsed) * time.Second, true
+		}
+	}
+
+	if hookData.TranscriptPath != "" && platform.FileExists(hookData.TranscriptPath) {
+		return summary.TurnDurationFromTranscript(hookData.TranscriptPath)
+	}
+	return 0, false
+}
+
+// isInputRequestStatus reports whether status comes from Claude waiting on the user.
+// These share the question cooldowns so a Notification hook does not repeat a PreToolUse/Stop alert.
+func isInputRequestStatus(status analyzer.Status) bool {
+	switch status {
+	case analyzer.StatusQuestion, analyzer.StatusPermissionRequest, analyzer.StatusIdlePrompt:
+		return true
 	}
+	return false
 }
 
 // isSubagentTranscript checks if the transcript path indicates a subagent session.
@@ -423,8 +1163,8 @@ func (h *Handler) cleanupOldLocks() {
 		logging.Warn("Failed to cleanup old locks: %v", err)
 	}
 
-	// Cleanup old state files (older than 60 seconds)
-	if err := h.stateMgr.Cleanup(60); err != nil {
+	// Cleanup stale state files (abandoned sessions that never sent SessionEnd)
+	if err := h.stateMgr.Cleanup(staleStateMaxAge); err != nil {
 		logging.Warn("Failed to cleanup old state files: %v", err)
 	}
 }
@@ -3,6 +3,7 @@ package hooks
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -15,7 +16,12 @@ import (
 	"github.com/777genius/claude-notifications/internal/analyzer"
 	"github.com/777genius/claude-notifications/internal/config"
 	"github.com/777genius/claude-notifications/internal/dedup"
+	"github.com/777genius/claude-notifications/internal/execchannel"
+	"github.com/777genius/claude-notifications/internal/history"
+	"github.com/777genius/claude-notifications/internal/notifier"
+	"github.com/777genius/claude-notifications/internal/sessionname"
 	"github.com/777genius/claude-notifications/internal/state"
+	"github.com/777genius/claude-notifications/internal/webhook"
 	"github.com/777genius/claude-notifications/pkg/jsonl"
 )
 
@@ -35,22 +41,33 @@ type mockNotifier struct {
 	mu         sync.Mutex
 	calls      []notificationCall
 	shouldFail bool
+	quiet      bool
+	sound      *string
+	closed     int
 }
 
 type notificationCall struct {
-	status  analyzer.Status
-	message string
-	cwd     string
+	status   analyzer.Status
+	title    string
+	subtitle string
+	message  string // Body
+	cwd      string
+	quiet    bool
+	sound    *string
 }
 
-func (m *mockNotifier) SendDesktop(status analyzer.Status, message, sessionID, cwd string) error {
+func (m *mockNotifier) SendDesktop(status analyzer.Status, msg notifier.Message, sessionID, cwd string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.calls = append(m.calls, notificationCall{
-		status:  status,
-		message: message,
-		cwd:     cwd,
+		status:   status,
+		title:    msg.Title,
+		subtitle: msg.Subtitle,
+		message:  msg.Body,
+		cwd:      cwd,
+		quiet:    m.quiet,
+		sound:    m.sound,
 	})
 
 	if m.shouldFail {
@@ -59,7 +76,22 @@ func (m *mockNotifier) SendDesktop(status analyzer.Status, message, sessionID, c
 	return nil
 }
 
+func (m *mockNotifier) SetQuiet(quiet bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quiet = quiet
+}
+
+func (m *mockNotifier) SetSound(sound *string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sound = sound
+}
+
 func (m *mockNotifier) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed++
 	return nil
 }
 
@@ -91,19 +123,22 @@ type mockWebhook struct {
 	calls           []webhookCall
 	shutdownCalled  bool
 	shutdownTimeout time.Duration
+	targetStats     map[string]webhook.Stats // Overrides the single "default" target
 }
 
 type webhookCall struct {
+	targets   []string
 	status    analyzer.Status
 	message   string
 	sessionID string
 }
 
-func (m *mockWebhook) SendAsync(status analyzer.Status, message, sessionID string) {
+func (m *mockWebhook) SendAsyncTo(targets []string, status analyzer.Status, message, sessionID, cwd string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.calls = append(m.calls, webhookCall{
+		targets:   targets,
 		status:    status,
 		message:   message,
 		sessionID: sessionID,
@@ -118,8 +153,19 @@ func (m *mockWebhook) Shutdown(timeout time.Duration) error {
 	return nil
 }
 
+func (m *mockWebhook) TargetMetrics() map[string]webhook.Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.targetStats != nil {
+		return m.targetStats
+	}
+	return map[string]webhook.Stats{
+		"default": {TotalRequests: int64(len(m.calls)), SuccessfulRequests: int64(len(m.calls))},
+	}
+}
+
 func (m *mockWebhook) Send(status analyzer.Status, message, sessionID string) error {
-	m.SendAsync(status, message, sessionID)
+	m.SendAsyncTo(nil, status, message, sessionID, "")
 	return nil
 }
 
@@ -141,8 +187,121 @@ func (m *mockWebhook) getShutdownTimeout() time.Duration {
 	return m.shutdownTimeout
 }
 
+// mockExec implements execInterface for testing
+type mockExec struct {
+	mu    sync.Mutex
+	calls []execCall
+}
+
+type execCall struct {
+	status    analyzer.Status
+	message   string
+	sessionID string
+	cwd       string
+}
+
+func (m *mockExec) SendAsync(status analyzer.Status, message, sessionID, cwd string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, execCall{status: status, message: message, sessionID: sessionID, cwd: cwd})
+}
+
+func (m *mockExec) Shutdown(timeout time.Duration) error {
+	return nil
+}
+
+func (m *mockExec) GetStats() execchannel.Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return execchannel.Stats{Succeeded: len(m.calls)}
+}
+
+func (m *mockExec) getCalls() []execCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]execCall(nil), m.calls...)
+}
+
 // === Test Helpers ===
 
+// mockReminders implements reminderInterface for testing
+type mockReminders struct {
+	mu        sync.Mutex
+	scheduled []notifier.ReminderRequest
+	cancelled []string
+}
+
+func (m *mockReminders) Schedule(req notifier.ReminderRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scheduled = append(m.scheduled, req)
+	return nil
+}
+
+func (m *mockReminders) Cancel(sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cancelled = append(m.cancelled, sessionID)
+	return nil
+}
+
+// mockEvents implements eventInterface for testing
+type mockEvents struct {
+	mu        sync.Mutex
+	published []notifier.Event
+}
+
+func (m *mockEvents) Publish(ev notifier.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.published = append(m.published, ev)
+	return nil
+}
+
+// mockClaims stands in for the daemon's dedup state; err makes every call fail
+type mockClaims struct {
+	mu        sync.Mutex
+	claims    []notifier.NotificationClaim
+	forgotten []string
+	result    notifier.ClaimResult
+	err       error
+}
+
+func (m *mockClaims) CheckDuplicate(sessionID, hookEvent string) (notifier.ClaimResult, error) {
+	return notifier.ClaimResult{}, m.err
+}
+
+func (m *mockClaims) Claim(claim notifier.NotificationClaim) (notifier.ClaimResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.claims = append(m.claims, claim)
+	return m.result, m.err
+}
+
+func (m *mockClaims) Forget(sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.forgotten = append(m.forgotten, sessionID)
+	return m.err
+}
+
+// mockAggregate stands in for the daemon's aggregation window; err makes Queue fail
+type mockAggregate struct {
+	mu     sync.Mutex
+	queued []notifier.AggregateRequest
+	err    error
+}
+
+func (m *mockAggregate) Queue(req notifier.AggregateRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.queued = append(m.queued, req)
+	return nil
+}
+
 func buildHookDataJSON(data HookData) io.Reader {
 	b, _ := json.Marshal(data)
 	return strings.NewReader(string(b))
@@ -243,6 +402,9 @@ func newTestHandler(t *testing.T, cfg *config.Config) (*Handler, *mockNotifier,
 		stateMgr:    state.NewManager(),
 		notifierSvc: mockNotif,
 		webhookSvc:  mockWH,
+		execSvc:     &mockExec{},
+		reminderSvc: &mockReminders{},
+		eventSvc:    &mockEvents{},
 		pluginRoot:  t.TempDir(),
 	}
 
@@ -450,6 +612,83 @@ func TestHandler_Notification_SuppressedAfterExitPlanMode(t *testing.T) {
 	}
 }
 
+func TestHandler_Notification_PermissionPrompt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID:        "test-notif-permission",
+		CWD:              "/test",
+		Message:          "Claude needs your permission to use Bash",
+		NotificationType: "permission_prompt",
+	})
+
+	if err := handler.HandleHook("Notification", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected notification to be sent")
+	}
+	if call.status != analyzer.StatusPermissionRequest {
+		t.Errorf("got status %v, want StatusPermissionRequest", call.status)
+	}
+	if !strings.HasSuffix(call.message, "Claude needs your permission to use Bash") {
+		t.Errorf("expected permission message as body, got %q", call.message)
+	}
+}
+
+func TestHandler_Notification_IdlePrompt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID:        "test-notif-idle",
+		CWD:              "/test",
+		Message:          "Claude is waiting for your input",
+		NotificationType: "idle_prompt",
+	})
+
+	if err := handler.HandleHook("Notification", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected notification to be sent")
+	}
+	if call.status != analyzer.StatusIdlePrompt {
+		t.Errorf("got status %v, want StatusIdlePrompt", call.status)
+	}
+}
+
+func TestHandler_Notification_PermissionSuppressedAfterAnyNotification(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.SuppressQuestionAfterAnyNotificationSeconds = intPtr(60)
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	sessionID := "test-notif-permission-cooldown"
+	if err := handler.stateMgr.UpdateLastNotification(sessionID, analyzer.StatusPlanReady, "plan"); err != nil {
+		t.Fatalf("failed to seed state: %v", err)
+	}
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID:        sessionID,
+		CWD:              "/test",
+		Message:          "Claude needs your permission to use ExitPlanMode",
+		NotificationType: "permission_prompt",
+	})
+
+	if err := handler.HandleHook("Notification", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mockNotif.wasCalled() {
+		t.Error("permission prompt should share the question cooldown")
+	}
+}
+
 // === Deduplication Tests ===
 
 func TestHandler_EarlyDuplicateCheck(t *testing.T) {
@@ -501,6 +740,275 @@ func TestHandler_EarlyDuplicateCheck(t *testing.T) {
 	}
 }
 
+func TestHandler_DaemonClaims(t *testing.T) {
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 300))
+	lockPath := func(sessionID string) string {
+		return filepath.Join(os.TempDir(), "claude-notification-"+sessionID+"-Stop.lock")
+	}
+
+	tests := []struct {
+		name     string
+		claims   *mockClaims
+		wantKind OutcomeKind
+		wantLock bool // Fell back to the lock files
+	}{
+		{"allowed", &mockClaims{result: notifier.ClaimResult{Allowed: true}}, OutcomeSent, false},
+		{"duplicate", &mockClaims{result: notifier.ClaimResult{Duplicate: true, Reason: "duplicate"}}, OutcomeDeduplicated, false},
+		{"cooldown", &mockClaims{result: notifier.ClaimResult{Reason: "cooldown"}}, OutcomeSuppressed, false},
+		{"daemon unavailable", &mockClaims{err: errors.New("daemon not available")}, OutcomeSent, true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockNotif, _ := newTestHandler(t, config.DefaultConfig())
+			handler.claimSvc = tt.claims
+			sessionID := fmt.Sprintf("test-daemon-claims-%d", i)
+
+			if err := handler.HandleHook("Stop", buildHookDataJSON(HookData{SessionID: sessionID, TranscriptPath: transcriptPath, CWD: "/test"})); err != nil {
+				t.Fatalf("HandleHook error: %v", err)
+			}
+
+			if handler.outcome.Kind != tt.wantKind {
+				t.Errorf("outcome = %+v, want %s", handler.outcome, tt.wantKind)
+			}
+			if sent := mockNotif.wasCalled(); sent != (tt.wantKind == OutcomeSent) {
+				t.Errorf("notification sent = %v, want outcome %s", sent, tt.wantKind)
+			}
+			if len(tt.claims.claims) != 1 {
+				t.Fatalf("got %d claims, want 1", len(tt.claims.claims))
+			}
+			claim := tt.claims.claims[0]
+			if claim.SessionID != sessionID || claim.HookEvent != "Stop" || !claim.TaskComplete || claim.InputRequest ||
+				claim.Message == "" || claim.DuplicateWindow != duplicateMessageWindow {
+				t.Errorf("claim = %+v", claim)
+			}
+			if _, err := os.Stat(lockPath(sessionID)); (err == nil) != tt.wantLock {
+				t.Errorf("lock file exists = %v, want %v", err == nil, tt.wantLock)
+			}
+		})
+	}
+}
+
+func TestHandler_SessionEndForgetsDaemonClaims(t *testing.T) {
+	handler, _, _ := newTestHandler(t, config.DefaultConfig())
+	claims := &mockClaims{}
+	handler.claimSvc = claims
+
+	if err := handler.HandleHook("SessionEnd", buildHookDataJSON(HookData{SessionID: "test-daemon-forget"})); err != nil {
+		t.Fatalf("HandleHook error: %v", err)
+	}
+
+	if len(claims.forgotten) != 1 || claims.forgotten[0] != "test-daemon-forget" {
+		t.Errorf("forgotten = %v, want the ended session", claims.forgotten)
+	}
+}
+
+func TestHandler_AggregateQueuesDesktopAndWebhook(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Webhook.Enabled = true
+	cfg.Notifications.Webhook.URL = "https://example.com/hook"
+	cfg.Notifications.Aggregate = config.AggregateConfig{Enabled: true, Window: "5s"}
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+	aggregate := &mockAggregate{}
+	handler.aggregateSvc = aggregate
+
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 300))
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{SessionID: "test-aggregate-queue", TranscriptPath: transcriptPath, CWD: "/test/project"}))
+	if err != nil {
+		t.Fatalf("HandleHook error: %v", err)
+	}
+
+	if mockNotif.wasCalled() || mockWH.wasCalled() {
+		t.Error("desktop and webhook should wait for the aggregation window")
+	}
+	if len(aggregate.queued) != 1 {
+		t.Fatalf("got %d queued notifications, want 1", len(aggregate.queued))
+	}
+	req := aggregate.queued[0]
+	if req.SessionID != "test-aggregate-queue" || req.Status != string(analyzer.StatusTaskComplete) || req.CWD != "/test/project" {
+		t.Errorf("queued %+v", req)
+	}
+	if !req.Desktop || !req.Webhook || req.Window != 5*time.Second || req.Rendered.Body == "" || req.WebhookText == "" {
+		t.Errorf("queued %+v", req)
+	}
+	for _, c := range handler.channels {
+		if (c.Name == channelDesktop || c.Name == channelWebhook) && c.Outcome != history.ChannelQueued {
+			t.Errorf("channel %s = %q, want %q", c.Name, c.Outcome, history.ChannelQueued)
+		}
+	}
+	if handler.outcome.Kind != OutcomeQueued {
+		t.Errorf("outcome = %v, want %v until deliver sends it", handler.outcome.Kind, OutcomeQueued)
+	}
+}
+
+func TestHandler_AggregateUnavailableSendsRightAway(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Aggregate = config.AggregateConfig{Enabled: true}
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+	handler.aggregateSvc = &mockAggregate{err: notifier.ErrAggregateUnsupported}
+
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 300))
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{SessionID: "test-aggregate-fallback", TranscriptPath: transcriptPath, CWD: "/test"}))
+	if err != nil {
+		t.Fatalf("HandleHook error: %v", err)
+	}
+
+	if !mockNotif.wasCalled() {
+		t.Error("expected the notification to be sent right away")
+	}
+}
+
+func TestHandler_AggregateSkipsOtherStatuses(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Aggregate = config.AggregateConfig{Enabled: true, Statuses: []string{"question"}}
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+	aggregate := &mockAggregate{}
+	handler.aggregateSvc = aggregate
+
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 300))
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{SessionID: "test-aggregate-status", TranscriptPath: transcriptPath, CWD: "/test"}))
+	if err != nil {
+		t.Fatalf("HandleHook error: %v", err)
+	}
+
+	if len(aggregate.queued) != 0 || !mockNotif.wasCalled() {
+		t.Errorf("task_complete should not wait for the window (queued %d)", len(aggregate.queued))
+	}
+}
+
+func buildAggregateJSON(batch []AggregatedNotification) io.Reader {
+	b, _ := json.Marshal(batch)
+	return strings.NewReader(string(b))
+}
+
+func TestHandler_HandleAggregate_MergesBatch(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+	questionSound := "/sounds/question.wav"
+
+	err := handler.HandleAggregate(buildAggregateJSON([]AggregatedNotification{
+		{SessionID: "session-a", Status: "task_complete", Message: "Created factorial function", Body: "a", CWD: "/work/api", Desktop: true, Webhook: true, Quiet: true},
+		{SessionID: "session-b", Status: "question", Message: "Which database?", Body: "b", CWD: "/work/web", Desktop: true, Webhook: true, Sound: &questionSound},
+		{SessionID: "session-c", Status: "task_complete", Message: "Fixed the tests", Body: "c", CWD: "/work/cli", Desktop: true, Webhook: true, Quiet: true},
+	}))
+	if err != nil {
+		t.Fatalf("HandleAggregate error: %v", err)
+	}
+
+	if mockNotif.callCount() != 1 {
+		t.Fatalf("got %d desktop notifications, want 1", mockNotif.callCount())
+	}
+	call := mockNotif.lastCall()
+	if call.status != analyzer.StatusQuestion {
+		t.Errorf("status = %v, want the most important one (question)", call.status)
+	}
+	if call.sound == nil || *call.sound != questionSound {
+		t.Errorf("sound = %v, want the question's sound", call.sound)
+	}
+	if call.quiet {
+		t.Error("merged notification should only be quiet when every notification is")
+	}
+	if call.cwd != "/work/web" {
+		t.Errorf("cwd = %q, want the question's session for click-to-focus", call.cwd)
+	}
+
+	names := []string{
+		sessionname.GenerateSessionLabel("session-a"),
+		sessionname.GenerateSessionLabel("session-b"),
+		sessionname.GenerateSessionLabel("session-c"),
+	}
+	wantTitle := "3 sessions need attention: " + strings.Join(names, ", ")
+	if call.title != wantTitle {
+		t.Errorf("title = %q, want %q", call.title, wantTitle)
+	}
+	for _, want := range []string{
+		names[0] + " (api): Created factorial function",
+		names[1] + " (web): Which database?",
+		names[2] + " (cli): Fixed the tests",
+	} {
+		if !strings.Contains(call.message, want) {
+			t.Errorf("body %q should contain %q", call.message, want)
+		}
+	}
+
+	mockWH.mu.Lock()
+	defer mockWH.mu.Unlock()
+	if len(mockWH.calls) != 1 {
+		t.Fatalf("got %d webhook messages, want 1", len(mockWH.calls))
+	}
+	if !strings.HasPrefix(mockWH.calls[0].message, wantTitle) || !strings.Contains(mockWH.calls[0].message, "Fixed the tests") {
+		t.Errorf("webhook message = %q", mockWH.calls[0].message)
+	}
+}
+
+func TestHandler_HandleAggregate_SingleSendsAsRendered(t *testing.T) {
+	handler, mockNotif, mockWH := newTestHandler(t, config.DefaultConfig())
+
+	err := handler.HandleAggregate(buildAggregateJSON([]AggregatedNotification{
+		{SessionID: "session-a", Status: "task_complete", Title: "Done", Body: "[bold|main api] Created factorial function", Desktop: true},
+	}))
+	if err != nil {
+		t.Fatalf("HandleAggregate error: %v", err)
+	}
+
+	call := mockNotif.lastCall()
+	if call == nil || call.title != "Done" || call.message != "[bold|main api] Created factorial function" {
+		t.Errorf("desktop call = %+v", call)
+	}
+	if mockWH.wasCalled() {
+		t.Error("webhook was not routed for this notification")
+	}
+}
+
+func TestHandler_HandleAggregate_OneSessionTitle(t *testing.T) {
+	handler, mockNotif, _ := newTestHandler(t, config.DefaultConfig())
+
+	err := handler.HandleAggregate(buildAggregateJSON([]AggregatedNotification{
+		{SessionID: "session-a", Status: "task_complete", Message: "First", Desktop: true},
+		{SessionID: "session-a", Status: "review_complete", Message: "Second", Desktop: true},
+	}))
+	if err != nil {
+		t.Fatalf("HandleAggregate error: %v", err)
+	}
+
+	want := "2 notifications from " + sessionname.GenerateSessionLabel("session-a")
+	if call := mockNotif.lastCall(); call == nil || call.title != want {
+		t.Errorf("desktop call = %+v, want title %q", call, want)
+	}
+}
+
+func TestHandler_HandleAggregate_SessionsWithSameName(t *testing.T) {
+	// Find a second session ID whose friendly name collides with the first
+	first := "06ddb8f7-0000-4000-8000-000000000000"
+	var second string
+	for i := 1; second == ""; i++ {
+		id := fmt.Sprintf("%08x-0000-4000-8000-000000000000", i)
+		if id != first && sessionname.GenerateSessionName(id) == sessionname.GenerateSessionName(first) {
+			second = id
+		}
+	}
+
+	handler, mockNotif, _ := newTestHandler(t, config.DefaultConfig())
+	err := handler.HandleAggregate(buildAggregateJSON([]AggregatedNotification{
+		{SessionID: first, Status: "task_complete", Message: "First", Desktop: true},
+		{SessionID: second, Status: "task_complete", Message: "Second", Desktop: true},
+	}))
+	if err != nil {
+		t.Fatalf("HandleAggregate error: %v", err)
+	}
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected a desktop notification")
+	}
+	for _, id := range []string{first, second} {
+		label := sessionname.GenerateSessionLabel(id)
+		if !strings.Contains(call.title, label) || !strings.Contains(call.message, label) {
+			t.Errorf("title %q and body %q should name session %q", call.title, call.message, label)
+		}
+	}
+}
+
 // === Cooldown Tests ===
 
 func TestHandler_QuestionCooldownAfterTaskComplete(t *testing.T) {
@@ -833,177 +1341,650 @@ func TestHandler_Stop_NotSuppressedWhenSuppressForSubagentsDisabled(t *testing.T
 	}
 
 	hookData := buildHookDataJSON(HookData{
-		SessionID:      "test-suppress-subagent-disabled-1",
-		TranscriptPath: transcriptPath,
-		CWD:            "/test",
+		SessionID:      "test-suppress-subagent-disabled-1",
+		TranscriptPath: transcriptPath,
+		CWD:            "/test",
+	})
+
+	err := handler.HandleHook("Stop", hookData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Should send notification (suppressForSubagents disabled)
+	if !mockNotif.wasCalled() {
+		t.Error("expected notification when suppressForSubagents is false")
+	}
+}
+
+func TestHandler_Stop_NotSuppressedForNonSubagentTranscript(t *testing.T) {
+	cfg := &config.Config{
+		Notifications: config.NotificationsConfig{
+			Desktop: config.DesktopConfig{Enabled: true},
+			// SuppressForSubagents: nil = default true
+		},
+		Statuses: map[string]config.StatusInfo{
+			"task_complete": {Title: "Task Complete"},
+		},
+	}
+
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	// Create transcript in a normal (non-subagent) directory
+	transcriptPath := createTempTranscript(t,
+		buildTranscriptWithTools([]string{"Write"}, 300))
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID:      "test-suppress-normal-1",
+		TranscriptPath: transcriptPath,
+		CWD:            "/test",
+	})
+
+	err := handler.HandleHook("Stop", hookData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Should send notification (not a subagent path)
+	if !mockNotif.wasCalled() {
+		t.Error("expected notification for non-subagent transcript")
+	}
+}
+
+func TestHandler_SubagentStop_SuppressedForSubagentTranscript(t *testing.T) {
+	cfg := &config.Config{
+		Notifications: config.NotificationsConfig{
+			Desktop:              config.DesktopConfig{Enabled: true},
+			NotifyOnSubagentStop: true, // Enable SubagentStop notifications
+			// SuppressForSubagents: nil = default true (suppress by path)
+		},
+		Statuses: map[string]config.StatusInfo{
+			"task_complete": {Title: "Task Complete"},
+		},
+	}
+
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	// Create transcript in a subagents directory
+	tmpDir := t.TempDir()
+	subagentDir := filepath.Join(tmpDir, "projects", "subagents", "agent-789")
+	if err := os.MkdirAll(subagentDir, 0755); err != nil {
+		t.Fatalf("failed to create subagent dir: %v", err)
+	}
+
+	transcriptPath := filepath.Join(subagentDir, "transcript.jsonl")
+	messages := buildTranscriptWithTools([]string{"Write"}, 300)
+	data, _ := json.Marshal(messages[0])
+	data2, _ := json.Marshal(messages[1])
+	if err := os.WriteFile(transcriptPath, append(append(data, '\n'), append(data2, '\n')...), 0644); err != nil {
+		t.Fatalf("failed to write transcript: %v", err)
+	}
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID:      "test-suppress-subagent-stop-1",
+		TranscriptPath: transcriptPath,
+		CWD:            "/test",
+	})
+
+	err := handler.HandleHook("SubagentStop", hookData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Should NOT send notification even with notifyOnSubagentStop=true
+	// because suppressForSubagents takes priority for subagent paths
+	if mockNotif.wasCalled() {
+		t.Error("expected NO notification for subagent transcript in SubagentStop (suppressForSubagents default true)")
+	}
+}
+
+func TestIsSubagentTranscript(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected bool
+	}{
+		{
+			name:     "subagent path unix",
+			path:     "/home/user/.claude/projects/foo/subagents/agent-123/transcript.jsonl",
+			expected: true,
+		},
+		{
+			name:     "subagent path macOS",
+			path:     "/Users/dev/.claude/projects/bar/subagents/abc/transcript.jsonl",
+			expected: true,
+		},
+		{
+			name:     "normal transcript path",
+			path:     "/home/user/.claude/projects/foo/transcript.jsonl",
+			expected: false,
+		},
+		{
+			name:     "empty path",
+			path:     "",
+			expected: false,
+		},
+		{
+			name:     "subagents in filename only",
+			path:     "/home/user/subagents-transcript.jsonl",
+			expected: false,
+		},
+		{
+			name:     "subagents as directory segment",
+			path:     "/tmp/claude/subagents/nested/deep/transcript.jsonl",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isSubagentTranscript(tt.path)
+			if result != tt.expected {
+				t.Errorf("isSubagentTranscript(%q) = %v, want %v", tt.path, result, tt.expected)
+			}
+		})
+	}
+}
+
+// === Unknown Hook Event ===
+
+func TestHandler_UnknownHookEvent(t *testing.T) {
+	cfg := &config.Config{
+		Notifications: config.NotificationsConfig{
+			Desktop: config.DesktopConfig{Enabled: true},
+		},
+		Statuses: map[string]config.StatusInfo{
+			"task_complete": {Title: "Task Complete"},
+		},
+	}
+	handler, _, _ := newTestHandler(t, cfg)
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID: "test-session-12",
+		CWD:       "/test",
+	})
+
+	err := handler.HandleHook("UnknownEvent", hookData)
+
+	if err == nil {
+		t.Fatal("expected error for unknown hook event")
+	}
+
+	if !strings.Contains(err.Error(), "unknown hook event") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+// === Lifecycle Events ===
+
+func TestHandler_UserPromptSubmit_RecordsTurnStart(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+
+	sessionID := "test-prompt-submit"
+	hookData := buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		CWD:       "/test",
+		Prompt:    "fix the bug",
+	})
+
+	if err := handler.HandleHook("UserPromptSubmit", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mockNotif.wasCalled() || mockWH.wasCalled() {
+		t.Error("UserPromptSubmit should not send notifications")
+	}
+
+	st, err := handler.stateMgr.Load(sessionID)
+	if err != nil || st == nil {
+		t.Fatalf("expected state to be saved, err=%v", err)
+	}
+	if st.LastTurnStartTime == 0 {
+		t.Error("expected LastTurnStartTime to be set")
+	}
+}
+
+func TestHandler_SessionStart_RegistersSession(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	sessionID := "test-session-start"
+	hookData := buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		CWD:       "/test",
+		Source:    "startup",
+	})
+
+	if err := handler.HandleHook("SessionStart", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mockNotif.wasCalled() {
+		t.Error("SessionStart should not send notifications")
+	}
+
+	st, err := handler.stateMgr.Load(sessionID)
+	if err != nil || st == nil {
+		t.Fatalf("expected state to be saved, err=%v", err)
+	}
+	if st.SessionStartTime == 0 {
+		t.Error("expected SessionStartTime to be set")
+	}
+	if st.SessionSource != "startup" {
+		t.Errorf("got source %q, want %q", st.SessionSource, "startup")
+	}
+}
+
+func TestHandler_SessionEnd_CleansUp(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, _, _ := newTestHandler(t, cfg)
+
+	sessionID := "test-session-end"
+	if err := handler.stateMgr.UpdateTurnStart(sessionID, "/test"); err != nil {
+		t.Fatalf("failed to seed state: %v", err)
+	}
+	if _, err := handler.dedupMgr.AcquireLock(sessionID, "Stop"); err != nil {
+		t.Fatalf("failed to seed lock: %v", err)
+	}
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		CWD:       "/test",
+		Reason:    "logout",
+	})
+
+	if err := handler.HandleHook("SessionEnd", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	st, err := handler.stateMgr.Load(sessionID)
+	if err != nil {
+		t.Fatalf("unexpected error loading state: %v", err)
+	}
+	if st != nil {
+		t.Error("expected session state to be deleted")
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(os.TempDir(), "claude-notification-"+sessionID+"*.lock"))
+	if len(matches) != 0 {
+		t.Errorf("expected session locks to be removed, found %v", matches)
+	}
+}
+
+func TestHandler_PostToolUse_ClearsInteractiveTool(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	sessionID := "test-post-tool-use"
+	if err := handler.stateMgr.UpdateInteractiveTool(sessionID, "ExitPlanMode", "/test"); err != nil {
+		t.Fatalf("failed to seed state: %v", err)
+	}
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		ToolName:  "ExitPlanMode",
+		CWD:       "/test",
+	})
+
+	if err := handler.HandleHook("PostToolUse", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mockNotif.wasCalled() {
+		t.Error("PostToolUse should not send notifications")
+	}
+
+	st, err := handler.stateMgr.Load(sessionID)
+	if err != nil || st == nil {
+		t.Fatalf("expected state to exist, err=%v", err)
+	}
+	if st.LastInteractiveTool != "" {
+		t.Errorf("expected interactive tool to be cleared, got %q", st.LastInteractiveTool)
+	}
+}
+
+func TestHandler_PreCompact_DisabledByDefault(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID: "test-precompact-disabled",
+		CWD:       "/test",
+		Trigger:   "auto",
+	})
+
+	if err := handler.HandleHook("PreCompact", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mockNotif.wasCalled() {
+		t.Error("PreCompact should not notify unless notifyOnPreCompact is enabled")
+	}
+}
+
+func TestHandler_PreCompact_EnabledInConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.NotifyOnPreCompact = true
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID: "test-precompact-enabled",
+		CWD:       "/test",
+		Trigger:   "manual",
+	})
+
+	if err := handler.HandleHook("PreCompact", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected notification to be sent")
+	}
+	if call.status != analyzer.StatusContextCompacting {
+		t.Errorf("got status %v, want StatusContextCompacting", call.status)
+	}
+	if !strings.Contains(call.message, "/compact") {
+		t.Errorf("expected manual compact message, got %q", call.message)
+	}
+}
+
+// === Minimum Turn Duration ===
+
+func TestHandler_MinTurnDuration_ShortTurnSuppressed(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.MinTurnDurationSeconds = intPtr(30)
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	// Transcript fallback: user at 12:00:00, assistant at 12:00:01
+	transcriptPath := createTempTranscript(t,
+		buildTranscriptWithTools([]string{"Edit", "Write"}, 300))
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID:      "test-min-turn-short",
+		TranscriptPath: transcriptPath,
+		CWD:            "/test",
+	})
+
+	if err := handler.HandleHook("Stop", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mockNotif.wasCalled() {
+		t.Error("expected notification for 1s turn to be suppressed")
+	}
+}
+
+func TestHandler_MinTurnDuration_LongTurnFromStateNotifies(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.MinTurnDurationSeconds = intPtr(30)
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	sessionID := "test-min-turn-long"
+	err := handler.stateMgr.Save(&state.SessionState{
+		SessionID:         sessionID,
+		LastTurnStartTime: time.Now().Add(-2 * time.Minute).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("failed to seed state: %v", err)
+	}
+
+	transcriptPath := createTempTranscript(t,
+		buildTranscriptWithTools([]string{"Edit", "Write"}, 300))
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID:      sessionID,
+		TranscriptPath: transcriptPath,
+		CWD:            "/test",
+	})
+
+	if err := handler.HandleHook("Stop", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !mockNotif.wasCalled() {
+		t.Error("expected notification for 2m turn (turn start from session state)")
+	}
+}
+
+func TestHandler_MinTurnDuration_QuestionExempt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.MinTurnDurationSeconds = intPtr(30)
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	sessionID := "test-min-turn-question"
+	if err := handler.stateMgr.UpdateTurnStart(sessionID, "/test"); err != nil {
+		t.Fatalf("failed to seed state: %v", err)
+	}
+
+	hookData := buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		ToolName:  "AskUserQuestion",
+		CWD:       "/test",
+	})
+
+	if err := handler.HandleHook("PreToolUse", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !mockNotif.wasCalled() {
+		t.Error("question should be exempt from minTurnDurationSeconds by default")
+	}
+}
+
+// === Reminders ===
+
+func TestHandler_Reminders_ScheduledForQuestion(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Reminders = config.RemindersConfig{
+		Enabled:                true,
+		Backoff:                []string{"5m", "15m"},
+		EscalateToWebhookAfter: 2,
+	}
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+	reminders := handler.reminderSvc.(*mockReminders)
+
+	sessionID := "test-reminder-question"
+	hookData := buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		ToolName:  "AskUserQuestion",
+		CWD:       "/test",
 	})
 
-	err := handler.HandleHook("Stop", hookData)
-	if err != nil {
+	if err := handler.HandleHook("PreToolUse", hookData); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Should send notification (suppressForSubagents disabled)
 	if !mockNotif.wasCalled() {
-		t.Error("expected notification when suppressForSubagents is false")
+		t.Fatal("expected notification to be sent")
 	}
-}
-
-func TestHandler_Stop_NotSuppressedForNonSubagentTranscript(t *testing.T) {
-	cfg := &config.Config{
-		Notifications: config.NotificationsConfig{
-			Desktop: config.DesktopConfig{Enabled: true},
-			// SuppressForSubagents: nil = default true
-		},
-		Statuses: map[string]config.StatusInfo{
-			"task_complete": {Title: "Task Complete"},
-		},
+	if len(reminders.scheduled) != 1 {
+		t.Fatalf("expected 1 reminder schedule, got %d", len(reminders.scheduled))
+	}
+	req := reminders.scheduled[0]
+	if req.SessionID != sessionID || req.Status != string(analyzer.StatusQuestion) {
+		t.Errorf("unexpected reminder request: %+v", req)
+	}
+	if len(req.Backoff) != 2 || req.Backoff[0] != 5*time.Minute || req.Backoff[1] != 15*time.Minute {
+		t.Errorf("got backoff %v, want [5m 15m]", req.Backoff)
+	}
+	if req.EscalateAfter != 2 {
+		t.Errorf("got escalateAfter %d, want 2", req.EscalateAfter)
 	}
 
-	handler, mockNotif, _ := newTestHandler(t, cfg)
+	st, err := handler.stateMgr.Load(sessionID)
+	if err != nil || st == nil {
+		t.Fatalf("expected state, err=%v", err)
+	}
+	if !st.ReminderPending {
+		t.Error("expected reminder to be pending in session state")
+	}
+}
 
-	// Create transcript in a normal (non-subagent) directory
-	transcriptPath := createTempTranscript(t,
-		buildTranscriptWithTools([]string{"Write"}, 300))
+func TestHandler_Reminders_NotScheduledWhenDisabled(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, _, _ := newTestHandler(t, cfg)
+	reminders := handler.reminderSvc.(*mockReminders)
 
 	hookData := buildHookDataJSON(HookData{
-		SessionID:      "test-suppress-normal-1",
-		TranscriptPath: transcriptPath,
-		CWD:            "/test",
+		SessionID: "test-reminder-disabled",
+		ToolName:  "ExitPlanMode",
+		CWD:       "/test",
 	})
 
-	err := handler.HandleHook("Stop", hookData)
-	if err != nil {
+	if err := handler.HandleHook("PreToolUse", hookData); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Should send notification (not a subagent path)
-	if !mockNotif.wasCalled() {
-		t.Error("expected notification for non-subagent transcript")
+	if len(reminders.scheduled) != 0 {
+		t.Errorf("expected no reminders when disabled, got %d", len(reminders.scheduled))
 	}
 }
 
-func TestHandler_SubagentStop_SuppressedForSubagentTranscript(t *testing.T) {
-	cfg := &config.Config{
-		Notifications: config.NotificationsConfig{
-			Desktop:              config.DesktopConfig{Enabled: true},
-			NotifyOnSubagentStop: true, // Enable SubagentStop notifications
-			// SuppressForSubagents: nil = default true (suppress by path)
-		},
-		Statuses: map[string]config.StatusInfo{
-			"task_complete": {Title: "Task Complete"},
-		},
+func TestHandler_Reminders_CancelledOnUserPrompt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Reminders.Enabled = true
+	handler, _, _ := newTestHandler(t, cfg)
+	reminders := handler.reminderSvc.(*mockReminders)
+
+	sessionID := "test-reminder-cancel"
+	if err := handler.stateMgr.SetReminderPending(sessionID, analyzer.StatusPlanReady, time.Now().Unix()); err != nil {
+		t.Fatalf("failed to seed state: %v", err)
 	}
 
-	handler, mockNotif, _ := newTestHandler(t, cfg)
+	hookData := buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		CWD:       "/test",
+		Prompt:    "looks good",
+	})
 
-	// Create transcript in a subagents directory
-	tmpDir := t.TempDir()
-	subagentDir := filepath.Join(tmpDir, "projects", "subagents", "agent-789")
-	if err := os.MkdirAll(subagentDir, 0755); err != nil {
-		t.Fatalf("failed to create subagent dir: %v", err)
+	if err := handler.HandleHook("UserPromptSubmit", hookData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
-	transcriptPath := filepath.Join(subagentDir, "transcript.jsonl")
-	messages := buildTranscriptWithTools([]string{"Write"}, 300)
-	data, _ := json.Marshal(messages[0])
-	data2, _ := json.Marshal(messages[1])
-	if err := os.WriteFile(transcriptPath, append(append(data, '\n'), append(data2, '\n')...), 0644); err != nil {
-		t.Fatalf("failed to write transcript: %v", err)
+	st, err := handler.stateMgr.Load(sessionID)
+	if err != nil || st == nil {
+		t.Fatalf("expected state, err=%v", err)
+	}
+	if st.ReminderPending {
+		t.Error("expected pending reminder to be cleared")
 	}
+	if len(reminders.cancelled) != 1 || reminders.cancelled[0] != sessionID {
+		t.Errorf("expected daemon reminders to be cancelled, got %v", reminders.cancelled)
+	}
+}
 
-	hookData := buildHookDataJSON(HookData{
-		SessionID:      "test-suppress-subagent-stop-1",
-		TranscriptPath: transcriptPath,
-		CWD:            "/test",
-	})
+func TestHandler_Reminders_CancelledWhenDisabled(t *testing.T) {
+	handler, _, _ := newTestHandler(t, config.DefaultConfig())
+	reminders := handler.reminderSvc.(*mockReminders)
 
-	err := handler.HandleHook("SubagentStop", hookData)
-	if err != nil {
+	// Timers scheduled while reminders were enabled must not outlive the answer
+	hookData := buildHookDataJSON(HookData{SessionID: "test-reminder-cancel-disabled", CWD: "/test", Prompt: "yes"})
+	if err := handler.HandleHook("UserPromptSubmit", hookData); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Should NOT send notification even with notifyOnSubagentStop=true
-	// because suppressForSubagents takes priority for subagent paths
-	if mockNotif.wasCalled() {
-		t.Error("expected NO notification for subagent transcript in SubagentStop (suppressForSubagents default true)")
+	if len(reminders.cancelled) != 1 {
+		t.Errorf("expected daemon reminders to be cancelled, got %v", reminders.cancelled)
 	}
 }
 
-func TestIsSubagentTranscript(t *testing.T) {
-	tests := []struct {
-		name     string
-		path     string
-		expected bool
-	}{
-		{
-			name:     "subagent path unix",
-			path:     "/home/user/.claude/projects/foo/subagents/agent-123/transcript.jsonl",
-			expected: true,
-		},
-		{
-			name:     "subagent path macOS",
-			path:     "/Users/dev/.claude/projects/bar/subagents/abc/transcript.jsonl",
-			expected: true,
-		},
-		{
-			name:     "normal transcript path",
-			path:     "/home/user/.claude/projects/foo/transcript.jsonl",
-			expected: false,
-		},
-		{
-			name:     "empty path",
-			path:     "",
-			expected: false,
-		},
-		{
-			name:     "subagents in filename only",
-			path:     "/home/user/subagents-transcript.jsonl",
-			expected: false,
-		},
-		{
-			name:     "subagents as directory segment",
-			path:     "/tmp/claude/subagents/nested/deep/transcript.jsonl",
-			expected: true,
-		},
+func buildReminderJSON(r Reminder) io.Reader {
+	data, _ := json.Marshal(r)
+	return strings.NewReader(string(data))
+}
+
+func TestHandler_HandleReminder_SendsDesktop(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Webhook.Enabled = true
+	cfg.Notifications.Webhook.URL = "https://example.com/hook"
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+
+	sessionID := "test-reminder-deliver"
+	if err := handler.stateMgr.SetReminderPending(sessionID, analyzer.StatusQuestion, time.Now().Unix()); err != nil {
+		t.Fatalf("failed to seed state: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := isSubagentTranscript(tt.path)
-			if result != tt.expected {
-				t.Errorf("isSubagentTranscript(%q) = %v, want %v", tt.path, result, tt.expected)
-			}
-		})
+	err := handler.HandleReminder(buildReminderJSON(Reminder{
+		SessionID: sessionID,
+		Status:    string(analyzer.StatusQuestion),
+		Message:   "Which database should I use?",
+		CWD:       "/test",
+		Attempt:   1,
+		Total:     3,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected desktop reminder")
+	}
+	if call.status != analyzer.StatusQuestion {
+		t.Errorf("got status %v, want StatusQuestion", call.status)
+	}
+	if !strings.Contains(call.message, "Reminder 1/3: Which database should I use?") {
+		t.Errorf("unexpected reminder message: %q", call.message)
+	}
+	if mockWH.wasCalled() {
+		t.Error("webhook should only be used once the reminder escalates")
+	}
+	if !mockWH.wasShutdownCalled() {
+		t.Error("expected webhook sender to be shut down")
 	}
 }
 
-// === Unknown Hook Event ===
+func TestHandler_HandleReminder_EscalatesToWebhook(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Webhook.Enabled = true
+	cfg.Notifications.Webhook.URL = "https://example.com/hook"
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
 
-func TestHandler_UnknownHookEvent(t *testing.T) {
-	cfg := &config.Config{
-		Notifications: config.NotificationsConfig{
-			Desktop: config.DesktopConfig{Enabled: true},
-		},
-		Statuses: map[string]config.StatusInfo{
-			"task_complete": {Title: "Task Complete"},
-		},
+	sessionID := "test-reminder-escalate"
+	if err := handler.stateMgr.SetReminderPending(sessionID, analyzer.StatusPlanReady, time.Now().Unix()); err != nil {
+		t.Fatalf("failed to seed state: %v", err)
 	}
-	handler, _, _ := newTestHandler(t, cfg)
 
-	hookData := buildHookDataJSON(HookData{
-		SessionID: "test-session-12",
+	err := handler.HandleReminder(buildReminderJSON(Reminder{
+		SessionID: sessionID,
+		Status:    string(analyzer.StatusPlanReady),
+		Message:   "Plan ready",
 		CWD:       "/test",
-	})
+		Attempt:   2,
+		Total:     3,
+		Escalate:  true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
-	err := handler.HandleHook("UnknownEvent", hookData)
+	if !mockNotif.wasCalled() {
+		t.Error("expected desktop reminder")
+	}
+	if !mockWH.wasCalled() {
+		t.Error("expected escalated reminder to be sent via webhook")
+	}
+}
 
-	if err == nil {
-		t.Fatal("expected error for unknown hook event")
+func TestHandler_HandleReminder_SkipsAnswered(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	err := handler.HandleReminder(buildReminderJSON(Reminder{
+		SessionID: "test-reminder-answered",
+		Status:    string(analyzer.StatusQuestion),
+		Message:   "Question",
+		Attempt:   1,
+		Total:     3,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if !strings.Contains(err.Error(), "unknown hook event") {
-		t.Errorf("unexpected error message: %v", err)
+	if mockNotif.wasCalled() {
+		t.Error("reminder without pending prompt should be skipped")
 	}
 }
 
@@ -1932,3 +2913,123 @@ func TestHandler_SuppressFilter_NonMatchingFilter_SendsNotification(t *testing.T
 		t.Error("expected notification when suppress-filter does not match")
 	}
 }
+
+func TestHandler_SuppressFilter_MessageAndCWDPatterns(t *testing.T) {
+	messagePattern := "re:a{20}"
+	cwdPattern := "*/scratch-*"
+
+	cfg := &config.Config{
+		Notifications: config.NotificationsConfig{
+			Desktop: config.DesktopConfig{Enabled: true},
+			SuppressFilters: []config.SuppressFilter{
+				{Name: "scratch dirs", CWD: &cwdPattern, Message: &messagePattern},
+			},
+		},
+		Statuses: map[string]config.StatusInfo{
+			"task_complete": {Title: "Task Complete"},
+		},
+	}
+
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 300))
+
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{
+		SessionID:      "test-filter-pattern-1",
+		TranscriptPath: transcriptPath,
+		CWD:            filepath.Join(t.TempDir(), "scratch-7"),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mockNotif.wasCalled() {
+		t.Error("expected NO notification when cwd and message patterns match")
+	}
+	if outcome := handler.LastOutcome(); outcome.Kind != OutcomeSuppressed || !strings.Contains(outcome.Reason, `filter "scratch dirs"`) {
+		t.Errorf("outcome = %+v, want suppressed by the named filter", outcome)
+	}
+
+	// Same message in another directory is delivered
+	err = handler.HandleHook("Stop", buildHookDataJSON(HookData{
+		SessionID:      "test-filter-pattern-2",
+		TranscriptPath: transcriptPath,
+		CWD:            filepath.Join(t.TempDir(), "app"),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mockNotif.wasCalled() {
+		t.Error("expected notification when the cwd pattern does not match")
+	}
+}
+
+// === Exec Channel Tests ===
+
+func TestHandler_ExecChannel_RunsCommandWithPlainMessage(t *testing.T) {
+	cfg := &config.Config{
+		Notifications: config.NotificationsConfig{
+			Exec: config.ExecConfig{Enabled: true, Command: []string{"led"}},
+		},
+		Statuses: map[string]config.StatusInfo{
+			"task_complete": {Title: "Task Complete"},
+		},
+	}
+
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 50))
+
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{
+		SessionID:      "test-exec-1",
+		TranscriptPath: transcriptPath,
+		CWD:            "/test/project",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := handler.execSvc.(*mockExec).getCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 exec call, got %d", len(calls))
+	}
+	if calls[0].status != analyzer.StatusTaskComplete || calls[0].cwd != "/test/project" {
+		t.Errorf("exec call = %+v, want task_complete in /test/project", calls[0])
+	}
+	if strings.HasPrefix(calls[0].message, "[") {
+		t.Errorf("exec message should not carry the session/folder prefix, got %q", calls[0].message)
+	}
+
+	// Desktop and webhook are disabled, exec alone is enough to notify
+	if mockNotif.wasCalled() || mockWH.wasCalled() {
+		t.Error("expected only the exec channel to be used")
+	}
+}
+
+func TestHandler_ExecChannel_StatusNotSelected(t *testing.T) {
+	cfg := &config.Config{
+		Notifications: config.NotificationsConfig{
+			Desktop: config.DesktopConfig{Enabled: true},
+			Exec:    config.ExecConfig{Enabled: true, Command: []string{"led"}, Statuses: []string{"question"}},
+		},
+		Statuses: map[string]config.StatusInfo{
+			"task_complete": {Title: "Task Complete"},
+		},
+	}
+
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+	transcriptPath := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 50))
+
+	err := handler.HandleHook("Stop", buildHookDataJSON(HookData{
+		SessionID:      "test-exec-2",
+		TranscriptPath: transcriptPath,
+		CWD:            "/test",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !mockNotif.wasCalled() {
+		t.Error("expected desktop notification")
+	}
+	if n := len(handler.execSvc.(*mockExec).getCalls()); n != 0 {
+		t.Errorf("expected no exec call for status outside exec.statuses, got %d", n)
+	}
+}
@@ -0,0 +1,58 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// shippedHooks is the part of hooks/hooks.json that tells Claude Code which events to send
+type shippedHooks struct {
+	Hooks map[string][]struct {
+		Matcher string `json:"matcher"`
+	} `json:"hooks"`
+}
+
+// matchesShipped reports whether Claude Code would call the hook registered for event
+// with value (a tool name or notification type). Matchers are regular expressions;
+// an empty matcher matches everything.
+func matchesShipped(t *testing.T, hooks shippedHooks, event, value string) bool {
+	t.Helper()
+	for _, entry := range hooks.Hooks[event] {
+		if entry.Matcher == "" || entry.Matcher == "*" {
+			return true
+		}
+		re, err := regexp.Compile("^(?:" + entry.Matcher + ")$")
+		if err != nil {
+			t.Fatalf("%s matcher %q is not a valid regexp: %v", event, entry.Matcher, err)
+		}
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShippedHooks_RouteNotificationTypes(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "hooks", "hooks.json"))
+	if err != nil {
+		t.Fatalf("failed to read hooks.json: %v", err)
+	}
+	var hooks shippedHooks
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		t.Fatalf("failed to parse hooks.json: %v", err)
+	}
+
+	for _, notificationType := range []string{"permission_prompt", "idle_prompt"} {
+		if !matchesShipped(t, hooks, "Notification", notificationType) {
+			t.Errorf("hooks.json doesn't route %s notifications to the hook", notificationType)
+		}
+	}
+	for _, tool := range []string{"ExitPlanMode", "AskUserQuestion"} {
+		if !matchesShipped(t, hooks, "PreToolUse", tool) {
+			t.Errorf("hooks.json doesn't route PreToolUse for %s to the hook", tool)
+		}
+	}
+}
@@ -48,6 +48,7 @@ func TestE2E_FullNotificationCycle(t *testing.T) {
 		stateMgr:    newTempStateManager(t),
 		notifierSvc: mockNotif,
 		webhookSvc:  mockWH,
+		execSvc:     &mockExec{},
 		pluginRoot:  pluginRoot,
 	}
 
@@ -247,6 +248,7 @@ func TestE2E_WebhookRetry(t *testing.T) {
 		stateMgr:    newTempStateManager(t),
 		notifierSvc: mockNotif,
 		webhookSvc:  webhook.New(cfg), // Real webhook sender
+		execSvc:     &mockExec{},
 		pluginRoot:  pluginRoot,
 	}
 
@@ -341,6 +343,7 @@ func TestE2E_ConcurrentSessions(t *testing.T) {
 		stateMgr:    newTempStateManager(t),
 		notifierSvc: mockNotif,
 		webhookSvc:  mockWH,
+		execSvc:     &mockExec{},
 		pluginRoot:  pluginRoot,
 	}
 
@@ -579,6 +582,7 @@ func TestE2E_WebhookGracefulShutdown(t *testing.T) {
 		stateMgr:    newTempStateManager(t),
 		notifierSvc: mockNotif,
 		webhookSvc:  webhook.New(cfg), // REAL webhook sender - not mock!
+		execSvc:     &mockExec{},
 		pluginRoot:  pluginRoot,
 	}
 
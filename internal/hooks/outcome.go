@@ -0,0 +1,54 @@
+package hooks
+
+import (
+	"fmt"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/journal"
+	"github.com/777genius/claude-notifications/internal/logging"
+)
+
+// OutcomeKind classifies what HandleHook did with an event
+type OutcomeKind string
+
+const (
+	OutcomeSent         OutcomeKind = "sent"         // Notification passed all checks and was dispatched
+	OutcomeQueued       OutcomeKind = "queued"       // Passed all checks; the daemon's aggregation window delivers it
+	OutcomeSuppressed   OutcomeKind = "suppressed"   // Config, filters, cooldowns or judge mode stopped it
+	OutcomeDeduplicated OutcomeKind = "deduplicated" // Dedup locks or duplicate message content stopped it
+	OutcomeSkipped      OutcomeKind = "skipped"      // Nothing to notify (lifecycle event, unknown status)
+)
+
+// Outcome describes the result of the last HandleHook call
+type Outcome struct {
+	Kind   OutcomeKind
+	Status analyzer.Status
+	Reason string
+}
+
+// LastOutcome returns what the last HandleHook call did
+func (h *Handler) LastOutcome() Outcome {
+	return h.outcome
+}
+
+// skip records why the event did not produce a notification, logs it and returns nil
+func (h *Handler) skip(kind OutcomeKind, status analyzer.Status, format string, args ...interface{}) error {
+	reason := fmt.Sprintf(format, args...)
+	h.outcome = Outcome{Kind: kind, Status: status, Reason: reason}
+	logging.Debug("%s", reason)
+	return nil
+}
+
+// recordJournal writes the raw hook payload to the journal (if enabled)
+func (h *Handler) recordJournal(hookEvent string, payload []byte) {
+	if h.journalDir == "" {
+		return
+	}
+
+	path, err := journal.Write(h.journalDir, journal.New(hookEvent, payload), h.cfg.GetJournalMaxEntries())
+	if err != nil {
+		logging.Warn("Failed to write hook journal: %v", err)
+		return
+	}
+	logging.Debug("Hook payload journaled: %s", path)
+}
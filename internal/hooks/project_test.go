@@ -0,0 +1,134 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/config"
+)
+
+// newProjectDir creates a git repository with the given .claude-notifications.json
+func newProjectDir(t *testing.T, projectConfig string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, config.ProjectConfigFile), []byte(projectConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestHandler_ProjectConfig_Overrides(t *testing.T) {
+	silent := newProjectDir(t, `{"notifications": {"desktop": {"enabled": false}}}`)
+	infra := newProjectDir(t, `{"notifications": {"webhook": {"enabled": true, "url": "https://hooks.example.com/infra"}}}`)
+
+	handler, mockNotif, mockWH := newTestHandler(t, config.DefaultConfig())
+
+	// The scratch repo stays silent
+	sendPlanReadyIn(t, handler, "test-project-silent", silent)
+	if mockNotif.wasCalled() {
+		t.Error("expected no desktop notification in a project that disables desktop")
+	}
+	if mockWH.wasCalled() {
+		t.Error("expected no webhook for a project without webhook config")
+	}
+
+	// The infra repo also pages the webhook; desktop comes from the user config again
+	sendPlanReadyIn(t, handler, "test-project-infra", infra)
+	if !mockNotif.wasCalled() {
+		t.Error("expected a desktop notification from the user config")
+	}
+	if !mockWH.wasCalled() {
+		t.Error("expected the project webhook to be used")
+	}
+	if handler.globalCfg.Notifications.Webhook.Enabled {
+		t.Error("project config must not change the user config")
+	}
+}
+
+func TestHandler_ProjectConfig_InvalidIgnored(t *testing.T) {
+	dir := newProjectDir(t, `{"notifications": {"quietHours": {"mode": "loud"}}}`)
+
+	handler, mockNotif, _ := newTestHandler(t, config.DefaultConfig())
+	sendPlanReadyIn(t, handler, "test-project-invalid", dir)
+
+	if !mockNotif.wasCalled() {
+		t.Error("expected the user config to be used when the project config is invalid")
+	}
+	if handler.cfg != handler.globalCfg {
+		t.Error("invalid project config should not become the effective config")
+	}
+}
+
+func TestHandler_ProjectConfig_ClosesReplacedServices(t *testing.T) {
+	dir := newProjectDir(t, `{"notifications": {"desktop": {"enabled": false}}}`)
+
+	handler, mockNotif, mockWH := newTestHandler(t, config.DefaultConfig())
+	handler.ownServices = true // As built by NewHandler
+	handler.applyProjectConfig(dir)
+	t.Cleanup(handler.closeServices)
+
+	if handler.notifierSvc == notifierInterface(mockNotif) {
+		t.Fatal("expected the services to be rebuilt for the project config")
+	}
+	if mockNotif.closed != 1 {
+		t.Errorf("replaced notifier closed %d times, want 1", mockNotif.closed)
+	}
+	if !mockWH.wasShutdownCalled() {
+		t.Error("expected the replaced webhook sender to be shut down")
+	}
+}
+
+func TestHandler_ProjectConfig_KeepsDaemonSettings(t *testing.T) {
+	dir := newProjectDir(t, `{"notifications": {"daemon": {"stateInMemory": true}}}`)
+
+	handler, _, _ := newTestHandler(t, config.DefaultConfig())
+	handler.applyProjectConfig(dir)
+
+	if handler.cfg.Notifications.Daemon.StateInMemory {
+		t.Error("a project config must not change daemon.stateInMemory, the claim service follows the user config")
+	}
+}
+
+// sendPlanReadyIn runs a PreToolUse(ExitPlanMode) hook in cwd
+func sendPlanReadyIn(t *testing.T, h *Handler, sessionID, cwd string) {
+	t.Helper()
+	err := h.HandleHook("PreToolUse", buildHookDataJSON(HookData{
+		SessionID: sessionID,
+		ToolName:  "ExitPlanMode",
+		CWD:       cwd,
+	}))
+	if err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+}
+
+func TestHandler_ProjectConfig_AppliedOnDelivery(t *testing.T) {
+	infra := newProjectDir(t, `{"notifications": {"webhook": {"enabled": true, "url": "https://hooks.example.com/infra"}}}`)
+	web := newProjectDir(t, `{"notifications": {"webhook": {"enabled": true, "url": "https://hooks.example.com/web"}}}`)
+
+	handler, _, mockWH := newTestHandler(t, config.DefaultConfig())
+	err := handler.HandleAggregate(buildAggregateJSON([]AggregatedNotification{
+		{SessionID: "test-deliver-infra", Status: "task_complete", WebhookText: "infra done", CWD: infra, Webhook: true},
+		{SessionID: "test-deliver-web", Status: "task_complete", WebhookText: "web done", CWD: web, Webhook: true},
+	}))
+	if err != nil {
+		t.Fatalf("HandleAggregate error: %v", err)
+	}
+
+	// Each project has its own webhook, so the notifications can't share a message
+	mockWH.mu.Lock()
+	defer mockWH.mu.Unlock()
+	if len(mockWH.calls) != 2 {
+		t.Fatalf("got %d webhook messages, want one per project", len(mockWH.calls))
+	}
+	if mockWH.calls[0].message != "infra done" || mockWH.calls[1].message != "web done" {
+		t.Errorf("webhook messages = %q, %q", mockWH.calls[0].message, mockWH.calls[1].message)
+	}
+	if handler.cfg.Notifications.Webhook.URL != "https://hooks.example.com/web" {
+		t.Errorf("effective webhook URL = %q, want the last project's", handler.cfg.Notifications.Webhook.URL)
+	}
+}
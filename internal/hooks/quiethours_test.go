@@ -0,0 +1,97 @@
+package hooks
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/quiethours"
+)
+
+// allDayQuietHours returns a config whose quiet hours cover the whole day
+func allDayQuietHours(mode string) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.QuietHours = config.QuietHoursConfig{
+		Enabled: true,
+		Windows: []config.QuietWindow{{Start: "00:00", End: "00:00"}},
+		Mode:    mode,
+	}
+	return cfg
+}
+
+// sendPlanReady runs a PreToolUse(ExitPlanMode) hook, which always produces plan_ready
+func sendPlanReady(t *testing.T, h *Handler, sessionID string) {
+	t.Helper()
+	sendPlanReadyIn(t, h, sessionID, "/tmp")
+}
+
+func TestHandler_QuietHours_MutesDesktop(t *testing.T) {
+	handler, mockNotif, _ := newTestHandler(t, allDayQuietHours(""))
+
+	sendPlanReady(t, handler, "test-quiet-mute")
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected a desktop notification in mute mode")
+	}
+	if !call.quiet {
+		t.Error("expected the notification to be sent quietly")
+	}
+	outcome := handler.LastOutcome()
+	if outcome.Kind != OutcomeSent || !strings.HasPrefix(outcome.Reason, "muted by quiet hours") {
+		t.Errorf("outcome = %+v, want sent and muted by quiet hours", outcome)
+	}
+}
+
+func TestHandler_QuietHours_Suppress(t *testing.T) {
+	handler, mockNotif, _ := newTestHandler(t, allDayQuietHours(config.QuietModeSuppress))
+
+	sendPlanReady(t, handler, "test-quiet-suppress")
+
+	if mockNotif.wasCalled() {
+		t.Error("expected no desktop notification in suppress mode")
+	}
+	if outcome := handler.LastOutcome(); outcome.Kind != OutcomeSuppressed || !strings.Contains(outcome.Reason, "quiet hours") {
+		t.Errorf("outcome = %+v, want suppressed by quiet hours", outcome)
+	}
+}
+
+func TestHandler_QuietHours_BreakThrough(t *testing.T) {
+	cfg := allDayQuietHours(config.QuietModeSuppress)
+	cfg.Notifications.QuietHours.BreakThrough = []string{"plan_ready"}
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	sendPlanReady(t, handler, "test-quiet-breakthrough")
+
+	call := mockNotif.lastCall()
+	if call == nil || call.quiet {
+		t.Errorf("expected a normal notification for a break-through status, got %+v", call)
+	}
+}
+
+func TestHandler_QuietHours_ManualOverride(t *testing.T) {
+	handler, mockNotif, _ := newTestHandler(t, config.DefaultConfig())
+	handler.dndPath = filepath.Join(t.TempDir(), "dnd.json")
+
+	if err := quiethours.SaveOverride(handler.dndPath, quiethours.Override{Quiet: true, SetAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	sendPlanReady(t, handler, "test-quiet-dnd-on")
+
+	if call := mockNotif.lastCall(); call == nil || !call.quiet {
+		t.Errorf("expected a quiet notification while dnd is on, got %+v", call)
+	}
+
+	// An expired override no longer applies
+	expired := quiethours.Override{Quiet: true, Until: time.Now().Add(-time.Minute), SetAt: time.Now().Add(-time.Hour)}
+	if err := quiethours.SaveOverride(handler.dndPath, expired); err != nil {
+		t.Fatal(err)
+	}
+	sendPlanReady(t, handler, "test-quiet-dnd-expired")
+
+	if call := mockNotif.lastCall(); call == nil || call.quiet {
+		t.Errorf("expected a normal notification after dnd expired, got %+v", call)
+	}
+}
@@ -0,0 +1,199 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/dedup"
+	"github.com/777genius/claude-notifications/internal/execchannel"
+	"github.com/777genius/claude-notifications/internal/journal"
+	"github.com/777genius/claude-notifications/internal/notifier"
+	"github.com/777genius/claude-notifications/internal/state"
+	"github.com/777genius/claude-notifications/internal/webhook"
+)
+
+// RecordedNotification is a notification captured during replay instead of being sent
+type RecordedNotification struct {
+	Status    analyzer.Status
+	Title     string // Desktop only
+	Message   string
+	SessionID string
+}
+
+// recordingNotifier captures desktop notifications
+type recordingNotifier struct {
+	sent []RecordedNotification
+}
+
+func (r *recordingNotifier) SendDesktop(status analyzer.Status, msg notifier.Message, sessionID, cwd string) error {
+	r.sent = append(r.sent, RecordedNotification{Status: status, Title: msg.Title, Message: msg.Body, SessionID: sessionID})
+	return nil
+}
+
+func (r *recordingNotifier) SetQuiet(quiet bool) {}
+
+func (r *recordingNotifier) SetSound(sound *string) {}
+
+func (r *recordingNotifier) Close() error {
+	return nil
+}
+
+// recordingWebhook captures webhook notifications
+type recordingWebhook struct {
+	sent []RecordedNotification
+}
+
+func (r *recordingWebhook) SendAsyncTo(targets []string, status analyzer.Status, message, sessionID, cwd string) {
+	r.sent = append(r.sent, RecordedNotification{Status: status, Message: message, SessionID: sessionID})
+}
+
+func (r *recordingWebhook) Shutdown(timeout time.Duration) error {
+	return nil
+}
+
+func (r *recordingWebhook) TargetMetrics() map[string]webhook.Stats {
+	return nil
+}
+
+// recordingExec captures exec channel runs
+type recordingExec struct {
+	sent []RecordedNotification
+}
+
+func (r *recordingExec) SendAsync(status analyzer.Status, message, sessionID, cwd string) {
+	r.sent = append(r.sent, RecordedNotification{Status: status, Message: message, SessionID: sessionID})
+}
+
+func (r *recordingExec) Shutdown(timeout time.Duration) error {
+	return nil
+}
+
+func (r *recordingExec) GetStats() execchannel.Stats {
+	return execchannel.Stats{}
+}
+
+// recordingReminders captures reminder scheduling so replay never talks to the daemon
+type recordingReminders struct {
+	scheduled []notifier.ReminderRequest
+}
+
+func (r *recordingReminders) Schedule(req notifier.ReminderRequest) error {
+	r.scheduled = append(r.scheduled, req)
+	return nil
+}
+
+func (r *recordingReminders) Cancel(sessionID string) error {
+	return nil
+}
+
+// ReplayResult is what the pipeline did with one journal entry
+type ReplayResult struct {
+	Entry     journal.Entry
+	Outcome   Outcome
+	Desktop   []RecordedNotification
+	Webhook   []RecordedNotification
+	Exec      []RecordedNotification
+	Reminders int   // Reminder chains that would have been scheduled
+	Err       error // Error returned by HandleHook
+}
+
+// Replay runs journal entries through the full hook pipeline with recording
+// notifier/webhook stand-ins. State and dedup files live in a private temp
+// directory, so replay neither sees nor disturbs real sessions. Entries share
+// that state, so cooldowns and dedup between consecutive hooks are reproduced
+// (timing windows use replay time, not the recorded time).
+func Replay(cfg *config.Config, pluginRoot string, entries []journal.Entry) ([]ReplayResult, error) {
+	workDir, err := os.MkdirTemp("", "claude-notifications-replay-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replay directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	notif := &recordingNotifier{}
+	wh := &recordingWebhook{}
+	ex := &recordingExec{}
+	reminders := &recordingReminders{}
+	h := &Handler{
+		cfg:         cfg,
+		dedupMgr:    dedup.NewManagerWithDir(workDir),
+		stateMgr:    state.NewManagerWithDir(workDir),
+		notifierSvc: notif,
+		webhookSvc:  wh,
+		execSvc:     ex,
+		reminderSvc: reminders,
+		pluginRoot:  pluginRoot,
+	}
+
+	results := make([]ReplayResult, 0, len(entries))
+	for i, entry := range entries {
+		notif.sent, wh.sent, ex.sent, reminders.scheduled = nil, nil, nil, nil
+
+		payload, err := replayPayload(entry, filepath.Join(workDir, fmt.Sprintf("transcript-%d.jsonl", i)))
+		if err != nil {
+			return results, err
+		}
+
+		restore := setReplayEnv(entry.Env)
+		err = h.HandleHook(entry.Event, bytes.NewReader(payload))
+		restore()
+
+		results = append(results, ReplayResult{
+			Entry:     entry,
+			Outcome:   h.LastOutcome(),
+			Desktop:   notif.sent,
+			Webhook:   wh.sent,
+			Exec:      ex.sent,
+			Reminders: len(reminders.scheduled),
+			Err:       err,
+		})
+	}
+	return results, nil
+}
+
+// replayPayload writes the transcript snapshot to transcriptPath and points the payload at it.
+// Payloads that are not valid JSON are replayed unchanged.
+func replayPayload(entry journal.Entry, transcriptPath string) ([]byte, error) {
+	payload := []byte(entry.Payload)
+	if entry.TranscriptPath == "" || entry.Transcript == "" {
+		return payload, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return payload, nil
+	}
+
+	if err := os.WriteFile(transcriptPath, []byte(entry.Transcript), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write transcript snapshot: %w", err)
+	}
+	fields["transcript_path"] = transcriptPath
+
+	return json.Marshal(fields)
+}
+
+// setReplayEnv applies the recorded judge-mode flag for one entry and returns a restore func.
+// Other recorded variables are informational only and are not applied.
+func setReplayEnv(env map[string]string) func() {
+	const key = "CLAUDE_HOOK_JUDGE_MODE"
+
+	old, hadOld := os.LookupEnv(key)
+	if value, ok := env[key]; ok {
+		_ = os.Setenv(key, value)
+	} else {
+		_ = os.Unsetenv(key)
+	}
+
+	return func() {
+		if hadOld {
+			_ = os.Setenv(key, old)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}
+}
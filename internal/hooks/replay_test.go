@@ -0,0 +1,147 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/journal"
+	"github.com/777genius/claude-notifications/internal/state"
+)
+
+// buildJournalEntry records a hook payload the same way HandleHook does
+func buildJournalEntry(t *testing.T, event string, data HookData) journal.Entry {
+	t.Helper()
+	payload, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("failed to marshal hook data: %v", err)
+	}
+	return journal.New(event, payload)
+}
+
+func TestHandler_JournalsRawPayload(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, _, _ := newTestHandler(t, cfg)
+	handler.journalDir = t.TempDir()
+
+	raw := `{"session_id":"test-journal-1","tool_name":"ExitPlanMode","cwd":"/tmp"}`
+	if err := handler.HandleHook("PreToolUse", strings.NewReader(raw)); err != nil {
+		t.Fatalf("HandleHook() error: %v", err)
+	}
+
+	entries, err := journal.Load(handler.journalDir)
+	if err != nil {
+		t.Fatalf("journal.Load() error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d journal entries, want 1", len(entries))
+	}
+	if entries[0].Event != "PreToolUse" || entries[0].Payload != raw {
+		t.Errorf("entry = %q %q, want PreToolUse with the raw payload", entries[0].Event, entries[0].Payload)
+	}
+}
+
+func TestHandler_JournalDisabledByDefault(t *testing.T) {
+	cfg := config.DefaultConfig()
+	handler, _, _ := newTestHandler(t, cfg)
+
+	if handler.journalDir != "" {
+		t.Errorf("journalDir = %q, want empty", handler.journalDir)
+	}
+}
+
+func TestReplay_SentThenDeduplicated(t *testing.T) {
+	transcript := createTempTranscript(t, buildTranscriptWithTools([]string{"Write"}, 50))
+	sessionID := "test-replay-dedup"
+	data := HookData{SessionID: sessionID, TranscriptPath: transcript, CWD: "/tmp"}
+
+	entries := []journal.Entry{
+		buildJournalEntry(t, "Stop", data),
+		buildJournalEntry(t, "Stop", data),
+	}
+	// The original transcript is gone by the time a bug report is replayed
+	if err := os.Remove(transcript); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Replay(config.DefaultConfig(), t.TempDir(), entries)
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	first := results[0]
+	if first.Err != nil {
+		t.Fatalf("first entry error: %v", first.Err)
+	}
+	if first.Outcome.Kind != OutcomeSent || first.Outcome.Status != analyzer.StatusTaskComplete {
+		t.Errorf("first outcome = %+v, want sent task_complete", first.Outcome)
+	}
+	if len(first.Desktop) != 1 {
+		t.Errorf("first entry: got %d desktop notifications, want 1", len(first.Desktop))
+	}
+
+	second := results[1]
+	if second.Outcome.Kind != OutcomeDeduplicated {
+		t.Errorf("second outcome = %+v, want deduplicated", second.Outcome)
+	}
+	if len(second.Desktop) != 0 {
+		t.Errorf("second entry: got %d desktop notifications, want 0", len(second.Desktop))
+	}
+
+	// Replay keeps its own state
+	if s, _ := state.NewManager().Load(sessionID); s != nil {
+		t.Error("replay should not write to the real session state")
+	}
+}
+
+func TestReplay_Suppressed(t *testing.T) {
+	t.Setenv("CLAUDE_HOOK_JUDGE_MODE", "")
+
+	judge := buildJournalEntry(t, "PreToolUse", HookData{SessionID: "test-replay-judge", ToolName: "ExitPlanMode"})
+	judge.Env = map[string]string{"CLAUDE_HOOK_JUDGE_MODE": "true"}
+
+	status := "plan_ready"
+	cfg := config.DefaultConfig()
+	cfg.Notifications.SuppressFilters = []config.SuppressFilter{{Status: &status}}
+	filtered := buildJournalEntry(t, "PreToolUse", HookData{SessionID: "test-replay-filter", ToolName: "ExitPlanMode"})
+
+	results, err := Replay(cfg, t.TempDir(), []journal.Entry{judge, filtered})
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+
+	for i, r := range results {
+		if r.Outcome.Kind != OutcomeSuppressed {
+			t.Errorf("result %d outcome = %+v, want suppressed", i, r.Outcome)
+		}
+		if r.Outcome.Reason == "" {
+			t.Errorf("result %d has no reason", i)
+		}
+	}
+	if os.Getenv("CLAUDE_HOOK_JUDGE_MODE") != "" {
+		t.Error("replay should restore CLAUDE_HOOK_JUDGE_MODE")
+	}
+}
+
+func TestReplay_LifecycleAndMalformed(t *testing.T) {
+	lifecycle := buildJournalEntry(t, "UserPromptSubmit", HookData{SessionID: "test-replay-lifecycle"})
+	malformed := journal.New("Stop", []byte("{not json"))
+
+	results, err := Replay(config.DefaultConfig(), t.TempDir(), []journal.Entry{lifecycle, malformed})
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+
+	if results[0].Outcome.Kind != OutcomeSkipped || results[0].Err != nil {
+		t.Errorf("lifecycle result = %+v, want skipped without error", results[0])
+	}
+	if results[1].Err == nil {
+		t.Error("malformed payload should report a parse error")
+	}
+}
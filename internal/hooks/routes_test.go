@@ -0,0 +1,129 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/config"
+)
+
+// routedConfig enables every channel and adds routes
+func routedConfig(routes ...config.RouteRule) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.Webhook = config.WebhookConfig{Enabled: true, Preset: "custom", URL: "http://localhost/hook"}
+	cfg.Notifications.Exec = config.ExecConfig{Enabled: true, Command: []string{"led"}}
+	cfg.Notifications.Routes = routes
+	return cfg
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestHandler_Routes_ChooseChannels(t *testing.T) {
+	cfg := routedConfig(
+		config.RouteRule{
+			Name:     "plans in app go to the webhook",
+			Match:    config.RouteMatch{Status: strPtr("plan_ready"), CWD: strPtr("*/app")},
+			Channels: []string{config.ChannelWebhook},
+			Stop:     true,
+		},
+		config.RouteRule{
+			Name:     "never reached for app",
+			Match:    config.RouteMatch{Status: strPtr("plan_*")},
+			Channels: []string{config.ChannelDesktop},
+		},
+	)
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+
+	sendPlanReadyIn(t, handler, "test-route-app", "/work/app")
+
+	if mockNotif.wasCalled() {
+		t.Error("expected no desktop notification: the first rule stops processing")
+	}
+	if !mockWH.wasCalled() {
+		t.Error("expected a webhook notification")
+	}
+	if calls := handler.execSvc.(*mockExec).getCalls(); len(calls) != 0 {
+		t.Errorf("expected no exec run, got %d", len(calls))
+	}
+}
+
+func TestHandler_Routes_LaterRuleAndDefaults(t *testing.T) {
+	cfg := routedConfig(
+		config.RouteRule{
+			Match:    config.RouteMatch{Status: strPtr("plan_ready"), CWD: strPtr("*/app")},
+			Channels: []string{config.ChannelWebhook},
+			Stop:     true,
+		},
+		config.RouteRule{
+			Name:     "plans elsewhere on desktop",
+			Match:    config.RouteMatch{Status: strPtr("plan_*"), Subagent: boolPtr(false)},
+			Channels: []string{config.ChannelDesktop},
+		},
+	)
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+
+	sendPlanReadyIn(t, handler, "test-route-other", "/work/lib")
+
+	if !mockNotif.wasCalled() {
+		t.Error("expected a desktop notification from the second rule")
+	}
+	if mockWH.wasCalled() {
+		t.Error("expected no webhook: the matching rule only names desktop")
+	}
+
+	// Without a matching rule the per-status settings apply: every channel
+	handler, mockNotif, mockWH = newTestHandler(t, routedConfig(config.RouteRule{
+		Match:    config.RouteMatch{Status: strPtr("question")},
+		Channels: []string{config.ChannelDesktop},
+	}))
+	sendPlanReadyIn(t, handler, "test-route-default", "/work/lib")
+	if !mockNotif.wasCalled() || !mockWH.wasCalled() || len(handler.execSvc.(*mockExec).getCalls()) != 1 {
+		t.Error("expected desktop, webhook and exec when no rule matches")
+	}
+}
+
+func TestHandler_Routes_SoundOverride(t *testing.T) {
+	cfg := routedConfig(config.RouteRule{
+		Name:  "quiet plans",
+		Match: config.RouteMatch{Status: strPtr("plan_ready")},
+		Sound: strPtr(""),
+	})
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+
+	sendPlanReadyIn(t, handler, "test-route-sound", "/work/app")
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected a desktop notification: a sound-only rule keeps the channels")
+	}
+	if call.sound == nil || *call.sound != "" {
+		t.Errorf("sound = %v, want the silent override", call.sound)
+	}
+	if !mockWH.wasCalled() {
+		t.Error("expected the webhook as well")
+	}
+}
+
+func TestHandler_Routes_NamedWebhookTargets(t *testing.T) {
+	cfg := routedConfig(config.RouteRule{
+		Name:     "plans to the team channel",
+		Match:    config.RouteMatch{Status: strPtr("plan_ready")},
+		Webhooks: []string{"team"},
+	})
+	cfg.Notifications.Webhooks = config.WebhookTargets{
+		{Name: "team", Enabled: true, Preset: "custom", Format: "json", URL: "http://localhost/team"},
+	}
+	handler, _, mockWH := newTestHandler(t, cfg)
+
+	sendPlanReadyIn(t, handler, "test-route-targets", "/work/app")
+
+	mockWH.mu.Lock()
+	defer mockWH.mu.Unlock()
+	if len(mockWH.calls) != 1 {
+		t.Fatalf("expected 1 webhook call, got %d", len(mockWH.calls))
+	}
+	if got := mockWH.calls[0].targets; len(got) != 1 || got[0] != "team" {
+		t.Errorf("targets = %v, want [team]", got)
+	}
+}
@@ -0,0 +1,60 @@
+package hooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/config"
+)
+
+func TestHandler_Templates_RenderPerChannel(t *testing.T) {
+	cfg := routedConfig()
+	cfg.Notifications.Templates = config.TemplatesConfig{
+		Desktop: config.DesktopTemplates{Title: "{{.Folder}}: {{.Title}}"},
+		Webhook: config.WebhookTemplates{Text: "{{upper .Status}} in {{.CWD}}"},
+	}
+	plan := cfg.Statuses["plan_ready"]
+	plan.Templates = &config.TemplatesConfig{Desktop: config.DesktopTemplates{Subtitle: "session {{.SessionID}}"}}
+	cfg.Statuses["plan_ready"] = plan
+	handler, mockNotif, mockWH := newTestHandler(t, cfg)
+
+	sendPlanReadyIn(t, handler, "test-templates", "/work/app")
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected a desktop notification")
+	}
+	if want := "app: " + plan.Title; call.title != want {
+		t.Errorf("title = %q, want %q", call.title, want)
+	}
+	if call.subtitle != "session test-templates" {
+		t.Errorf("subtitle = %q, want the plan_ready template", call.subtitle)
+	}
+	if call.message == "" || strings.HasPrefix(call.message, "[") {
+		t.Errorf("body = %q, want the default (summary only)", call.message)
+	}
+
+	if len(mockWH.calls) != 1 || mockWH.calls[0].message != "PLAN_READY in /work/app" {
+		t.Errorf("webhook calls = %+v, want the webhook template", mockWH.calls)
+	}
+	if calls := handler.execSvc.(*mockExec).getCalls(); len(calls) != 1 || calls[0].message != call.message {
+		t.Errorf("exec should get the plain message, got %+v", calls)
+	}
+}
+
+func TestHandler_Templates_FailingTemplateFallsBack(t *testing.T) {
+	cfg := routedConfig()
+	// Parses, but fails when executed (Validate would report it)
+	cfg.Notifications.Templates.Desktop.Title = `{{call .Title}}`
+	handler, mockNotif, _ := newTestHandler(t, cfg)
+
+	sendPlanReadyIn(t, handler, "test-templates-fallback", "/work/lib")
+
+	call := mockNotif.lastCall()
+	if call == nil {
+		t.Fatal("expected a desktop notification")
+	}
+	if !strings.HasPrefix(call.title, cfg.Statuses["plan_ready"].Title+" [") {
+		t.Errorf("title = %q, want the default layout", call.title)
+	}
+}
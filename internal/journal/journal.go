@@ -0,0 +1,187 @@
+// ABOUTME: Hook journal: records raw hook payloads with env context and a transcript snapshot.
+// ABOUTME: Entries rotate by count and can be fed back through the pipeline with `claude-notifications replay`.
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// entryVersion is the journal entry format version
+const entryVersion = 1
+
+// maxTranscriptSnapshot caps the transcript snapshot size. Longer transcripts keep
+// their tail, which holds the current turn the analyzer looks at.
+const maxTranscriptSnapshot = 2 << 20
+
+// entryPattern matches journal entry files
+const entryPattern = "*.json"
+
+// envKeys are the environment variables recorded with each entry.
+// Only hook-relevant variables are kept so the journal never captures secrets.
+var envKeys = []string{
+	"CLAUDE_PLUGIN_ROOT",
+	"CLAUDE_PROJECT_DIR",
+	"CLAUDE_HOOK_JUDGE_MODE",
+	"TERM",
+	"TERM_PROGRAM",
+	"TMUX",
+	"DISPLAY",
+	"WAYLAND_DISPLAY",
+	"XDG_CURRENT_DESKTOP",
+}
+
+// Entry is one recorded hook invocation
+type Entry struct {
+	Version             int               `json:"version"`
+	Time                time.Time         `json:"time"`
+	Event               string            `json:"event"`
+	Payload             string            `json:"payload"` // Raw stdin, kept as a string so malformed input survives
+	OS                  string            `json:"os"`
+	Env                 map[string]string `json:"env,omitempty"`
+	TranscriptPath      string            `json:"transcript_path,omitempty"` // Path from the payload
+	Transcript          string            `json:"transcript,omitempty"`      // Transcript contents at hook time
+	TranscriptTruncated bool              `json:"transcript_truncated,omitempty"`
+
+	// Path is the file the entry was loaded from (not serialized)
+	Path string `json:"-"`
+}
+
+// New builds an entry for a hook payload, snapshotting the environment and the transcript
+func New(event string, payload []byte) Entry {
+	entry := Entry{
+		Version: entryVersion,
+		Time:    time.Now(),
+		Event:   event,
+		Payload: string(payload),
+		OS:      runtime.GOOS,
+		Env:     make(map[string]string),
+	}
+
+	for _, key := range envKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			entry.Env[key] = value
+		}
+	}
+
+	var hookData struct {
+		TranscriptPath string `json:"transcript_path"`
+	}
+	if err := json.Unmarshal(payload, &hookData); err == nil && hookData.TranscriptPath != "" {
+		entry.TranscriptPath = hookData.TranscriptPath
+		entry.Transcript, entry.TranscriptTruncated = snapshotTranscript(hookData.TranscriptPath)
+	}
+
+	return entry
+}
+
+// snapshotTranscript reads the transcript, keeping whole lines from its tail if it is too large
+func snapshotTranscript(path string) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	if len(data) <= maxTranscriptSnapshot {
+		return string(data), false
+	}
+
+	tail := data[len(data)-maxTranscriptSnapshot:]
+	if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	return string(tail), true
+}
+
+// Write stores the entry in dir and removes the oldest entries beyond maxEntries.
+// Returns the path of the new entry file.
+func Write(dir string, entry Entry, maxEntries int) (string, error) {
+	// Payloads contain prompts and transcripts, keep them private
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	// Timestamp prefix keeps lexical order == chronological order
+	name := fmt.Sprintf("%s-%d-%s.json", entry.Time.UTC().Format("20060102T150405.000000000"), os.Getpid(), entry.Event)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write journal entry: %w", err)
+	}
+
+	if err := rotate(dir, maxEntries); err != nil {
+		return path, err
+	}
+	return path, nil
+}
+
+// rotate removes the oldest entries so that at most maxEntries remain
+func rotate(dir string, maxEntries int) error {
+	if maxEntries <= 0 {
+		return nil
+	}
+
+	files, err := entryFiles(dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > maxEntries {
+		if err := os.Remove(files[0]); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate journal: %w", err)
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// entryFiles returns the entry files in dir, oldest first
+func entryFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, entryPattern))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Load reads a single entry file, or all entries of a journal directory (oldest first)
+func Load(path string) ([]Entry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = entryFiles(path); err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse journal entry %s: %w", file, err)
+		}
+		if entry.Event == "" {
+			return nil, fmt.Errorf("journal entry %s has no event", file)
+		}
+		entry.Path = file
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,112 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_SnapshotsTranscriptAndEnv(t *testing.T) {
+	transcript := filepath.Join(t.TempDir(), "transcript.jsonl")
+	require.NoError(t, os.WriteFile(transcript, []byte(`{"type":"user"}`+"\n"), 0600))
+	t.Setenv("CLAUDE_HOOK_JUDGE_MODE", "true")
+	t.Setenv("SOME_SECRET_TOKEN", "hunter2")
+
+	payload := []byte(`{"session_id":"s1","transcript_path":"` + filepath.ToSlash(transcript) + `"}`)
+	entry := New("Stop", payload)
+
+	assert.Equal(t, entryVersion, entry.Version)
+	assert.Equal(t, "Stop", entry.Event)
+	assert.Equal(t, string(payload), entry.Payload)
+	assert.Equal(t, filepath.ToSlash(transcript), entry.TranscriptPath)
+	assert.Equal(t, `{"type":"user"}`+"\n", entry.Transcript)
+	assert.False(t, entry.TranscriptTruncated)
+	assert.Equal(t, "true", entry.Env["CLAUDE_HOOK_JUDGE_MODE"])
+	assert.NotContains(t, entry.Env, "SOME_SECRET_TOKEN", "only whitelisted variables are recorded")
+}
+
+func TestNew_MalformedPayload(t *testing.T) {
+	entry := New("Stop", []byte("{not json"))
+
+	assert.Equal(t, "{not json", entry.Payload)
+	assert.Empty(t, entry.TranscriptPath)
+	assert.Empty(t, entry.Transcript)
+}
+
+func TestSnapshotTranscript_KeepsTail(t *testing.T) {
+	transcript := filepath.Join(t.TempDir(), "big.jsonl")
+	line := strings.Repeat("x", 1023) + "\n"
+	content := strings.Repeat(line, maxTranscriptSnapshot/len(line)+10) + "last\n"
+	require.NoError(t, os.WriteFile(transcript, []byte(content), 0600))
+
+	snapshot, truncated := snapshotTranscript(transcript)
+
+	assert.True(t, truncated)
+	assert.LessOrEqual(t, len(snapshot), maxTranscriptSnapshot)
+	assert.True(t, strings.HasSuffix(snapshot, "last\n"))
+	assert.True(t, strings.HasPrefix(snapshot, "x"), "snapshot starts on a line boundary")
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "journal")
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i, event := range []string{"PreToolUse", "Notification", "Stop"} {
+		entry := New(event, []byte(`{"session_id":"s1"}`))
+		entry.Time = base.Add(time.Duration(i) * time.Second)
+		_, err := Write(dir, entry, 10)
+		require.NoError(t, err)
+	}
+
+	entries, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "PreToolUse", entries[0].Event)
+	assert.Equal(t, "Stop", entries[2].Event)
+	assert.NotEmpty(t, entries[0].Path)
+
+	// Single entry file
+	single, err := Load(entries[1].Path)
+	require.NoError(t, err)
+	require.Len(t, single, 1)
+	assert.Equal(t, "Notification", single[0].Event)
+}
+
+func TestWrite_Rotates(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		entry := New("Stop", []byte(`{}`))
+		entry.Time = base.Add(time.Duration(i) * time.Second)
+		_, err := Write(dir, entry, 3)
+		require.NoError(t, err)
+	}
+
+	entries, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, base.Add(2*time.Second), entries[0].Time.UTC(), "oldest entries are removed first")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	bad := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte("{"), 0600))
+	_, err = Load(bad)
+	assert.Error(t, err)
+
+	noEvent := filepath.Join(dir, "noevent.json")
+	require.NoError(t, os.WriteFile(noEvent, []byte(`{"payload":"{}"}`), 0600))
+	_, err = Load(noEvent)
+	assert.ErrorContains(t, err, "has no event")
+}
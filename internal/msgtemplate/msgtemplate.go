@@ -0,0 +1,124 @@
+// ABOUTME: Message templates: Go text/template strings rendering desktop titles, subtitles, bodies and webhook text.
+// ABOUTME: The defaults reproduce the built-in layout; Check validates user templates against a sample context.
+package msgtemplate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// Default templates
+const (
+	// DefaultDesktopTitle is "✅ Completed [bold 06ddb8f7]", with the folder inside the brackets outside git repos
+	DefaultDesktopTitle = `{{.Title}}{{with .SessionName}} [{{.}}{{if not $.Branch}} {{$.Folder}}{{end}}]{{end}}`
+	// DefaultDesktopSubtitle is "main · my-project" in git repos (shown on macOS)
+	DefaultDesktopSubtitle = `{{if .Branch}}{{.Branch}} · {{.Folder}}{{end}}`
+	// DefaultDesktopBody is the summary
+	DefaultDesktopBody = `{{.Summary}}`
+	// DefaultWebhookText is "[bold 06ddb8f7|main my-project] summary"
+	DefaultWebhookText = `[{{.SessionName}}{{if .Branch}}|{{.Branch}}{{end}} {{.Folder}}] {{.Summary}}`
+)
+
+// Context is what templates can use
+type Context struct {
+	Status      string         // e.g. "task_complete"
+	Title       string         // The status title, e.g. "✅ Completed"
+	Summary     string         // Generated message, e.g. "Created factorial function 📝 1 new ⏱ 2m"
+	SessionID   string         // Full session ID
+	SessionName string         // Session label, e.g. "bold 06ddb8f7"
+	Branch      string         // Git branch ("" outside git repos)
+	Folder      string         // Base name of CWD
+	CWD         string         // Project working directory
+	Duration    time.Duration  // Length of the turn (0 = unknown); use {{duration .Duration}}
+	ToolCounts  map[string]int // Tools used in the turn by name, e.g. {"Edit": 3, "Bash": 1}
+	Question    string         // Question asked with AskUserQuestion ("" if none)
+}
+
+// Funcs are the functions available in templates besides the text/template builtins
+var Funcs = template.FuncMap{
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"trim":     strings.TrimSpace,
+	"truncate": truncate,
+	"duration": formatDuration,
+}
+
+// Parse parses a template with Funcs; missing map keys give the zero value
+// (so {{.ToolCounts.Edit}} is 0 in a turn without edits)
+func Parse(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(Funcs).Option("missingkey=zero").Parse(text)
+}
+
+// Render executes text with ctx
+func Render(text string, ctx Context) (string, error) {
+	tmpl, err := Parse("message", text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, ctx); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Check parses text and executes it against a sample context, so unknown fields
+// and functions are reported before the template is used
+func Check(text string) error {
+	tmpl, err := Parse("check", text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(io.Discard, sampleContext)
+}
+
+// sampleContext has every field set for Check
+var sampleContext = Context{
+	Status:      "question",
+	Title:       "❓ Question",
+	Summary:     "Which database should I use?",
+	SessionID:   "06ddb8f7-0000-0000-0000-000000000000",
+	SessionName: "bold 06ddb8f7",
+	Branch:      "main",
+	Folder:      "project",
+	CWD:         "/home/user/project",
+	Duration:    95 * time.Second,
+	ToolCounts:  map[string]int{"Edit": 2, "Bash": 1},
+	Question:    "Which database should I use?",
+}
+
+// truncate shortens s to at most n characters, ending with "..." if it was cut
+func truncate(n int, s string) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if n <= 3 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+// formatDuration formats d as "45s", "2m 15s" or "1h 5m"; "" if d is zero
+func formatDuration(d time.Duration) string {
+	seconds := int(d.Seconds())
+	switch {
+	case seconds <= 0:
+		return ""
+	case seconds < 60:
+		return fmt.Sprintf("%ds", seconds)
+	case seconds < 3600:
+		if seconds%60 == 0 {
+			return fmt.Sprintf("%dm", seconds/60)
+		}
+		return fmt.Sprintf("%dm %ds", seconds/60, seconds%60)
+	}
+	if minutes := seconds / 60 % 60; minutes > 0 {
+		return fmt.Sprintf("%dh %dm", seconds/3600, minutes)
+	}
+	return fmt.Sprintf("%dh", seconds/3600)
+}
@@ -0,0 +1,98 @@
+package msgtemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaults_ReproduceBuiltInLayout(t *testing.T) {
+	ctx := Context{
+		Status:      "task_complete",
+		Title:       "✅ Completed",
+		Summary:     "Created factorial function",
+		SessionName: "bold 06ddb8f7",
+		Branch:      "main",
+		Folder:      "my-project",
+	}
+
+	render := func(text string, ctx Context) string {
+		out, err := Render(text, ctx)
+		require.NoError(t, err)
+		return out
+	}
+
+	assert.Equal(t, "✅ Completed [bold 06ddb8f7]", render(DefaultDesktopTitle, ctx))
+	assert.Equal(t, "main · my-project", render(DefaultDesktopSubtitle, ctx))
+	assert.Equal(t, "Created factorial function", render(DefaultDesktopBody, ctx))
+	assert.Equal(t, "[bold 06ddb8f7|main my-project] Created factorial function", render(DefaultWebhookText, ctx))
+
+	// Outside a git repo the folder moves into the title
+	ctx.Branch = ""
+	assert.Equal(t, "✅ Completed [bold 06ddb8f7 my-project]", render(DefaultDesktopTitle, ctx))
+	assert.Equal(t, "", render(DefaultDesktopSubtitle, ctx))
+	assert.Equal(t, "[bold 06ddb8f7 my-project] Created factorial function", render(DefaultWebhookText, ctx))
+
+	ctx.SessionName = ""
+	assert.Equal(t, "✅ Completed", render(DefaultDesktopTitle, ctx))
+}
+
+func TestRender_FieldsAndFuncs(t *testing.T) {
+	ctx := Context{
+		Status:     "question",
+		Summary:    "  Which database should I use for the cache layer?  ",
+		Duration:   135 * time.Second,
+		ToolCounts: map[string]int{"Edit": 3},
+		Question:   "Which database?",
+	}
+
+	out, err := Render(`{{upper .Status}} {{duration .Duration}} edits={{.ToolCounts.Edit}} bash={{.ToolCounts.Bash}}`, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "QUESTION 2m 15s edits=3 bash=0", out)
+
+	out, err = Render(`{{.Summary | trim | truncate 20}}`, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Which database sh...", out)
+
+	out, err = Render(`{{with .Question}}Q: {{.}}{{else}}{{.Summary}}{{end}}`, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Q: Which database?", out)
+}
+
+func TestCheck(t *testing.T) {
+	for _, text := range []string{DefaultDesktopTitle, DefaultDesktopSubtitle, DefaultDesktopBody, DefaultWebhookText, `{{lower .Folder}}`} {
+		assert.NoError(t, Check(text), text)
+	}
+
+	assert.Error(t, Check(`{{.Summary`), "syntax error")
+	assert.Error(t, Check(`{{.Nope}}`), "unknown field")
+	assert.Error(t, Check(`{{shout .Summary}}`), "unknown function")
+	assert.Error(t, Check(`{{truncate "x" .Summary}}`), "wrong argument type")
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate(10, "short"))
+	assert.Equal(t, "abcdefg", truncate(0, "abcdefg"))
+	assert.Equal(t, "abcd...", truncate(7, "abcdefghij"))
+	assert.Equal(t, "ab", truncate(2, "abcdefghij"))
+	assert.Equal(t, "привет...", truncate(9, "привет мир!"))
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, ""},
+		{45 * time.Second, "45s"},
+		{2 * time.Minute, "2m"},
+		{135 * time.Second, "2m 15s"},
+		{time.Hour, "1h"},
+		{65 * time.Minute, "1h 5m"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatDuration(tt.d), tt.d.String())
+	}
+}
@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAggregateUnsupported is returned where no daemon can hold the aggregation window
+var ErrAggregateUnsupported = errors.New("aggregation requires the Linux notification daemon")
+
+// AggregateRequest is a notification handed to the daemon's aggregation window,
+// rendered and routed by the hook
+type AggregateRequest struct {
+	SessionID   string
+	Status      string
+	Message     string  // Plain summary
+	Rendered    Message // Desktop title, subtitle and body
+	WebhookText string
+	CWD         string
+	Desktop     bool     // Routed to the desktop
+	Webhook     bool     // Routed to the webhook
+	Webhooks    []string // Webhook targets chosen by routes (empty = all)
+	Sound       *string  // Sound chosen by routes
+	Quiet       bool     // Muted by quiet hours
+	PluginRoot  string
+	Window      time.Duration
+}
@@ -0,0 +1,26 @@
+package notifier
+
+import "errors"
+
+// ErrClaimsUnsupported is returned where no daemon can hold dedup and cooldown state
+var ErrClaimsUnsupported = errors.New("in-memory dedup state requires the Linux notification daemon")
+
+// NotificationClaim describes a notification a hook is about to send, checked against
+// the dedup and cooldown state in the daemon
+type NotificationClaim struct {
+	SessionID         string
+	HookEvent         string
+	Message           string
+	TaskComplete      bool // Starts the cooldown after task completion
+	InputRequest      bool // Question-like status, subject to the cooldowns
+	CooldownAfterAny  int  // Seconds input requests are suppressed after any notification
+	CooldownAfterTask int  // Seconds input requests are suppressed after task completion
+	DuplicateWindow   int  // Seconds the same message counts as a duplicate
+}
+
+// ClaimResult is the daemon's decision on a claim
+type ClaimResult struct {
+	Allowed   bool   // The notification was recorded and may be sent
+	Duplicate bool   // Rejected as a duplicate rather than by a cooldown
+	Reason    string // Why it was rejected
+}
@@ -0,0 +1,13 @@
+package notifier
+
+// Event is the outcome of a hook's notification, passed on to `watch` clients by the daemon
+type Event struct {
+	Type      string // "sent" or "suppressed"
+	Event     string // Hook event (Stop, Notification, reminder, ...)
+	SessionID string
+	Status    string
+	Message   string
+	Reason    string            // Why it was suppressed, or the quiet hours reason of a silent send
+	CWD       string            // Session working directory
+	Channels  map[string]string // Channel name -> outcome (sent, failed, disabled)
+}
@@ -20,6 +20,17 @@ var multiplexerHandlers = []multiplexerHandler{
 	{"kitty", IsKitty, buildKittyClickArgs},
 }
 
+// DetectMultiplexer returns the name of the first detected multiplexer or terminal with
+// click-to-focus integration (tmux, zellij, wezterm, kitty), or "" if none
+func DetectMultiplexer() string {
+	for _, mux := range multiplexerHandlers {
+		if mux.detect() {
+			return mux.name
+		}
+	}
+	return ""
+}
+
 // detectMultiplexerArgs tries each registered multiplexer.
 // Returns (args, name) if detected and target obtained,
 // (nil, name) if detected but target failed,
@@ -20,6 +20,13 @@ import (
 	"github.com/777genius/claude-notifications/internal/platform"
 )
 
+// Message is a rendered desktop notification (see notifications.templates)
+type Message struct {
+	Title    string // Empty = the status title
+	Subtitle string // Shown by terminal-notifier on macOS only
+	Body     string
+}
+
 // Notifier sends desktop notifications
 type Notifier struct {
 	cfg         *config.Config
@@ -28,7 +35,9 @@ type Notifier struct {
 	playerErr   error
 	mu          sync.Mutex
 	wg          sync.WaitGroup
-	closing     bool // Prevents new sounds from being enqueued after Close() is called
+	closing     bool    // Prevents new sounds from being enqueued after Close() is called
+	quiet       bool    // Quiet hours: no sound, no terminal bell, not time-sensitive
+	sound       *string // Routing rule sound override (nil = the status's sound, "" = silent)
 }
 
 // New creates a new notifier
@@ -38,6 +47,18 @@ func New(cfg *config.Config) *Notifier {
 	}
 }
 
+// SetQuiet mutes subsequent notifications (quiet hours): the toast is still shown,
+// but without sound, terminal bell or the time-sensitive flag
+func (n *Notifier) SetQuiet(quiet bool) {
+	n.quiet = quiet
+}
+
+// SetSound overrides the sound of subsequent notifications (routing rules):
+// nil restores the status's sound, "" sends them without sound
+func (n *Notifier) SetSound(sound *string) {
+	n.sound = sound
+}
+
 // isTimeSensitiveStatus returns true for statuses that should break through Focus Mode
 func isTimeSensitiveStatus(status analyzer.Status) bool {
 	switch status {
@@ -52,9 +73,9 @@ func isTimeSensitiveStatus(status analyzer.Status) bool {
 // On macOS with clickToFocus enabled, uses terminal-notifier for click-to-focus support
 // On Linux with clickToFocus enabled, uses background daemon for click-to-focus support
 // cwd is the working directory of the project; used for window-specific focus. May be empty.
-func (n *Notifier) SendDesktop(status analyzer.Status, message, sessionID, cwd string) error {
+func (n *Notifier) SendDesktop(status analyzer.Status, msg Message, sessionID, cwd string) error {
 	// Send terminal bell for terminal tab indicators (e.g. Ghostty, tmux)
-	if n.cfg.IsTerminalBellEnabled() {
+	if n.cfg.IsTerminalBellEnabled() && !n.quiet {
 		sendTerminalBell()
 	}
 
@@ -67,32 +88,17 @@ func (n *Notifier) SendDesktop(status analyzer.Status, message, sessionID, cwd s
 	if !exists {
 		return fmt.Errorf("unknown status: %s", status)
 	}
-
-	// Extract session name, git branch and folder name from message
-	// Format: "[session-name|branch folder] actual message" or "[session-name folder] actual message"
-	sessionName, gitBranch, cleanMessage := extractSessionInfo(message)
-
-	// Build clean title (status only + session name)
-	// Format: "✅ Completed [peak]" or "✅ Completed"
-	title := statusInfo.Title
-	if sessionName != "" {
-		title = fmt.Sprintf("%s [%s]", title, sessionName)
+	sound := statusInfo.Sound
+	if n.sound != nil {
+		sound = *n.sound
 	}
 
-	// Build subtitle from branch and folder name
-	// Format: "main · notification_plugin_go" or just folder name
-	var subtitle string
-	if gitBranch != "" {
-		// gitBranch may contain "branch folder" (space-separated from hooks.go format)
-		parts := strings.SplitN(gitBranch, " ", 2)
-		if len(parts) == 2 {
-			subtitle = fmt.Sprintf("%s \u00B7 %s", parts[0], parts[1])
-		} else {
-			subtitle = gitBranch
-		}
+	title, subtitle, body := msg.Title, msg.Subtitle, msg.Body
+	if title == "" {
+		title = statusInfo.Title
 	}
 
-	timeSensitive := isTimeSensitiveStatus(status)
+	timeSensitive := isTimeSensitiveStatus(status) && !n.quiet
 
 	// Get app icon path if configured
 	appIcon := n.cfg.Notifications.Desktop.AppIcon
@@ -104,12 +110,12 @@ func (n *Notifier) SendDesktop(status analyzer.Status, message, sessionID, cwd s
 	// macOS: Try terminal-notifier for click-to-focus support
 	if platform.IsMacOS() && n.cfg.Notifications.Desktop.ClickToFocus {
 		if IsTerminalNotifierAvailable() {
-			if err := n.sendWithTerminalNotifier(title, cleanMessage, subtitle, sessionID, timeSensitive, cwd); err != nil {
+			if err := n.sendWithTerminalNotifier(title, body, subtitle, sessionID, timeSensitive, cwd); err != nil {
 				logging.Warn("terminal-notifier failed, falling back to beeep: %v", err)
 				// Fall through to beeep
 			} else {
 				logging.Debug("Desktop notification sent via terminal-notifier: title=%s", title)
-				n.playSoundAsync(statusInfo.Sound)
+				n.playSoundAsync(sound)
 				return nil
 			}
 		} else {
@@ -119,18 +125,18 @@ func (n *Notifier) SendDesktop(status analyzer.Status, message, sessionID, cwd s
 
 	// Linux: Try daemon for click-to-focus support
 	if platform.IsLinux() && n.cfg.Notifications.Desktop.ClickToFocus {
-		if err := sendLinuxNotification(title, cleanMessage, appIcon, n.cfg, cwd); err != nil {
+		if err := sendLinuxNotification(title, body, appIcon, n.cfg, status, sessionID, cwd); err != nil {
 			logging.Warn("Linux daemon notification failed, falling back to beeep: %v", err)
 			// Fall through to beeep
 		} else {
 			logging.Debug("Desktop notification sent via Linux daemon: title=%s", title)
-			n.playSoundAsync(statusInfo.Sound)
+			n.playSoundAsync(sound)
 			return nil
 		}
 	}
 
 	// Standard path: beeep (Windows, macOS fallback, Linux fallback)
-	return n.sendWithBeeep(title, cleanMessage, appIcon, statusInfo.Sound)
+	return n.sendWithBeeep(title, body, appIcon, sound)
 }
 
 // sendWithTerminalNotifier sends notification via terminal-notifier on macOS
@@ -385,9 +391,20 @@ func (n *Notifier) sendWithBeeep(title, message, appIcon, sound string) error {
 	return nil
 }
 
-// playSoundAsync plays sound asynchronously if enabled
+// playSoundAsync plays sound asynchronously if enabled.
+// On Linux a running daemon plays it, so Close doesn't have to wait for playback.
 func (n *Notifier) playSoundAsync(sound string) {
-	if n.cfg.Notifications.Desktop.Sound && sound != "" {
+	if n.cfg.Notifications.Desktop.Sound && sound != "" && !n.quiet {
+		if platform.IsLinux() {
+			desktop := n.cfg.Notifications.Desktop
+			if err := playViaDaemon(sound, desktop.Volume, desktop.AudioDevice); err == nil {
+				logging.Debug("Sound handed to the daemon: %s", sound)
+				return
+			} else {
+				logging.Debug("Daemon can't play the sound (%v), playing in-process", err)
+			}
+		}
+
 		// Check if notifier is closing to prevent WaitGroup race
 		n.mu.Lock()
 		if n.closing {
@@ -489,38 +506,3 @@ func sendTerminalBell() {
 	defer f.Close()
 	_, _ = f.Write([]byte("\a"))
 }
-
-// extractSessionInfo extracts session name and git branch from message
-// Format: "[session-name|branch] message" or "[session-name] message"
-// Returns session name, git branch (may be empty), and clean message
-func extractSessionInfo(message string) (sessionName, gitBranch, cleanMessage string) {
-	message = strings.TrimSpace(message)
-
-	// Check if message starts with [
-	if !strings.HasPrefix(message, "[") {
-		return "", "", message
-	}
-
-	// Find closing bracket
-	closingIdx := strings.Index(message, "]")
-	if closingIdx == -1 {
-		return "", "", message
-	}
-
-	// Extract content inside brackets
-	bracketContent := message[1:closingIdx]
-
-	// Check if there's a pipe separator for git branch
-	if pipeIdx := strings.Index(bracketContent, "|"); pipeIdx != -1 {
-		sessionName = bracketContent[:pipeIdx]
-		gitBranch = bracketContent[pipeIdx+1:]
-	} else {
-		sessionName = bracketContent
-		gitBranch = ""
-	}
-
-	// Extract clean message (everything after "] ")
-	cleanMessage = strings.TrimSpace(message[closingIdx+1:])
-
-	return sessionName, gitBranch, cleanMessage
-}
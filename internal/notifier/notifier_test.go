@@ -13,109 +13,6 @@ import (
 	"github.com/777genius/claude-notifications/internal/config"
 )
 
-func TestExtractSessionInfo(t *testing.T) {
-	tests := []struct {
-		name             string
-		message          string
-		expectedSession  string
-		expectedBranch   string
-		expectedCleanMsg string
-	}{
-		{
-			name:             "Valid session name with message",
-			message:          "[boldcat] 📝 3 new  ✏️ 2 edited  ⏱ 2m 15s",
-			expectedSession:  "boldcat",
-			expectedBranch:   "",
-			expectedCleanMsg: "📝 3 new  ✏️ 2 edited  ⏱ 2m 15s",
-		},
-		{
-			name:             "Session name with git branch",
-			message:          "[boldcat|main] 📝 3 new",
-			expectedSession:  "boldcat",
-			expectedBranch:   "main",
-			expectedCleanMsg: "📝 3 new",
-		},
-		{
-			name:             "Session with feature branch",
-			message:          "[swifteagle|feature/auth] Task complete",
-			expectedSession:  "swifteagle",
-			expectedBranch:   "feature/auth",
-			expectedCleanMsg: "Task complete",
-		},
-		{
-			name:             "Valid session name with short message",
-			message:          "[swifteagle] Task complete",
-			expectedSession:  "swifteagle",
-			expectedBranch:   "",
-			expectedCleanMsg: "Task complete",
-		},
-		{
-			name:             "Message without session name",
-			message:          "Task completed successfully",
-			expectedSession:  "",
-			expectedBranch:   "",
-			expectedCleanMsg: "Task completed successfully",
-		},
-		{
-			name:             "Message with only opening bracket",
-			message:          "[no-closing-bracket Task complete",
-			expectedSession:  "",
-			expectedBranch:   "",
-			expectedCleanMsg: "[no-closing-bracket Task complete",
-		},
-		{
-			name:             "Empty message",
-			message:          "",
-			expectedSession:  "",
-			expectedBranch:   "",
-			expectedCleanMsg: "",
-		},
-		{
-			name:             "Session name with extra spaces",
-			message:          "[cool-fox]   Multiple   spaces   message",
-			expectedSession:  "cool-fox",
-			expectedBranch:   "",
-			expectedCleanMsg: "Multiple   spaces   message",
-		},
-		{
-			name:             "Session name only (no message)",
-			message:          "[lonely-wolf]",
-			expectedSession:  "lonely-wolf",
-			expectedBranch:   "",
-			expectedCleanMsg: "",
-		},
-		{
-			name:             "Leading/trailing spaces",
-			message:          "  [trim-test] Message with spaces  ",
-			expectedSession:  "trim-test",
-			expectedBranch:   "",
-			expectedCleanMsg: "Message with spaces",
-		},
-		{
-			name:             "Session with branch only (no message)",
-			message:          "[test-session|develop]",
-			expectedSession:  "test-session",
-			expectedBranch:   "develop",
-			expectedCleanMsg: "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			session, branch, cleanMsg := extractSessionInfo(tt.message)
-			if session != tt.expectedSession {
-				t.Errorf("extractSessionInfo(%q) session = %q, want %q", tt.message, session, tt.expectedSession)
-			}
-			if branch != tt.expectedBranch {
-				t.Errorf("extractSessionInfo(%q) branch = %q, want %q", tt.message, branch, tt.expectedBranch)
-			}
-			if cleanMsg != tt.expectedCleanMsg {
-				t.Errorf("extractSessionInfo(%q) cleanMsg = %q, want %q", tt.message, cleanMsg, tt.expectedCleanMsg)
-			}
-		})
-	}
-}
-
 func TestSendDesktopRestoresAppName(t *testing.T) {
 	// This test verifies that SendDesktop properly restores beeep.AppName
 	// after sending a notification, even if the notification fails.
@@ -136,7 +33,7 @@ func TestSendDesktopRestoresAppName(t *testing.T) {
 	n := New(cfg)
 
 	// Call SendDesktop - should not change AppName since notifications are disabled
-	_ = n.SendDesktop(analyzer.StatusTaskComplete, "test message", "", "")
+	_ = n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: "test message"}, "", "")
 
 	// Verify AppName is unchanged (because we skipped notification)
 	if beeep.AppName != testAppName {
@@ -149,7 +46,7 @@ func TestSendDesktopRestoresAppName(t *testing.T) {
 
 	// This will attempt to send a real notification and may fail in CI,
 	// but the important thing is that AppName is restored afterward
-	_ = n.SendDesktop(analyzer.StatusTaskComplete, "test message", "", "")
+	_ = n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: "test message"}, "", "")
 
 	// Verify AppName is restored to testAppName after the defer runs
 	if beeep.AppName != testAppName {
@@ -171,7 +68,7 @@ func TestSendDesktop_ClickToFocusDisabled(t *testing.T) {
 	// Should not panic and should use beeep path
 	// We can't easily verify which path was taken without mocking,
 	// but we can verify it doesn't crash
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "[test-session] Task done", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Title: "✅ Completed [test-session]", Body: "Task done"}, "", "")
 	// Error is acceptable in CI environment where notifications may not work
 	_ = err
 }
@@ -191,7 +88,7 @@ func TestSendDesktop_WithTerminalBundleIDOverride(t *testing.T) {
 	}
 
 	// SendDesktop should work without panic
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "Test message", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: "Test message"}, "", "")
 	_ = err // Error acceptable in CI
 }
 
@@ -301,7 +198,7 @@ func TestSendDesktop_AllStatuses(t *testing.T) {
 	for _, status := range statuses {
 		t.Run(string(status), func(t *testing.T) {
 			// Should not panic for any status
-			err := n.SendDesktop(status, "[test] Message for "+string(status), "test-session", "")
+			err := n.SendDesktop(status, Message{Body: "Message for " + string(status)}, "test-session", "")
 			// Error is acceptable (notifications may not work in CI)
 			_ = err
 		})
@@ -315,7 +212,7 @@ func TestSendDesktop_Disabled(t *testing.T) {
 	n := New(cfg)
 
 	// Should return nil without doing anything
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "test message", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: "test message"}, "", "")
 	if err != nil {
 		t.Errorf("Expected nil error when disabled, got: %v", err)
 	}
@@ -328,7 +225,7 @@ func TestSendDesktop_UnknownStatus(t *testing.T) {
 	n := New(cfg)
 
 	// Should return error for unknown status
-	err := n.SendDesktop(analyzer.Status("unknown_status"), "test message", "", "")
+	err := n.SendDesktop(analyzer.Status("unknown_status"), Message{Body: "test message"}, "", "")
 	if err == nil {
 		t.Error("Expected error for unknown status, got nil")
 	}
@@ -343,7 +240,7 @@ func TestSendDesktop_WithSessionName(t *testing.T) {
 	n := New(cfg)
 
 	// Test with session name
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "[my-session] Task completed", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Title: "✅ Completed [my-session]", Body: "Task completed"}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
@@ -357,7 +254,7 @@ func TestSendDesktop_WithoutSessionName(t *testing.T) {
 	n := New(cfg)
 
 	// Test without session name
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "Task completed without session", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: "Task completed without session"}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
@@ -401,88 +298,6 @@ func TestNotifier_CloseWithoutPlayback(t *testing.T) {
 	}
 }
 
-func TestExtractSessionInfo_MoreCases(t *testing.T) {
-	tests := []struct {
-		name             string
-		message          string
-		expectedSession  string
-		expectedBranch   string
-		expectedCleanMsg string
-	}{
-		{
-			name:             "Nested brackets",
-			message:          "[outer] message with [inner] brackets",
-			expectedSession:  "outer",
-			expectedBranch:   "",
-			expectedCleanMsg: "message with [inner] brackets",
-		},
-		{
-			name:             "Multiple brackets at start",
-			message:          "[first][second] message",
-			expectedSession:  "first",
-			expectedBranch:   "",
-			expectedCleanMsg: "[second] message",
-		},
-		{
-			name:             "Bracket in middle",
-			message:          "message [not-session] here",
-			expectedSession:  "",
-			expectedBranch:   "",
-			expectedCleanMsg: "message [not-session] here",
-		},
-		{
-			name:             "Only brackets with text",
-			message:          "[]",
-			expectedSession:  "",
-			expectedBranch:   "",
-			expectedCleanMsg: "",
-		},
-		{
-			name:             "Hyphenated session name",
-			message:          "[bold-red-fox] Long message here",
-			expectedSession:  "bold-red-fox",
-			expectedBranch:   "",
-			expectedCleanMsg: "Long message here",
-		},
-		{
-			name:             "Underscored session name",
-			message:          "[session_with_underscores] Message",
-			expectedSession:  "session_with_underscores",
-			expectedBranch:   "",
-			expectedCleanMsg: "Message",
-		},
-		{
-			name:             "Numeric session name",
-			message:          "[session123] Message",
-			expectedSession:  "session123",
-			expectedBranch:   "",
-			expectedCleanMsg: "Message",
-		},
-		{
-			name:             "Session with branch containing slash",
-			message:          "[test-session|feature/new-feature] Work in progress",
-			expectedSession:  "test-session",
-			expectedBranch:   "feature/new-feature",
-			expectedCleanMsg: "Work in progress",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			session, branch, cleanMsg := extractSessionInfo(tt.message)
-			if session != tt.expectedSession {
-				t.Errorf("extractSessionInfo(%q) session = %q, want %q", tt.message, session, tt.expectedSession)
-			}
-			if branch != tt.expectedBranch {
-				t.Errorf("extractSessionInfo(%q) branch = %q, want %q", tt.message, branch, tt.expectedBranch)
-			}
-			if cleanMsg != tt.expectedCleanMsg {
-				t.Errorf("extractSessionInfo(%q) cleanMsg = %q, want %q", tt.message, cleanMsg, tt.expectedCleanMsg)
-			}
-		})
-	}
-}
-
 func TestPlaySoundAsync_WithSoundFile(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Notifications.Desktop.Sound = true
@@ -507,7 +322,7 @@ func TestSendDesktop_ClickToFocusWithBeeepFallback(t *testing.T) {
 
 	// Should work regardless of terminal-notifier availability
 	// Will use terminal-notifier if available, otherwise beeep
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "[fallback-test] Testing fallback", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Title: "✅ Completed [fallback-test]", Body: "Testing fallback"}, "", "")
 	// Error acceptable in CI where neither may work
 	_ = err
 }
@@ -645,7 +460,7 @@ func TestSendDesktop_FallbackWhenTerminalNotifierFails(t *testing.T) {
 	n := New(cfg)
 
 	// Should not return error - should fall back to beeep
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "[test] Fallback test", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Title: "✅ Completed [test]", Body: "Fallback test"}, "", "")
 	// Error is acceptable in CI, but should not panic
 	_ = err
 }
@@ -659,7 +474,7 @@ func TestSendDesktop_ClickToFocusDisabledUsesBeeep(t *testing.T) {
 	n := New(cfg)
 
 	// Should use beeep path even on macOS
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "[test] Beeep path test", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Title: "✅ Completed [test]", Body: "Beeep path test"}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
@@ -767,7 +582,7 @@ func TestSendDesktop_AppIconNotFound(t *testing.T) {
 	n := New(cfg)
 
 	// Should handle missing icon gracefully
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "[test] Icon test", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Title: "✅ Completed [test]", Body: "Icon test"}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
@@ -781,7 +596,7 @@ func TestSendDesktop_EmptyMessage(t *testing.T) {
 	n := New(cfg)
 
 	// Empty message should still work
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
@@ -796,7 +611,7 @@ func TestSendDesktop_VeryLongMessage(t *testing.T) {
 
 	// Very long message
 	longMessage := "[test-session] " + strings.Repeat("This is a very long message. ", 100)
-	err := n.SendDesktop(analyzer.StatusTaskComplete, longMessage, "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: longMessage}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
@@ -811,7 +626,7 @@ func TestSendDesktop_SpecialCharactersInMessage(t *testing.T) {
 
 	// Message with special characters
 	specialMessage := "[test] Message with \"quotes\", 'apostrophes', <brackets>, & ampersand, \n newline"
-	err := n.SendDesktop(analyzer.StatusTaskComplete, specialMessage, "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: specialMessage}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
@@ -826,38 +641,11 @@ func TestSendDesktop_UnicodeMessage(t *testing.T) {
 
 	// Unicode message
 	unicodeMessage := "[тест] Сообщение на русском 你好 🎉 émojis"
-	err := n.SendDesktop(analyzer.StatusTaskComplete, unicodeMessage, "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: unicodeMessage}, "", "")
 	// Error acceptable in CI
 	_ = err
 }
 
-func TestExtractSessionInfo_Unicode(t *testing.T) {
-	tests := []struct {
-		message          string
-		expectedSession  string
-		expectedBranch   string
-		expectedCleanMsg string
-	}{
-		{"[тест-сессия] Сообщение", "тест-сессия", "", "Сообщение"},
-		{"[日本語] Japanese text", "日本語", "", "Japanese text"},
-		{"[émoji-🎉] Fun message", "émoji-🎉", "", "Fun message"},
-		{"[тест|ветка] С веткой", "тест", "ветка", "С веткой"},
-	}
-
-	for _, tt := range tests {
-		session, branch, cleanMsg := extractSessionInfo(tt.message)
-		if session != tt.expectedSession {
-			t.Errorf("extractSessionInfo(%q) session = %q, want %q", tt.message, session, tt.expectedSession)
-		}
-		if branch != tt.expectedBranch {
-			t.Errorf("extractSessionInfo(%q) branch = %q, want %q", tt.message, branch, tt.expectedBranch)
-		}
-		if cleanMsg != tt.expectedCleanMsg {
-			t.Errorf("extractSessionInfo(%q) cleanMsg = %q, want %q", tt.message, cleanMsg, tt.expectedCleanMsg)
-		}
-	}
-}
-
 // Note: Concurrent SendDesktop is not tested because beeep.AppName is a global
 // variable and the beeep library is not thread-safe. In practice, notifications
 // are sent sequentially from hooks, so this is not a real use case.
@@ -889,7 +677,7 @@ func TestSendDesktop_CallsBell(t *testing.T) {
 	n := New(cfg)
 
 	// Should not panic — bell is sent, then returns nil for disabled desktop
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "test message", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: "test message"}, "", "")
 	if err != nil {
 		t.Errorf("Expected nil error when disabled, got: %v", err)
 	}
@@ -905,7 +693,7 @@ func TestSendDesktop_BellDisabledByConfig(t *testing.T) {
 	n := New(cfg)
 
 	// Should not panic — bell is skipped, then returns nil for disabled desktop
-	err := n.SendDesktop(analyzer.StatusTaskComplete, "test message", "", "")
+	err := n.SendDesktop(analyzer.StatusTaskComplete, Message{Body: "test message"}, "", "")
 	if err != nil {
 		t.Errorf("Expected nil error when disabled, got: %v", err)
 	}
@@ -960,39 +748,6 @@ func TestIsTimeSensitiveStatus(t *testing.T) {
 	}
 }
 
-// === Tests for subtitle building ===
-
-func TestSendDesktop_SubtitleFromBranchAndFolder(t *testing.T) {
-	// Verify that subtitle is correctly extracted from the "[session|branch folder] message" format
-	_, gitBranch, _ := extractSessionInfo("[peak|main notification_plugin_go] Task complete")
-	if gitBranch != "main notification_plugin_go" {
-		t.Errorf("expected gitBranch='main notification_plugin_go', got %q", gitBranch)
-	}
-
-	// Verify subtitle construction: "main · notification_plugin_go"
-	parts := strings.SplitN(gitBranch, " ", 2)
-	if len(parts) != 2 {
-		t.Fatalf("expected 2 parts, got %d", len(parts))
-	}
-	subtitle := parts[0] + " \u00B7 " + parts[1]
-	if subtitle != "main · notification_plugin_go" {
-		t.Errorf("subtitle = %q, want 'main · notification_plugin_go'", subtitle)
-	}
-}
-
-func TestSendDesktop_SubtitleBranchOnly(t *testing.T) {
-	// When git branch has no space (no folder), subtitle is just the branch
-	_, gitBranch, _ := extractSessionInfo("[peak|develop] Task complete")
-	if gitBranch != "develop" {
-		t.Errorf("expected gitBranch='develop', got %q", gitBranch)
-	}
-
-	parts := strings.SplitN(gitBranch, " ", 2)
-	if len(parts) != 1 {
-		t.Errorf("expected 1 part for branch-only, got %d", len(parts))
-	}
-}
-
 // === Tests for buildFocusScript and helpers ===
 
 func TestBuildFocusScript_EmptyCWD(t *testing.T) {
@@ -0,0 +1,22 @@
+package notifier
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRemindersUnsupported is returned where no daemon can hold reminder timers
+var ErrRemindersUnsupported = errors.New("reminders require the Linux notification daemon")
+
+// ReminderRequest describes reminders for a prompt that may stay unanswered
+type ReminderRequest struct {
+	SessionID      string
+	Status         string
+	Message        string // Notification body without the session prefix
+	CWD            string
+	TranscriptPath string
+	PluginRoot     string
+	NotifiedAt     int64           // Unix time of the original notification
+	Backoff        []time.Duration // Delay before each reminder
+	EscalateAfter  int             // From this reminder on (1-based) also use the webhook, 0 = never
+}
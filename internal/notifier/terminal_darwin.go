@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/777genius/claude-notifications/internal/analyzer"
 	"github.com/777genius/claude-notifications/internal/config"
 	"github.com/777genius/claude-notifications/internal/platform"
 )
@@ -235,7 +236,7 @@ func EnsureClaudeNotificationsApp() error {
 
 // sendLinuxNotification is a stub for macOS.
 // On macOS, click-to-focus is handled via terminal-notifier.
-func sendLinuxNotification(title, body, appIcon string, cfg *config.Config, cwd string) error {
+func sendLinuxNotification(title, body, appIcon string, cfg *config.Config, status analyzer.Status, sessionID, cwd string) error {
 	return fmt.Errorf("Linux notifications not available on macOS")
 }
 
@@ -253,3 +254,43 @@ func StartDaemon() bool {
 func StopDaemon() error {
 	return nil
 }
+
+// ScheduleReminder is not supported on macOS (no daemon to hold the timers).
+func ScheduleReminder(req ReminderRequest) error {
+	return ErrRemindersUnsupported
+}
+
+// CancelReminders is a no-op on macOS.
+func CancelReminders(sessionID string) error {
+	return nil
+}
+
+// PublishEvent is a no-op on macOS (no daemon to pass events on).
+func PublishEvent(ev Event) error {
+	return nil
+}
+
+// CheckDuplicate is not supported on non-Linux platforms; hooks use the lock files.
+func CheckDuplicate(sessionID, hookEvent string) (ClaimResult, error) {
+	return ClaimResult{}, ErrClaimsUnsupported
+}
+
+// ClaimNotification is not supported on non-Linux platforms; hooks use the state files.
+func ClaimNotification(claim NotificationClaim) (ClaimResult, error) {
+	return ClaimResult{}, ErrClaimsUnsupported
+}
+
+// ForgetSession is a no-op on non-Linux platforms.
+func ForgetSession(sessionID string) error {
+	return nil
+}
+
+// QueueAggregate is not supported on non-Linux platforms (no daemon to hold the window).
+func QueueAggregate(req AggregateRequest) error {
+	return ErrAggregateUnsupported
+}
+
+// playViaDaemon is not available on macOS; sounds are played in-process.
+func playViaDaemon(soundPath string, volume float64, device string) error {
+	return fmt.Errorf("sound playback via the daemon is only available on Linux")
+}
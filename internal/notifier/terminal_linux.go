@@ -7,7 +7,9 @@ package notifier
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
+	"github.com/777genius/claude-notifications/internal/analyzer"
 	"github.com/777genius/claude-notifications/internal/config"
 	"github.com/777genius/claude-notifications/internal/daemon"
 	"github.com/777genius/claude-notifications/internal/logging"
@@ -42,7 +44,7 @@ func EnsureClaudeNotificationsApp() error {
 // When clickToFocus is enabled, uses the daemon for click-to-focus support.
 // Falls back to beeep when daemon is unavailable.
 // cwd is the working directory of the project; used for window-specific focus. May be empty.
-func sendLinuxNotification(title, body, appIcon string, cfg *config.Config, cwd string) error {
+func sendLinuxNotification(title, body, appIcon string, cfg *config.Config, status analyzer.Status, sessionID, cwd string) error {
 	// If click-to-focus is disabled, use beeep directly
 	if !cfg.Notifications.Desktop.ClickToFocus {
 		logging.Debug("Click-to-focus disabled, using beeep directly")
@@ -50,7 +52,7 @@ func sendLinuxNotification(title, body, appIcon string, cfg *config.Config, cwd
 	}
 
 	// Try to use daemon for click-to-focus
-	if err := sendViaDaemon(title, body, cwd); err == nil {
+	if err := sendViaDaemon(title, body, status, sessionID, cwd); err == nil {
 		logging.Debug("Notification sent via daemon with click-to-focus support")
 		return nil
 	} else {
@@ -64,7 +66,7 @@ func sendLinuxNotification(title, body, appIcon string, cfg *config.Config, cwd
 // sendViaDaemon sends a notification via the background daemon.
 // Returns an error if daemon is not available or fails.
 // cwd is used to extract the project folder name for window-specific focus.
-func sendViaDaemon(title, body, cwd string) error {
+func sendViaDaemon(title, body string, status analyzer.Status, sessionID, cwd string) error {
 	// Start daemon on-demand (no-op if already running)
 	if !daemon.StartDaemonOnDemand() {
 		return daemon.ErrDaemonNotAvailable
@@ -83,7 +85,14 @@ func sendViaDaemon(title, body, cwd string) error {
 	}
 
 	// Send notification with 30 second timeout, auto-detect terminal
-	_, err = client.SendNotification(title, body, "", folderName, 30)
+	_, err = client.SendNotification(&daemon.NotifyRequest{
+		Title:       title,
+		Body:        body,
+		FocusFolder: folderName,
+		Timeout:     30,
+		SessionID:   sessionID,
+		Status:      string(status),
+	})
 	return err
 }
 
@@ -103,3 +112,171 @@ func StartDaemon() bool {
 func StopDaemon() error {
 	return daemon.StopDaemon()
 }
+
+// ScheduleReminder hands reminders for an unanswered prompt to the daemon,
+// starting it on demand. The daemon owns the timers so the hook process can exit.
+func ScheduleReminder(req ReminderRequest) error {
+	if !daemon.StartDaemonOnDemand() {
+		return daemon.ErrDaemonNotAvailable
+	}
+
+	client, err := daemon.NewClient()
+	if err != nil {
+		return err
+	}
+
+	return client.ScheduleReminder(&daemon.RemindRequest{
+		SessionID:      req.SessionID,
+		Status:         req.Status,
+		Message:        req.Message,
+		CWD:            req.CWD,
+		TranscriptPath: req.TranscriptPath,
+		PluginRoot:     req.PluginRoot,
+		NotifiedAt:     req.NotifiedAt,
+		Backoff:        req.Backoff,
+		EscalateAfter:  req.EscalateAfter,
+	})
+}
+
+// CancelReminders drops pending reminders of a session.
+// Does not start the daemon: if it is not running, nothing is pending.
+func CancelReminders(sessionID string) error {
+	if !daemon.IsDaemonStarted() {
+		return nil
+	}
+
+	client, err := daemon.NewClient()
+	if err != nil {
+		return err
+	}
+	return client.CancelReminders(sessionID)
+}
+
+// PublishEvent passes a hook's event on to `watch` clients.
+// Does not start the daemon: if it is not running, nobody is watching.
+func PublishEvent(ev Event) error {
+	if !daemon.IsDaemonStarted() {
+		return nil
+	}
+
+	client, err := daemon.NewClient()
+	if err != nil {
+		return nil
+	}
+
+	return client.PublishEvent(&daemon.Event{
+		Type:      daemon.EventType(ev.Type),
+		Time:      time.Now(),
+		Event:     ev.Event,
+		SessionID: ev.SessionID,
+		Status:    ev.Status,
+		Message:   ev.Message,
+		Reason:    ev.Reason,
+		CWD:       ev.CWD,
+		Channels:  ev.Channels,
+	})
+}
+
+// CheckDuplicate asks the daemon whether the hook event was handled for the session a
+// moment ago, starting the daemon on demand so the claim that follows finds it
+func CheckDuplicate(sessionID, hookEvent string) (ClaimResult, error) {
+	if !daemon.StartDaemonOnDemand() {
+		return ClaimResult{}, daemon.ErrDaemonNotAvailable
+	}
+
+	client, err := daemon.NewClient()
+	if err != nil {
+		return ClaimResult{}, err
+	}
+
+	resp, err := client.CheckDuplicate(&daemon.ClaimRequest{SessionID: sessionID, HookEvent: hookEvent})
+	if err != nil {
+		return ClaimResult{}, err
+	}
+	return ClaimResult{Duplicate: resp.Duplicate, Reason: resp.Reason}, nil
+}
+
+// ClaimNotification checks a notification against the daemon's dedup and cooldown state
+// and records it there if it may be sent. Does not start the daemon.
+func ClaimNotification(claim NotificationClaim) (ClaimResult, error) {
+	client, err := daemon.NewClient()
+	if err != nil {
+		return ClaimResult{}, err
+	}
+
+	resp, err := client.ClaimNotification(&daemon.ClaimRequest{
+		SessionID:         claim.SessionID,
+		HookEvent:         claim.HookEvent,
+		Message:           claim.Message,
+		TaskComplete:      claim.TaskComplete,
+		InputRequest:      claim.InputRequest,
+		CooldownAfterAny:  claim.CooldownAfterAny,
+		CooldownAfterTask: claim.CooldownAfterTask,
+		DuplicateWindow:   claim.DuplicateWindow,
+	})
+	if err != nil {
+		return ClaimResult{}, err
+	}
+	return ClaimResult{Allowed: resp.Allowed, Duplicate: resp.Duplicate, Reason: resp.Reason}, nil
+}
+
+// ForgetSession drops a finished session's dedup and cooldown state in the daemon.
+// Does not start the daemon: if it is not running, it holds no state.
+func ForgetSession(sessionID string) error {
+	if !daemon.IsDaemonStarted() {
+		return nil
+	}
+
+	client, err := daemon.NewClient()
+	if err != nil {
+		return err
+	}
+	return client.ForgetSession(sessionID)
+}
+
+// QueueAggregate hands a notification to the daemon's aggregation window, starting the
+// daemon on demand. The daemon delivers it with the others of the window.
+func QueueAggregate(req AggregateRequest) error {
+	if !daemon.StartDaemonOnDemand() {
+		return daemon.ErrDaemonNotAvailable
+	}
+
+	client, err := daemon.NewClient()
+	if err != nil {
+		return err
+	}
+
+	return client.Aggregate(&daemon.AggregateRequest{
+		SessionID:   req.SessionID,
+		Status:      req.Status,
+		Message:     req.Message,
+		Title:       req.Rendered.Title,
+		Subtitle:    req.Rendered.Subtitle,
+		Body:        req.Rendered.Body,
+		WebhookText: req.WebhookText,
+		CWD:         req.CWD,
+		Desktop:     req.Desktop,
+		Webhook:     req.Webhook,
+		Webhooks:    req.Webhooks,
+		Sound:       req.Sound,
+		Quiet:       req.Quiet,
+		PluginRoot:  req.PluginRoot,
+		Window:      req.Window,
+	})
+}
+
+// playViaDaemon hands a sound to the running daemon, which plays it from its long-lived
+// audio context. Does not start the daemon; the caller plays the sound itself on error.
+func playViaDaemon(soundPath string, volume float64, device string) error {
+	path, err := filepath.Abs(soundPath)
+	if err != nil {
+		return err
+	}
+
+	client, err := daemon.NewClient()
+	if err != nil {
+		return err
+	}
+
+	return client.PlaySound(&daemon.PlayRequest{Path: path, Volume: volume, Device: device})
+}
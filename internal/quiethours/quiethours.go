@@ -0,0 +1,127 @@
+// ABOUTME: Quiet hours: evaluates the weekly do-not-disturb schedule and the manual `dnd` override.
+// ABOUTME: The override is a small JSON file in the XDG state dir shared by all hook processes.
+package quiethours
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/config"
+)
+
+// Override is a manual do-not-disturb setting made with `claude-notifications dnd`
+type Override struct {
+	Quiet bool      `json:"quiet"`           // true = quiet (dnd on/until), false = not quiet (dnd off)
+	Until time.Time `json:"until,omitempty"` // Zero = until changed again
+	SetAt time.Time `json:"set_at"`
+}
+
+// ActiveAt reports whether the override still applies at now
+func (o *Override) ActiveAt(now time.Time) bool {
+	return o != nil && (o.Until.IsZero() || now.Before(o.Until))
+}
+
+// State is the do-not-disturb decision for a moment
+type State struct {
+	Quiet  bool
+	Manual bool      // Decided by the `dnd` override rather than the schedule
+	Until  time.Time // When the state ends (zero = not known / until changed)
+}
+
+// Reason describes why notifications are quiet, for logs and the history
+func (s State) Reason() string {
+	source := "quiet hours"
+	if s.Manual {
+		source = "do-not-disturb"
+	}
+	if s.Until.IsZero() {
+		return source
+	}
+	return fmt.Sprintf("%s until %s", source, s.Until.Format("15:04"))
+}
+
+// Evaluate decides whether notifications are quiet at now.
+// An active override wins over the schedule; the schedule only counts when quietHours.enabled is set.
+func Evaluate(cfg *config.Config, override *Override, now time.Time) State {
+	if override.ActiveAt(now) {
+		return State{Quiet: override.Quiet, Manual: true, Until: override.Until}
+	}
+	if !cfg.Notifications.QuietHours.Enabled {
+		return State{}
+	}
+	if end, ok := ScheduledEnd(cfg, now); ok {
+		return State{Quiet: true, Until: end}
+	}
+	return State{}
+}
+
+// ScheduledEnd returns the end of the schedule window now falls into, if any.
+// Overlapping windows are not merged: the first matching window's end is returned.
+func ScheduledEnd(cfg *config.Config, now time.Time) (time.Time, bool) {
+	now = now.In(cfg.GetQuietHoursLocation())
+	for _, w := range cfg.Notifications.QuietHours.Windows {
+		if end, ok := w.EndAfter(now); ok {
+			return end, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NextClock returns the next time the clock reads hour:minute in loc, strictly after now
+func NextClock(now time.Time, hour, minute int, loc *time.Location) time.Time {
+	now = now.In(loc)
+	t := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, loc)
+	if !t.After(now) {
+		t = time.Date(now.Year(), now.Month(), now.Day()+1, hour, minute, 0, 0, loc)
+	}
+	return t
+}
+
+// LoadOverride reads the override file. A missing file means no override.
+func LoadOverride(path string) (*Override, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dnd override: %w", err)
+	}
+
+	var o Override
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("failed to parse dnd override: %w", err)
+	}
+	return &o, nil
+}
+
+// SaveOverride writes the override file atomically
+func SaveOverride(path string, o Override) error {
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal dnd override: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write dnd override: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write dnd override: %w", err)
+	}
+	return nil
+}
+
+// ClearOverride removes the override file
+func ClearOverride(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove dnd override: %w", err)
+	}
+	return nil
+}
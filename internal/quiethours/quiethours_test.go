@@ -0,0 +1,141 @@
+package quiethours
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newScheduleConfig returns a config with quiet hours enabled in UTC
+func newScheduleConfig(windows ...config.QuietWindow) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Notifications.QuietHours = config.QuietHoursConfig{
+		Enabled:  true,
+		Timezone: "UTC",
+		Windows:  windows,
+	}
+	return cfg
+}
+
+func at(day, hour, minute int) time.Time {
+	// 2026-03-02 is a Monday
+	return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestScheduledEnd(t *testing.T) {
+	night := config.QuietWindow{Start: "22:00", End: "07:00"}
+	lunch := config.QuietWindow{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "12:00", End: "13:00"}
+	weekend := config.QuietWindow{Days: []string{"Sat"}, Start: "00:00", End: "00:00"}
+
+	tests := []struct {
+		name    string
+		window  config.QuietWindow
+		now     time.Time
+		wantEnd time.Time
+		wantOK  bool
+	}{
+		{"before night window", night, at(2, 21, 59), time.Time{}, false},
+		{"night window start", night, at(2, 22, 0), at(3, 7, 0), true},
+		{"night window after midnight", night, at(3, 6, 59), at(3, 7, 0), true},
+		{"night window end is exclusive", night, at(3, 7, 0), time.Time{}, false},
+		{"weekday window on monday", lunch, at(2, 12, 30), at(2, 13, 0), true},
+		{"weekday window on saturday", lunch, at(7, 12, 30), time.Time{}, false},
+		{"full day window", weekend, at(7, 18, 0), at(8, 0, 0), true},
+		{"full day window next day", weekend, at(8, 0, 0), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, ok := ScheduledEnd(newScheduleConfig(tt.window), tt.now)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.True(t, tt.wantEnd.Equal(end), "end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestScheduledEnd_DaysOfWrappingWindow(t *testing.T) {
+	// Friday night window covers early Saturday but not early Friday
+	cfg := newScheduleConfig(config.QuietWindow{Days: []string{"fri"}, Start: "23:00", End: "08:00"})
+
+	_, ok := ScheduledEnd(cfg, at(7, 3, 0))
+	assert.True(t, ok, "saturday 03:00 is inside friday's window")
+
+	_, ok = ScheduledEnd(cfg, at(6, 3, 0))
+	assert.False(t, ok, "friday 03:00 would need a thursday window")
+}
+
+func TestScheduledEnd_Timezone(t *testing.T) {
+	cfg := newScheduleConfig(config.QuietWindow{Start: "22:00", End: "07:00"})
+	cfg.Notifications.QuietHours.Timezone = "Asia/Tokyo" // UTC+9
+
+	// 14:00 UTC is 23:00 in Tokyo
+	_, ok := ScheduledEnd(cfg, at(2, 14, 0))
+	assert.True(t, ok)
+
+	_, ok = ScheduledEnd(cfg, at(2, 23, 0))
+	assert.False(t, ok, "23:00 UTC is 08:00 in Tokyo")
+}
+
+func TestEvaluate(t *testing.T) {
+	cfg := newScheduleConfig(config.QuietWindow{Start: "22:00", End: "07:00"})
+	night := at(2, 23, 0)
+	day := at(2, 10, 0)
+
+	s := Evaluate(cfg, nil, night)
+	assert.True(t, s.Quiet)
+	assert.False(t, s.Manual)
+	assert.Equal(t, "quiet hours until 07:00", s.Reason())
+
+	assert.False(t, Evaluate(cfg, nil, day).Quiet)
+
+	// Manual off wins over the schedule
+	off := &Override{Quiet: false, Until: at(3, 7, 0)}
+	assert.False(t, Evaluate(cfg, off, night).Quiet)
+
+	// Manual on works even with the schedule disabled
+	cfg.Notifications.QuietHours.Enabled = false
+	on := &Override{Quiet: true}
+	s = Evaluate(cfg, on, day)
+	assert.True(t, s.Quiet)
+	assert.True(t, s.Manual)
+	assert.Equal(t, "do-not-disturb", s.Reason())
+
+	// Expired overrides are ignored
+	expired := &Override{Quiet: true, Until: at(2, 9, 0)}
+	assert.False(t, Evaluate(cfg, expired, day).Quiet)
+}
+
+func TestNextClock(t *testing.T) {
+	assert.Equal(t, at(2, 14, 0), NextClock(at(2, 10, 0), 14, 0, time.UTC))
+	assert.Equal(t, at(3, 14, 0), NextClock(at(2, 15, 0), 14, 0, time.UTC))
+	assert.Equal(t, at(3, 14, 0), NextClock(at(2, 14, 0), 14, 0, time.UTC))
+}
+
+func TestOverrideFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "dnd.json")
+
+	o, err := LoadOverride(path)
+	require.NoError(t, err)
+	assert.Nil(t, o)
+
+	want := Override{Quiet: true, Until: at(2, 14, 0), SetAt: at(2, 10, 0)}
+	require.NoError(t, SaveOverride(path, want))
+
+	o, err = LoadOverride(path)
+	require.NoError(t, err)
+	require.NotNil(t, o)
+	assert.True(t, want.Until.Equal(o.Until))
+	assert.True(t, o.Quiet)
+
+	require.NoError(t, ClearOverride(path))
+	require.NoError(t, ClearOverride(path), "clearing twice is fine")
+	o, err = LoadOverride(path)
+	require.NoError(t, err)
+	assert.Nil(t, o)
+}
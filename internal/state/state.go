@@ -20,6 +20,12 @@ type SessionState struct {
 	LastNotificationTime    int64  `json:"last_notification_ts,omitempty"`
 	LastNotificationStatus  string `json:"last_notification_status,omitempty"`
 	LastNotificationMessage string `json:"last_notification_message,omitempty"`
+	LastTurnStartTime       int64  `json:"last_turn_start_ts,omitempty"`
+	SessionStartTime        int64  `json:"session_start_ts,omitempty"`
+	SessionSource           string `json:"session_source,omitempty"`
+	ReminderPending         bool   `json:"reminder_pending,omitempty"`
+	ReminderStatus          string `json:"reminder_status,omitempty"`
+	ReminderSince           int64  `json:"reminder_since_ts,omitempty"`
 	CWD                     string `json:"cwd"`
 }
 
@@ -35,6 +41,14 @@ func NewManager() *Manager {
 	}
 }
 
+// NewManagerWithDir creates a state manager that keeps its files in dir
+// instead of the system temp directory (used by replay to stay isolated)
+func NewManagerWithDir(dir string) *Manager {
+	return &Manager{
+		tempDir: dir,
+	}
+}
+
 // getStatePath returns the path to the state file for a session
 func (m *Manager) getStatePath(sessionID string) string {
 	return filepath.Join(m.tempDir, fmt.Sprintf("claude-session-state-%s.json", sessionID))
@@ -129,6 +143,107 @@ func (m *Manager) UpdateTaskComplete(sessionID string) error {
 	return m.Save(state)
 }
 
+// UpdateTurnStart records the start of a new user turn (UserPromptSubmit)
+func (m *Manager) UpdateTurnStart(sessionID, cwd string) error {
+	state, err := m.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		state = &SessionState{
+			SessionID: sessionID,
+		}
+	}
+
+	state.LastTurnStartTime = platform.CurrentTimestamp()
+	if cwd != "" {
+		state.CWD = cwd
+	}
+
+	return m.Save(state)
+}
+
+// RegisterSession records session start information (SessionStart).
+// source is the SessionStart source: "startup", "resume", "clear" or "compact".
+// A "compact" restart keeps the original session start time.
+func (m *Manager) RegisterSession(sessionID, cwd, source string) error {
+	state, err := m.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		state = &SessionState{
+			SessionID: sessionID,
+		}
+	}
+
+	if state.SessionStartTime == 0 || source != "compact" {
+		state.SessionStartTime = platform.CurrentTimestamp()
+	}
+	state.SessionSource = source
+	state.CWD = cwd
+
+	return m.Save(state)
+}
+
+// ClearInteractiveTool clears the last interactive tool once the user has
+// answered it (PostToolUse for AskUserQuestion/ExitPlanMode)
+func (m *Manager) ClearInteractiveTool(sessionID string) error {
+	state, err := m.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if state == nil || state.LastInteractiveTool == "" {
+		return nil
+	}
+
+	state.LastInteractiveTool = ""
+
+	return m.Save(state)
+}
+
+// SetReminderPending marks the session as waiting on the user since the given
+// notification, so the daemon knows reminders for it are still wanted
+func (m *Manager) SetReminderPending(sessionID string, status analyzer.Status, since int64) error {
+	state, err := m.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		state = &SessionState{
+			SessionID: sessionID,
+		}
+	}
+
+	state.ReminderPending = true
+	state.ReminderStatus = string(status)
+	state.ReminderSince = since
+
+	return m.Save(state)
+}
+
+// ClearReminderPending marks the pending prompt as answered (no-op if nothing is pending)
+func (m *Manager) ClearReminderPending(sessionID string) error {
+	state, err := m.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if state == nil || !state.ReminderPending {
+		return nil
+	}
+
+	state.ReminderPending = false
+	state.ReminderStatus = ""
+	state.ReminderSince = 0
+
+	return m.Save(state)
+}
+
 // ShouldSuppressQuestion checks if a question notification should be suppressed
 // due to being within the cooldown window after a task completion
 func (m *Manager) ShouldSuppressQuestion(sessionID string, cooldownSeconds int) (bool, error) {
@@ -218,11 +333,11 @@ func (m *Manager) ShouldSuppressQuestionAfterAnyNotification(sessionID string, c
 	return shouldSuppress, nil
 }
 
-// normalizeMessage normalizes a message for comparison by:
+// NormalizeMessage normalizes a message for duplicate comparison by:
 // - Trimming whitespace
 // - Removing trailing dots
 // - Converting to lowercase
-func normalizeMessage(msg string) string {
+func NormalizeMessage(msg string) string {
 	msg = strings.TrimSpace(msg)
 	msg = strings.TrimRight(msg, ".")
 	return strings.ToLower(msg)
@@ -252,5 +367,5 @@ func (m *Manager) IsDuplicateMessage(sessionID string, message string, windowSec
 	}
 
 	// Compare normalized messages
-	return normalizeMessage(message) == normalizeMessage(state.LastNotificationMessage), nil
+	return NormalizeMessage(message) == NormalizeMessage(state.LastNotificationMessage), nil
 }
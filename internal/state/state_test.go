@@ -181,6 +181,136 @@ func TestManager_UpdateTaskComplete_ExistingState(t *testing.T) {
 	assert.Equal(t, "ExitPlanMode", state.LastInteractiveTool)
 }
 
+// === Lifecycle Tests ===
+
+func TestManager_UpdateTurnStart(t *testing.T) {
+	mgr := NewManager()
+	sessionID := "test-turn-start"
+	defer func() { _ = mgr.Delete(sessionID) }()
+
+	err := mgr.UpdateTurnStart(sessionID, "/project")
+	require.NoError(t, err)
+
+	state, err := mgr.Load(sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	assert.Greater(t, state.LastTurnStartTime, int64(0))
+	assert.Equal(t, "/project", state.CWD)
+}
+
+func TestManager_RegisterSession(t *testing.T) {
+	mgr := NewManager()
+	sessionID := "test-register"
+	defer func() { _ = mgr.Delete(sessionID) }()
+
+	err := mgr.RegisterSession(sessionID, "/project", "startup")
+	require.NoError(t, err)
+
+	state, err := mgr.Load(sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	assert.Greater(t, state.SessionStartTime, int64(0))
+	assert.Equal(t, "startup", state.SessionSource)
+	assert.Equal(t, "/project", state.CWD)
+}
+
+func TestManager_RegisterSession_CompactKeepsStartTime(t *testing.T) {
+	mgr := NewManager()
+	sessionID := "test-register-compact"
+	defer func() { _ = mgr.Delete(sessionID) }()
+
+	err := mgr.Save(&SessionState{SessionID: sessionID, SessionStartTime: 12345})
+	require.NoError(t, err)
+
+	err = mgr.RegisterSession(sessionID, "", "compact")
+	require.NoError(t, err)
+
+	state, err := mgr.Load(sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	assert.Equal(t, int64(12345), state.SessionStartTime)
+	assert.Equal(t, "compact", state.SessionSource)
+}
+
+func TestManager_ClearInteractiveTool(t *testing.T) {
+	mgr := NewManager()
+	sessionID := "test-clear-tool"
+	defer func() { _ = mgr.Delete(sessionID) }()
+
+	err := mgr.UpdateInteractiveTool(sessionID, "AskUserQuestion", "/project")
+	require.NoError(t, err)
+
+	err = mgr.ClearInteractiveTool(sessionID)
+	require.NoError(t, err)
+
+	state, err := mgr.Load(sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	assert.Empty(t, state.LastInteractiveTool)
+	assert.Equal(t, "/project", state.CWD)
+}
+
+func TestManager_ClearInteractiveTool_NoState(t *testing.T) {
+	mgr := NewManager()
+
+	err := mgr.ClearInteractiveTool("non-existent-clear")
+	assert.NoError(t, err)
+}
+
+// === Reminder Tests ===
+
+func TestManager_SetReminderPending(t *testing.T) {
+	mgr := NewManager()
+	sessionID := "test-reminder-set"
+	defer func() { _ = mgr.Delete(sessionID) }()
+
+	err := mgr.SetReminderPending(sessionID, analyzer.StatusQuestion, 12345)
+	require.NoError(t, err)
+
+	state, err := mgr.Load(sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	assert.True(t, state.ReminderPending)
+	assert.Equal(t, string(analyzer.StatusQuestion), state.ReminderStatus)
+	assert.Equal(t, int64(12345), state.ReminderSince)
+}
+
+func TestManager_ClearReminderPending(t *testing.T) {
+	mgr := NewManager()
+	sessionID := "test-reminder-clear"
+	defer func() { _ = mgr.Delete(sessionID) }()
+
+	err := mgr.SetReminderPending(sessionID, analyzer.StatusPlanReady, 12345)
+	require.NoError(t, err)
+
+	err = mgr.ClearReminderPending(sessionID)
+	require.NoError(t, err)
+
+	state, err := mgr.Load(sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	assert.False(t, state.ReminderPending)
+	assert.Empty(t, state.ReminderStatus)
+	assert.Zero(t, state.ReminderSince)
+}
+
+func TestManager_ClearReminderPending_NoState(t *testing.T) {
+	mgr := NewManager()
+
+	err := mgr.ClearReminderPending("non-existent-reminder")
+	assert.NoError(t, err)
+
+	state, err := mgr.Load("non-existent-reminder")
+	require.NoError(t, err)
+	assert.Nil(t, state, "clearing must not create state")
+}
+
 // === UpdateLastNotification Tests ===
 
 func TestManager_UpdateLastNotification_NewState(t *testing.T) {
@@ -340,26 +340,67 @@ func extractExitPlanModePlan(messages []jsonl.Message) string {
 
 // calculateDuration calculates duration between last user and last assistant messages
 func calculateDuration(messages []jsonl.Message) string {
+	duration, ok := turnDuration(messages)
+	if !ok {
+		return ""
+	}
+
+	return formatDuration(duration)
+}
+
+// turnDuration returns the time between the last user and last assistant messages.
+// ok is false if either timestamp is missing or invalid, or the order is reversed.
+func turnDuration(messages []jsonl.Message) (time.Duration, bool) {
 	userTS := jsonl.GetLastUserTimestamp(messages)
 	assistantTS := jsonl.GetLastAssistantTimestamp(messages)
 
 	if userTS == "" || assistantTS == "" {
-		return ""
+		return 0, false
 	}
 
 	userTime, err1 := time.Parse(time.RFC3339, userTS)
 	assistantTime, err2 := time.Parse(time.RFC3339, assistantTS)
 
 	if err1 != nil || err2 != nil {
-		return ""
+		return 0, false
 	}
 
 	duration := assistantTime.Sub(userTime)
 	if duration < 0 {
-		return ""
+		return 0, false
 	}
 
-	return formatDuration(duration)
+	return duration, true
+}
+
+// TurnDurationFromTranscript returns the duration of the current turn recorded in the transcript
+func TurnDurationFromTranscript(transcriptPath string) (time.Duration, bool) {
+	messages, err := jsonl.ParseFile(transcriptPath)
+	if err != nil || len(messages) == 0 {
+		return 0, false
+	}
+	return turnDuration(messages)
+}
+
+// TurnDetails are facts about the current turn for message templates
+type TurnDetails struct {
+	Duration   time.Duration  // 0 if unknown
+	ToolCounts map[string]int // Tools used since the last user message
+	Question   string         // Recent AskUserQuestion question, "" if none
+}
+
+// TurnDetailsFromTranscript reads the current turn's duration, tool counts and question from the transcript
+func TurnDetailsFromTranscript(transcriptPath string) TurnDetails {
+	messages, err := jsonl.ParseFile(transcriptPath)
+	if err != nil || len(messages) == 0 {
+		return TurnDetails{}
+	}
+	details := TurnDetails{ToolCounts: countToolsByType(messages)}
+	details.Duration, _ = turnDuration(messages)
+	if question, isRecent := extractAskUserQuestion(messages); isRecent {
+		details.Question = CleanMarkdown(question)
+	}
+	return details
 }
 
 // formatDuration formats duration into human-readable string
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -1175,6 +1176,58 @@ func TestCalculateDuration(t *testing.T) {
 	}
 }
 
+func TestTurnDurationFromTranscript(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	content := `{"type":"user","timestamp":"` + base.Format(time.RFC3339) + `","message":{"role":"user","content":"Do something"}}
+{"type":"assistant","timestamp":"` + base.Add(45*time.Second).Format(time.RFC3339) + `","message":{"role":"assistant","content":[{"type":"text","text":"Done"}]}}
+`
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	duration, ok := TurnDurationFromTranscript(path)
+	if !ok {
+		t.Fatal("expected turn duration to be known")
+	}
+	if duration != 45*time.Second {
+		t.Errorf("got %v, want 45s", duration)
+	}
+
+	if _, ok := TurnDurationFromTranscript(filepath.Join(t.TempDir(), "missing.jsonl")); ok {
+		t.Error("expected unknown duration for missing transcript")
+	}
+}
+
+func TestTurnDetailsFromTranscript(t *testing.T) {
+	base := time.Now().Add(-time.Minute).UTC()
+	ts := func(d time.Duration) string { return base.Add(d).Format(time.RFC3339) }
+	content := `{"type":"user","timestamp":"` + ts(0) + `","message":{"role":"user","content":"Set up the cache"}}
+{"type":"assistant","timestamp":"` + ts(10*time.Second) + `","message":{"role":"assistant","content":[{"type":"tool_use","name":"Edit","input":{}},{"type":"tool_use","name":"Edit","input":{}}]}}
+{"type":"assistant","timestamp":"` + ts(20*time.Second) + `","message":{"role":"assistant","content":[{"type":"tool_use","name":"AskUserQuestion","input":{"questions":[{"question":"Use **Redis**?"}]}}]}}
+`
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	details := TurnDetailsFromTranscript(path)
+	if details.Duration != 20*time.Second {
+		t.Errorf("Duration = %v, want 20s", details.Duration)
+	}
+	if details.ToolCounts["Edit"] != 2 || details.ToolCounts["AskUserQuestion"] != 1 {
+		t.Errorf("ToolCounts = %v, want 2 Edit and 1 AskUserQuestion", details.ToolCounts)
+	}
+	if details.Question != "Use Redis?" {
+		t.Errorf("Question = %q, want %q", details.Question, "Use Redis?")
+	}
+
+	missing := TurnDetailsFromTranscript(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if missing.Duration != 0 || missing.ToolCounts != nil || missing.Question != "" {
+		t.Errorf("expected no details for a missing transcript, got %+v", missing)
+	}
+}
+
 func TestExtractExitPlanModePlan(t *testing.T) {
 	tests := []struct {
 		name     string
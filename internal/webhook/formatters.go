@@ -8,15 +8,16 @@ import (
 	"github.com/777genius/claude-notifications/internal/config"
 )
 
-// Formatter interface for different webhook formats
+// Formatter interface for different webhook formats. project is empty when the
+// caller doesn't know the project (e.g. doctor test messages).
 type Formatter interface {
-	Format(status analyzer.Status, message, sessionID string, statusInfo config.StatusInfo) (interface{}, error)
+	Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error)
 }
 
 // SlackFormatter formats messages for Slack
 type SlackFormatter struct{}
 
-func (f *SlackFormatter) Format(status analyzer.Status, message, sessionID string, statusInfo config.StatusInfo) (interface{}, error) {
+func (f *SlackFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
 	color := getColorForStatus(status)
 
 	return map[string]interface{}{
@@ -37,7 +38,7 @@ func (f *SlackFormatter) Format(status analyzer.Status, message, sessionID strin
 // DiscordFormatter formats messages for Discord with embeds
 type DiscordFormatter struct{}
 
-func (f *DiscordFormatter) Format(status analyzer.Status, message, sessionID string, statusInfo config.StatusInfo) (interface{}, error) {
+func (f *DiscordFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
 	colorInt := getDiscordColorInt(status)
 
 	return map[string]interface{}{
@@ -61,7 +62,7 @@ type TelegramFormatter struct {
 	ChatID string
 }
 
-func (f *TelegramFormatter) Format(status analyzer.Status, message, sessionID string, statusInfo config.StatusInfo) (interface{}, error) {
+func (f *TelegramFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
 	// HTML formatting for Telegram
 	emoji := getEmojiForStatus(status)
 	text := fmt.Sprintf("<b>%s %s</b>\n\n%s\n\n<i>Session: %s</i>",
@@ -85,6 +86,8 @@ func getColorForStatus(status analyzer.Status) string {
 		return "#ffc107" // Yellow/Orange
 	case analyzer.StatusPlanReady:
 		return "#007bff" // Blue
+	case analyzer.StatusPermissionRequest:
+		return "#fd7e14" // Orange
 	default:
 		return "#6c757d" // Gray
 	}
@@ -101,6 +104,8 @@ func getDiscordColorInt(status analyzer.Status) int {
 		return 0xffc107 // Yellow
 	case analyzer.StatusPlanReady:
 		return 0x007bff // Blue
+	case analyzer.StatusPermissionRequest:
+		return 0xfd7e14 // Orange
 	default:
 		return 0x6c757d // Gray
 	}
@@ -117,6 +122,10 @@ func getEmojiForStatus(status analyzer.Status) string {
 		return "❓"
 	case analyzer.StatusPlanReady:
 		return "📋"
+	case analyzer.StatusPermissionRequest:
+		return "🔐"
+	case analyzer.StatusIdlePrompt:
+		return "💤"
 	default:
 		return "ℹ️"
 	}
@@ -125,7 +134,7 @@ func getEmojiForStatus(status analyzer.Status) string {
 // LarkFormatter formats messages for Feishu/Lark with interactive cards
 type LarkFormatter struct{}
 
-func (f *LarkFormatter) Format(status analyzer.Status, message, sessionID string, statusInfo config.StatusInfo) (interface{}, error) {
+func (f *LarkFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
 	return map[string]interface{}{
 		"msg_type": "interactive",
 		"card": map[string]interface{}{
@@ -173,6 +182,8 @@ func getLarkColorTemplate(status analyzer.Status) string {
 		return "red"
 	case analyzer.StatusPlanReady:
 		return "blue"
+	case analyzer.StatusPermissionRequest:
+		return "orange"
 	default:
 		return "grey"
 	}
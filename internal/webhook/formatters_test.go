@@ -19,6 +19,7 @@ func TestSlackFormatterFormat(t *testing.T) {
 		analyzer.StatusTaskComplete,
 		"The task has been completed successfully",
 		"session-123",
+		Project{},
 		statusInfo,
 	)
 
@@ -89,7 +90,7 @@ func TestSlackFormatterColors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(string(tt.status), func(t *testing.T) {
-			result, err := formatter.Format(tt.status, "test", "session-1", statusInfo)
+			result, err := formatter.Format(tt.status, "test", "session-1", Project{}, statusInfo)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
@@ -115,6 +116,7 @@ func TestDiscordFormatterFormat(t *testing.T) {
 		analyzer.StatusQuestion,
 		"What should we do next?",
 		"session-456",
+		Project{},
 		statusInfo,
 	)
 
@@ -200,7 +202,7 @@ func TestDiscordFormatterColors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(string(tt.status), func(t *testing.T) {
-			result, err := formatter.Format(tt.status, "test", "session-1", statusInfo)
+			result, err := formatter.Format(tt.status, "test", "session-1", Project{}, statusInfo)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
@@ -226,6 +228,7 @@ func TestTelegramFormatterFormat(t *testing.T) {
 		analyzer.StatusReviewComplete,
 		"Code review finished",
 		"session-789",
+		Project{},
 		statusInfo,
 	)
 
@@ -303,7 +306,7 @@ func TestTelegramFormatterEmojis(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(string(tt.status), func(t *testing.T) {
-			result, err := formatter.Format(tt.status, "test", "session-1", statusInfo)
+			result, err := formatter.Format(tt.status, "test", "session-1", Project{}, statusInfo)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
@@ -394,6 +397,7 @@ func TestLarkFormatterFormat(t *testing.T) {
 		analyzer.StatusTaskComplete,
 		"The task has been completed successfully",
 		"session-123",
+		Project{},
 		statusInfo,
 	)
 
@@ -505,7 +509,7 @@ func TestLarkFormatterColors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(string(tt.status), func(t *testing.T) {
-			result, err := formatter.Format(tt.status, "test", "session-1", statusInfo)
+			result, err := formatter.Format(tt.status, "test", "session-1", Project{}, statusInfo)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
@@ -530,6 +534,7 @@ func TestLarkFormatterUnknownStatus(t *testing.T) {
 		analyzer.Status("unknown"),
 		"Unknown status",
 		"session-999",
+		Project{},
 		statusInfo,
 	)
 
@@ -0,0 +1,69 @@
+// ABOUTME: Matrix preset: m.room.message events sent through the client-server API.
+// ABOUTME: The X-Request-ID is the transaction ID, so retried sends post the message only once.
+package webhook
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/sessionname"
+)
+
+// MatrixFormatter sends an m.room.message event to a Matrix room. URL is the homeserver
+// (https://matrix.org), RoomID the room's ID (!abc123:matrix.org) and Token an access token.
+type MatrixFormatter struct {
+	URL    string
+	RoomID string
+	Token  string
+}
+
+func (f *MatrixFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
+	endpoint, err := matrixSendURL(f.URL, f.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionLabel := sessionname.GenerateSessionLabel(sessionID)
+	plain := fmt.Sprintf("%s\n\n%s\n\nSession: %s", statusInfo.Title, message, sessionLabel)
+	formatted := fmt.Sprintf("<b>%s</b><br><br>%s<br><br><i>Session: %s</i>",
+		matrixEscape(statusInfo.Title), matrixEscape(message), matrixEscape(sessionLabel))
+
+	return &Request{
+		Method:          http.MethodPut,
+		URL:             endpoint,
+		AppendRequestID: true,
+		Headers:         map[string]string{"Authorization": "Bearer " + f.Token},
+		Payload: map[string]interface{}{
+			// m.notice is what bots should send, but the default push rules never notify for it
+			"msgtype":        "m.text",
+			"body":           plain,
+			"format":         "org.matrix.custom.html",
+			"formatted_body": formatted,
+		},
+	}, nil
+}
+
+// matrixSendURL returns the send endpoint of roomID on the homeserver, without the transaction ID
+func matrixSendURL(homeserver, roomID string) (string, error) {
+	u, err := url.Parse(homeserver)
+	if err != nil {
+		return "", err
+	}
+	if roomID == "" {
+		return "", fmt.Errorf("matrix room_id is required")
+	}
+	u.RawQuery, u.Fragment = "", ""
+	return strings.TrimSuffix(u.String(), "/") + "/_matrix/client/v3/rooms/" + url.PathEscape(roomID) + "/send/m.room.message/", nil
+}
+
+// matrixEscape escapes text for formatted_body and keeps its line breaks
+func matrixEscape(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+)
+
+// matrixRequest is one request received by the homeserver stand-in
+type matrixRequest struct {
+	method, path, requestID, auth string
+	payload                       map[string]string
+}
+
+// newMatrixServer starts a homeserver stand-in that fails the first failures requests with 502
+func newMatrixServer(t *testing.T, failures int) (*httptest.Server, func() []matrixRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []matrixRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := matrixRequest{method: r.Method, path: r.URL.Path, requestID: r.Header.Get("X-Request-ID"), auth: r.Header.Get("Authorization")}
+		if err := json.Unmarshal(body, &req.payload); err != nil {
+			t.Errorf("body is not JSON: %q", body)
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		n := len(requests)
+		mu.Unlock()
+		if n <= failures {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"event_id":"$event"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []matrixRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]matrixRequest(nil), requests...)
+	}
+}
+
+func newMatrixConfig(url string) *config.Config {
+	cfg := newTestConfig(url)
+	cfg.Notifications.Webhook.Preset = "matrix"
+	cfg.Notifications.Webhook.RoomID = "!room:example.org"
+	cfg.Notifications.Webhook.Token = "syt_secret"
+	return cfg
+}
+
+func TestSenderSendMatrix(t *testing.T) {
+	server, requests := newMatrixServer(t, 0)
+
+	if err := New(newMatrixConfig(server.URL+"/")).Send(analyzer.StatusQuestion, "Use <b>Postgres</b>?\nOr SQLite", "session-1"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(got))
+	}
+	req := got[0]
+	if req.method != http.MethodPut {
+		t.Errorf("method = %s, want PUT", req.method)
+	}
+	if want := "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/" + req.requestID; req.requestID == "" || req.path != want {
+		t.Errorf("path = %q, want %q", req.path, want)
+	}
+	if req.auth != "Bearer syt_secret" {
+		t.Errorf("Authorization = %q", req.auth)
+	}
+	if req.payload["msgtype"] != "m.text" || req.payload["format"] != "org.matrix.custom.html" {
+		t.Errorf("unexpected payload %v", req.payload)
+	}
+	if !strings.Contains(req.payload["body"], "Use <b>Postgres</b>?\nOr SQLite") {
+		t.Errorf("body should carry the plain text, got %q", req.payload["body"])
+	}
+	if !strings.Contains(req.payload["formatted_body"], "<b>Question</b>") ||
+		!strings.Contains(req.payload["formatted_body"], "Use &lt;b&gt;Postgres&lt;/b&gt;?<br>Or SQLite") {
+		t.Errorf("formatted_body should be escaped HTML, got %q", req.payload["formatted_body"])
+	}
+}
+
+func TestSenderSendMatrix_RetriesReuseTransactionID(t *testing.T) {
+	server, requests := newMatrixServer(t, 2)
+
+	if err := New(newMatrixConfig(server.URL)).Send(analyzer.StatusTaskComplete, "All done", "session-1"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(got))
+	}
+	for _, req := range got[1:] {
+		if req.path != got[0].path {
+			t.Errorf("retry went to %q, want the same transaction as %q", req.path, got[0].path)
+		}
+	}
+}
+
+func TestMatrixSendURL(t *testing.T) {
+	got, err := matrixSendURL("https://matrix.example.org/base/?x=1", "!a b:example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://matrix.example.org/base/_matrix/client/v3/rooms/%21a%20b:example.org/send/m.room.message/"; got != want {
+		t.Errorf("matrixSendURL = %q, want %q", got, want)
+	}
+	if _, err := matrixSendURL("https://matrix.example.org", ""); err == nil {
+		t.Error("expected an error without room ID")
+	}
+}
@@ -166,3 +166,29 @@ func (s *Stats) FailureRate() float64 {
 	}
 	return float64(s.FailedRequests) / float64(s.TotalRequests) * 100
 }
+
+// combineStats sums the stats of several targets. The average latency is weighted by
+// successful requests; the circuit breaker state is the worst one (open, then half-open).
+func combineStats(all []Stats) Stats {
+	total := Stats{StatusCounts: make(map[analyzer.Status]int64)}
+	var latencySum int64
+	for _, s := range all {
+		total.TotalRequests += s.TotalRequests
+		total.SuccessfulRequests += s.SuccessfulRequests
+		total.FailedRequests += s.FailedRequests
+		total.RetriedRequests += s.RetriedRequests
+		total.RateLimitedRequests += s.RateLimitedRequests
+		total.CircuitOpenRequests += s.CircuitOpenRequests
+		for status, count := range s.StatusCounts {
+			total.StatusCounts[status] += count
+		}
+		latencySum += s.AverageLatencyMs * s.SuccessfulRequests
+		if s.CircuitBreakerState == StateOpen || (s.CircuitBreakerState == StateHalfOpen && total.CircuitBreakerState != StateOpen) {
+			total.CircuitBreakerState = s.CircuitBreakerState
+		}
+	}
+	if total.SuccessfulRequests > 0 {
+		total.AverageLatencyMs = latencySum / total.SuccessfulRequests
+	}
+	return total
+}
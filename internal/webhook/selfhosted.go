@@ -0,0 +1,140 @@
+// ABOUTME: ntfy and Gotify presets for self-hosted push servers, using each server's native publish API.
+// ABOUTME: Every status maps to a priority; ntfy tags show the status emoji.
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+)
+
+// NtfyFormatter publishes to ntfy as JSON. URL is the topic URL (https://ntfy.sh/my-topic);
+// JSON messages go to the server root with the topic in the body.
+type NtfyFormatter struct {
+	URL   string
+	Token string // Access token, sent as a Bearer token (optional)
+}
+
+func (f *NtfyFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
+	root, topic, err := splitNtfyURL(f.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &Request{
+		URL: root,
+		Payload: map[string]interface{}{
+			"topic":    topic,
+			"title":    statusInfo.Title,
+			"message":  message,
+			"priority": getNtfyPriority(status),
+			"tags":     []string{getNtfyTag(status), string(status)},
+		},
+	}
+	if f.Token != "" {
+		req.Headers = map[string]string{"Authorization": "Bearer " + f.Token}
+	}
+	return req, nil
+}
+
+// splitNtfyURL splits an ntfy topic URL into the server root and the topic
+func splitNtfyURL(rawURL string) (root, topic string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	if i < 0 || path[i+1:] == "" {
+		return "", "", fmt.Errorf("ntfy URL must end with the topic, e.g. https://ntfy.sh/my-topic")
+	}
+	topic = path[i+1:]
+	u.Path = path[:i+1]
+	u.RawPath, u.RawQuery, u.Fragment = "", "", ""
+	return u.String(), topic, nil
+}
+
+// GotifyFormatter posts to Gotify's /message endpoint. URL is the server URL; Token is
+// the application token.
+type GotifyFormatter struct {
+	URL   string
+	Token string
+}
+
+func (f *GotifyFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
+	endpoint := f.URL
+	if u, err := url.Parse(f.URL); err == nil {
+		u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/message") + "/message"
+		u.RawPath = ""
+		endpoint = u.String()
+	}
+
+	return &Request{
+		URL:     endpoint,
+		Headers: map[string]string{"X-Gotify-Key": f.Token},
+		Payload: map[string]interface{}{
+			"title":    statusInfo.Title,
+			"message":  message,
+			"priority": getGotifyPriority(status),
+		},
+	}, nil
+}
+
+// getNtfyPriority returns the ntfy priority for status (1 = min, 3 = default, 5 = max)
+func getNtfyPriority(status analyzer.Status) int {
+	switch status {
+	case analyzer.StatusAPIError, analyzer.StatusAPIErrorOverloaded:
+		return 5
+	case analyzer.StatusQuestion, analyzer.StatusPermissionRequest, analyzer.StatusPlanReady, analyzer.StatusSessionLimitReached:
+		return 4
+	case analyzer.StatusReviewComplete, analyzer.StatusContextCompacting:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// getGotifyPriority returns the Gotify priority for status (0-10; the Android app
+// is silent below 4 and pops up from 8)
+func getGotifyPriority(status analyzer.Status) int {
+	switch status {
+	case analyzer.StatusAPIError, analyzer.StatusAPIErrorOverloaded:
+		return 10
+	case analyzer.StatusQuestion, analyzer.StatusPermissionRequest, analyzer.StatusPlanReady, analyzer.StatusSessionLimitReached:
+		return 8
+	case analyzer.StatusReviewComplete, analyzer.StatusContextCompacting:
+		return 2
+	default:
+		return 5
+	}
+}
+
+// getNtfyTag returns the ntfy tag for the emoji of status's default title (ntfy shows
+// emoji short codes as emoji)
+func getNtfyTag(status analyzer.Status) string {
+	switch status {
+	case analyzer.StatusTaskComplete:
+		return "white_check_mark"
+	case analyzer.StatusReviewComplete:
+		return "mag"
+	case analyzer.StatusQuestion:
+		return "question"
+	case analyzer.StatusPlanReady:
+		return "clipboard"
+	case analyzer.StatusSessionLimitReached:
+		return "stopwatch"
+	case analyzer.StatusAPIError, analyzer.StatusAPIErrorOverloaded:
+		return "red_circle"
+	case analyzer.StatusContextCompacting:
+		return "clamp"
+	case analyzer.StatusPermissionRequest:
+		return "closed_lock_with_key"
+	case analyzer.StatusIdlePrompt:
+		return "zzz"
+	default:
+		return "information_source"
+	}
+}
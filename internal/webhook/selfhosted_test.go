@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+)
+
+// pushServer is a local stand-in for an ntfy or Gotify server that records the last request
+type pushServer struct {
+	*httptest.Server
+	path    string
+	headers http.Header
+	payload map[string]interface{}
+}
+
+func newPushServer(t *testing.T) *pushServer {
+	t.Helper()
+	s := &pushServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.path, s.headers, s.payload = r.URL.Path, r.Header, nil
+		if err := json.Unmarshal(body, &s.payload); err != nil {
+			t.Errorf("body is not JSON: %q", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSenderSendNtfy(t *testing.T) {
+	server := newPushServer(t)
+	cfg := newTestConfig(server.URL + "/ntfy/claude-alerts")
+	cfg.Notifications.Webhook.Preset = "ntfy"
+	cfg.Notifications.Webhook.Token = "tk_secret"
+
+	if err := New(cfg).Send(analyzer.StatusQuestion, "Which database?", "session-1"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if server.path != "/ntfy/" {
+		t.Errorf("JSON messages go to the server root, got path %q", server.path)
+	}
+	if got := server.headers.Get("Authorization"); got != "Bearer tk_secret" {
+		t.Errorf("Authorization = %q", got)
+	}
+	if server.payload["topic"] != "claude-alerts" || server.payload["title"] != "Question" || server.payload["message"] != "Which database?" {
+		t.Errorf("unexpected payload %v", server.payload)
+	}
+	if server.payload["priority"] != float64(4) {
+		t.Errorf("priority = %v, want 4", server.payload["priority"])
+	}
+	tags, _ := server.payload["tags"].([]interface{})
+	if len(tags) != 2 || tags[0] != "question" || tags[1] != "question" {
+		t.Errorf("tags = %v, want the emoji and the status", tags)
+	}
+}
+
+func TestSenderSendGotify(t *testing.T) {
+	server := newPushServer(t)
+	cfg := newTestConfig(server.URL + "/")
+	cfg.Notifications.Webhook.Preset = "gotify"
+	cfg.Notifications.Webhook.Token = "A1b2C3"
+	cfg.Notifications.Webhook.Headers = map[string]string{"X-Extra": "yes"}
+
+	if err := New(cfg).Send(analyzer.StatusTaskComplete, "All done", "session-1"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if server.path != "/message" {
+		t.Errorf("path = %q, want /message", server.path)
+	}
+	if server.headers.Get("X-Gotify-Key") != "A1b2C3" || server.headers.Get("X-Extra") != "yes" {
+		t.Errorf("expected the token and the configured headers, got %v", server.headers)
+	}
+	if server.payload["title"] != "Task Complete" || server.payload["message"] != "All done" || server.payload["priority"] != float64(5) {
+		t.Errorf("unexpected payload %v", server.payload)
+	}
+}
+
+func TestSplitNtfyURL(t *testing.T) {
+	tests := []struct {
+		url, root, topic string
+	}{
+		{"https://ntfy.sh/mytopic", "https://ntfy.sh/", "mytopic"},
+		{"https://push.example.com/ntfy/alerts/?x=1", "https://push.example.com/ntfy/", "alerts"},
+	}
+	for _, tt := range tests {
+		root, topic, err := splitNtfyURL(tt.url)
+		if err != nil || root != tt.root || topic != tt.topic {
+			t.Errorf("splitNtfyURL(%q) = %q, %q, %v; want %q, %q", tt.url, root, topic, err, tt.root, tt.topic)
+		}
+	}
+	if _, _, err := splitNtfyURL("https://ntfy.sh/"); err == nil {
+		t.Error("expected an error for a URL without topic")
+	}
+}
+
+func TestPushPriorities(t *testing.T) {
+	tests := []struct {
+		status       analyzer.Status
+		ntfy, gotify int
+	}{
+		{analyzer.StatusAPIError, 5, 10},
+		{analyzer.StatusPermissionRequest, 4, 8},
+		{analyzer.StatusTaskComplete, 3, 5},
+		{analyzer.StatusReviewComplete, 2, 2},
+	}
+	for _, tt := range tests {
+		if got := getNtfyPriority(tt.status); got != tt.ntfy {
+			t.Errorf("getNtfyPriority(%s) = %d, want %d", tt.status, got, tt.ntfy)
+		}
+		if got := getGotifyPriority(tt.status); got != tt.gotify {
+			t.Errorf("getGotifyPriority(%s) = %d, want %d", tt.status, got, tt.gotify)
+		}
+	}
+	// api_error is the most urgent status on both
+	for _, status := range []analyzer.Status{analyzer.StatusQuestion, analyzer.StatusSessionLimitReached, analyzer.StatusIdlePrompt} {
+		if getNtfyPriority(status) >= getNtfyPriority(analyzer.StatusAPIError) || getGotifyPriority(status) >= getGotifyPriority(analyzer.StatusAPIError) {
+			t.Errorf("%s should rank below api_error", status)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// ABOUTME: Microsoft Teams preset: an Adaptive Card for Teams Workflows and incoming webhooks.
+// ABOUTME: Text is escaped for the card's Markdown subset and the message is cut to fit the payload limit.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/sessionname"
+)
+
+// teamsMaxPayloadBytes keeps cards under the 28 KB message limit of Teams webhooks
+const teamsMaxPayloadBytes = 27 * 1024
+
+// teamsEscaper backslash-escapes the Markdown that TextBlocks and facts interpret
+var teamsEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`",
+	"[", `\[`, "]", `\]`, "#", `\#`, "<", `\<`, ">", `\>`,
+)
+
+// TeamsFormatter formats messages as an Adaptive Card for Microsoft Teams
+type TeamsFormatter struct{}
+
+func (f *TeamsFormatter) Format(status analyzer.Status, message, sessionID string, project Project, statusInfo config.StatusInfo) (interface{}, error) {
+	sessionLabel := sessionname.GenerateSessionLabel(sessionID)
+	// The facts show what the default "[session|branch folder] " prefix of the webhook text says
+	if project.Folder != "" {
+		prefix := fmt.Sprintf("[%s %s] ", sessionLabel, project.Folder)
+		if project.Branch != "" {
+			prefix = fmt.Sprintf("[%s|%s %s] ", sessionLabel, project.Branch, project.Folder)
+		}
+		message = strings.TrimPrefix(message, prefix)
+	}
+
+	facts := []map[string]string{{"title": "Session", "value": teamsEscape(sessionLabel)}}
+	if project.Branch != "" {
+		facts = append(facts, map[string]string{"title": "Branch", "value": teamsEscape(project.Branch)})
+	}
+	if project.Folder != "" {
+		facts = append(facts, map[string]string{"title": "Folder", "value": teamsEscape(project.Folder)})
+	}
+
+	card := teamsCard(status, statusInfo.Title, facts, message)
+	data, err := json.Marshal(card)
+	if err != nil {
+		return nil, err
+	}
+	// Escaping and JSON encoding can more than double the text, so cut it in proportion
+	// to the excess (or by the excess itself once that is the smaller cut)
+	for len(data) > teamsMaxPayloadBytes && message != "" {
+		keep := len(message) * teamsMaxPayloadBytes / len(data)
+		if exact := len(message) - (len(data) - teamsMaxPayloadBytes); exact > keep {
+			keep = exact
+		}
+		message = truncateBytes(strings.TrimSuffix(message, "…"), keep-len("…"))
+		if message != "" {
+			message += "…"
+		}
+		card = teamsCard(status, statusInfo.Title, facts, message)
+		if data, err = json.Marshal(card); err != nil {
+			return nil, err
+		}
+	}
+	return card, nil
+}
+
+// teamsCard builds the message: a status-colored header, the facts and the message text
+func teamsCard(status analyzer.Status, title string, facts []map[string]string, message string) map[string]interface{} {
+	body := []map[string]interface{}{
+		{
+			"type":  "Container",
+			"style": getTeamsContainerStyle(status),
+			"bleed": true,
+			"items": []map[string]interface{}{
+				{"type": "TextBlock", "text": teamsEscape(title), "weight": "Bolder", "size": "Medium", "wrap": true},
+			},
+		},
+		{"type": "FactSet", "facts": facts},
+	}
+	if message != "" {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": teamsEscape(message), "wrap": true})
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"msteams": map[string]string{"width": "Full"},
+					"body":    body,
+				},
+			},
+		},
+	}
+}
+
+// teamsEscape escapes Markdown and turns newlines into the blank lines TextBlocks need for a line break
+func teamsEscape(s string) string {
+	s = teamsEscaper.Replace(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "\n\n")
+}
+
+// truncateBytes cuts s to at most n bytes without splitting a UTF-8 character
+func truncateBytes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// getTeamsContainerStyle returns the Adaptive Card container style for status
+func getTeamsContainerStyle(status analyzer.Status) string {
+	switch status {
+	case analyzer.StatusTaskComplete:
+		return "good" // Green
+	case analyzer.StatusReviewComplete, analyzer.StatusPlanReady:
+		return "accent" // Blue
+	case analyzer.StatusQuestion, analyzer.StatusPermissionRequest:
+		return "warning" // Yellow/Orange
+	case analyzer.StatusAPIError, analyzer.StatusAPIErrorOverloaded, analyzer.StatusSessionLimitReached:
+		return "attention" // Red
+	default:
+		return "emphasis" // Gray
+	}
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/777genius/claude-notifications/internal/analyzer"
+	"github.com/777genius/claude-notifications/internal/config"
+	"github.com/777genius/claude-notifications/internal/sessionname"
+)
+
+// teamsCardBody formats a message and returns the card's body elements
+func teamsCardBody(t *testing.T, status analyzer.Status, message string, project Project) []interface{} {
+	t.Helper()
+	result, err := (&TeamsFormatter{}).Format(status, message, "session-123", project, config.StatusInfo{Title: "✅ Completed"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if len(data) > teamsMaxPayloadBytes {
+		t.Errorf("payload is %d bytes, want at most %d", len(data), teamsMaxPayloadBytes)
+	}
+
+	var payload struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string        `json:"type"`
+				Body []interface{} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if payload.Type != "message" || len(payload.Attachments) != 1 {
+		t.Fatalf("expected a message with one attachment, got %s", data)
+	}
+	attachment := payload.Attachments[0]
+	if attachment.ContentType != "application/vnd.microsoft.card.adaptive" || attachment.Content.Type != "AdaptiveCard" {
+		t.Fatalf("expected an Adaptive Card attachment, got %s", data)
+	}
+	return attachment.Content.Body
+}
+
+func TestTeamsFormatterFormat(t *testing.T) {
+	label := sessionname.GenerateSessionLabel("session-123")
+	project := Project{CWD: "/work/my_app", Branch: "feature/x", Folder: "my_app"}
+	body := teamsCardBody(t, analyzer.StatusTaskComplete, "["+label+"|feature/x my_app] Fixed *all* tests\nin <pkg>", project)
+	if len(body) != 3 {
+		t.Fatalf("expected header, facts and message, got %v", body)
+	}
+
+	header := body[0].(map[string]interface{})
+	if header["type"] != "Container" || header["style"] != "good" {
+		t.Errorf("expected a green container, got %v", header)
+	}
+	title := header["items"].([]interface{})[0].(map[string]interface{})
+	if title["text"] != "✅ Completed" {
+		t.Errorf("title = %v", title["text"])
+	}
+
+	facts := body[1].(map[string]interface{})["facts"].([]interface{})
+	want := map[string]string{"Session": label, "Branch": "feature/x", "Folder": `my\_app`}
+	if len(facts) != len(want) {
+		t.Fatalf("expected %d facts, got %v", len(want), facts)
+	}
+	for _, f := range facts {
+		fact := f.(map[string]interface{})
+		if value := want[fact["title"].(string)]; fact["value"] != value {
+			t.Errorf("fact %v = %q, want %q", fact["title"], fact["value"], value)
+		}
+	}
+
+	text := body[2].(map[string]interface{})["text"]
+	if text != `Fixed \*all\* tests`+"\n\n"+`in \<pkg\>` {
+		t.Errorf("message should drop the prefix and be escaped, got %q", text)
+	}
+}
+
+func TestTeamsFormatter_CustomTextWithoutProject(t *testing.T) {
+	body := teamsCardBody(t, analyzer.StatusQuestion, "[keep] this", Project{})
+	facts := body[1].(map[string]interface{})["facts"].([]interface{})
+	if len(facts) != 1 {
+		t.Errorf("expected only the session fact, got %v", facts)
+	}
+	if text := body[2].(map[string]interface{})["text"]; text != `\[keep\] this` {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestTeamsFormatter_TruncatesToPayloadLimit(t *testing.T) {
+	message := strings.Repeat("_é", teamsMaxPayloadBytes)
+	body := teamsCardBody(t, analyzer.StatusPlanReady, message, Project{})
+	text := body[2].(map[string]interface{})["text"].(string)
+	if !strings.HasSuffix(text, "…") || !strings.HasPrefix(text, `\_é`) {
+		t.Errorf("expected the message cut with an ellipsis, got %q...", text[:20])
+	}
+}
+
+func TestGetTeamsContainerStyle(t *testing.T) {
+	tests := map[analyzer.Status]string{
+		analyzer.StatusTaskComplete:      "good",
+		analyzer.StatusPlanReady:         "accent",
+		analyzer.StatusQuestion:          "warning",
+		analyzer.StatusAPIError:          "attention",
+		analyzer.StatusContextCompacting: "emphasis",
+	}
+	for status, want := range tests {
+		if got := getTeamsContainerStyle(status); got != want {
+			t.Errorf("getTeamsContainerStyle(%s) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestTruncateBytes(t *testing.T) {
+	if got := truncateBytes("héllo", 2); got != "h" {
+		t.Errorf("should not split é, got %q", got)
+	}
+	if got := truncateBytes("héllo", 3); got != "hé" {
+		t.Errorf("got %q", got)
+	}
+	if got := truncateBytes("abc", 10); got != "abc" {
+		t.Errorf("got %q", got)
+	}
+	if got := truncateBytes("abc", -1); got != "" {
+		t.Errorf("got %q", got)
+	}
+}
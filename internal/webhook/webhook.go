@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -15,18 +17,18 @@ import (
 	"github.com/777genius/claude-notifications/internal/config"
 	"github.com/777genius/claude-notifications/internal/errorhandler"
 	"github.com/777genius/claude-notifications/internal/logging"
+	"github.com/777genius/claude-notifications/internal/platform"
 	"github.com/google/uuid"
 )
 
-// Sender sends webhook notifications with professional patterns
+// Sender sends webhook notifications with professional patterns. Every enabled target
+// has its own retry, circuit breaker, rate limiter and metrics, and targets are sent
+// to concurrently, so a slow or dead endpoint doesn't hold up the others.
 type Sender struct {
-	cfg            *config.Config
-	client         *http.Client
-	retry          *Retryer
-	circuitBreaker *CircuitBreaker
-	rateLimiter    *RateLimiter
-	metrics        *Metrics
-	formatters     map[string]Formatter
+	cfg     *config.Config
+	client  *http.Client
+	targets map[string]*target
+	names   []string // Target names in config order
 
 	// Graceful shutdown
 	wg     sync.WaitGroup
@@ -34,76 +36,143 @@ type Sender struct {
 	cancel context.CancelFunc
 }
 
-// New creates a new professional webhook sender
+// target is one webhook endpoint and its delivery state
+type target struct {
+	cfg            config.WebhookConfig
+	retry          *Retryer
+	circuitBreaker *CircuitBreaker
+	rateLimiter    *RateLimiter
+	metrics        *Metrics
+	formatters     map[string]Formatter
+}
+
+// New creates a new professional webhook sender for the enabled targets of cfg
 func New(cfg *config.Config) *Sender {
 	// Create base HTTP client with timeout
 	client := &http.Client{
 		Timeout: 10 * time.Second,
 	}
 
+	// Create context for graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Sender{
+		cfg:     cfg,
+		client:  client,
+		targets: make(map[string]*target),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for _, targetCfg := range cfg.WebhookTargets() {
+		s.targets[targetCfg.Name] = newTarget(targetCfg)
+		s.names = append(s.names, targetCfg.Name)
+	}
+	return s
+}
+
+// newTarget creates the retry, circuit breaker, rate limiter and formatters of one target
+func newTarget(cfg config.WebhookConfig) *target {
 	// Parse retry config
-	retryConfig := parseRetryConfig(cfg.Notifications.Webhook.Retry)
-	retry := NewRetryer(retryConfig)
+	retry := NewRetryer(parseRetryConfig(cfg.Retry))
 
 	// Parse circuit breaker config
-	cbCfg := cfg.Notifications.Webhook.CircuitBreaker
 	var circuitBreaker *CircuitBreaker
-	if cbCfg.Enabled {
-		timeout, _ := time.ParseDuration(cbCfg.Timeout)
+	if cfg.CircuitBreaker.Enabled {
+		timeout, _ := time.ParseDuration(cfg.CircuitBreaker.Timeout)
 		if timeout == 0 {
 			timeout = 30 * time.Second
 		}
-		circuitBreaker = NewCircuitBreaker(cbCfg.FailureThreshold, cbCfg.SuccessThreshold, timeout)
+		circuitBreaker = NewCircuitBreaker(cfg.CircuitBreaker.FailureThreshold, cfg.CircuitBreaker.SuccessThreshold, timeout)
 	}
 
 	// Create rate limiter
 	var rateLimiter *RateLimiter
-	if cfg.Notifications.Webhook.RateLimit.Enabled {
-		rateLimiter = NewRateLimiter(cfg.Notifications.Webhook.RateLimit.RequestsPerMinute)
+	if cfg.RateLimit.Enabled {
+		rateLimiter = NewRateLimiter(cfg.RateLimit.RequestsPerMinute)
 	}
 
 	// Create formatters
 	formatters := map[string]Formatter{
 		"slack":    &SlackFormatter{},
 		"discord":  &DiscordFormatter{},
-		"telegram": &TelegramFormatter{ChatID: cfg.Notifications.Webhook.ChatID},
+		"telegram": &TelegramFormatter{ChatID: cfg.ChatID},
 		"lark":     &LarkFormatter{},
+		"teams":    &TeamsFormatter{},
+		"ntfy":     &NtfyFormatter{URL: cfg.URL, Token: cfg.Token},
+		"gotify":   &GotifyFormatter{URL: cfg.URL, Token: cfg.Token},
+		"matrix":   &MatrixFormatter{URL: cfg.URL, RoomID: cfg.RoomID, Token: cfg.Token},
 	}
 
-	// Create context for graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-
-	return &Sender{
+	return &target{
 		cfg:            cfg,
-		client:         client,
 		retry:          retry,
 		circuitBreaker: circuitBreaker,
 		rateLimiter:    rateLimiter,
 		metrics:        NewMetrics(),
 		formatters:     formatters,
-		ctx:            ctx,
-		cancel:         cancel,
 	}
 }
 
-// Send sends a webhook notification with full professional stack
+// Send sends a webhook notification to every target that accepts the status
 func (s *Sender) Send(status analyzer.Status, message, sessionID string) error {
+	return s.SendTo(nil, status, message, sessionID, "")
+}
+
+// SendTo sends a webhook notification to the named targets that accept the status
+// (nil names = every target). Targets are sent to concurrently; the error joins the
+// failures of all targets, each prefixed with its name if there is more than one.
+// cwd is the project directory, shown by presets with project fields (teams); may be empty.
+func (s *Sender) SendTo(names []string, status analyzer.Status, message, sessionID, cwd string) error {
 	if !s.cfg.IsWebhookEnabled() {
 		logging.Debug("Webhooks disabled, skipping")
 		return nil
 	}
 
+	var targets []*target
+	for _, targetCfg := range s.cfg.WebhookTargetsFor(string(status), names) {
+		if t, ok := s.targets[targetCfg.Name]; ok {
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		logging.Debug("No webhook target sends status %s, skipping", status)
+		return nil
+	}
+
+	project := projectFor(cwd)
+	if len(targets) == 1 {
+		return s.sendTarget(targets[0], status, message, sessionID, project)
+	}
+
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		i, t := i, t
+		wg.Add(1)
+		errorhandler.SafeGo(func() {
+			defer wg.Done()
+			if err := s.sendTarget(t, status, message, sessionID, project); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", t.cfg.Name, err)
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// sendTarget sends to one target with its rate limiter, circuit breaker and retries
+func (s *Sender) sendTarget(t *target, status analyzer.Status, message, sessionID string, project Project) error {
 	// Check rate limit (non-blocking check)
-	if s.rateLimiter != nil && !s.rateLimiter.Allow() {
-		s.metrics.RecordRateLimited()
-		logging.Warn("Rate limit exceeded, dropping webhook")
+	if t.rateLimiter != nil && !t.rateLimiter.Allow() {
+		t.metrics.RecordRateLimited()
+		logging.Warn("Rate limit exceeded, dropping webhook %q", t.cfg.Name)
 		return ErrRateLimitExceeded
 	}
 
 	// Check circuit breaker
-	if s.circuitBreaker != nil && s.circuitBreaker.GetState() == StateOpen {
-		s.metrics.RecordCircuitOpen()
-		logging.Warn("Circuit breaker is open, skipping webhook")
+	if t.circuitBreaker != nil && t.circuitBreaker.GetState() == StateOpen {
+		t.metrics.RecordCircuitOpen()
+		logging.Warn("Circuit breaker is open, skipping webhook %q", t.cfg.Name)
 		return ErrCircuitOpen
 	}
 
@@ -111,83 +180,150 @@ func (s *Sender) Send(status analyzer.Status, message, sessionID string) error {
 	requestID := uuid.New().String()
 
 	// Record metrics
-	s.metrics.RecordRequest()
+	t.metrics.RecordRequest()
 	start := time.Now()
 
 	// Execute with retry and circuit breaker
-	err := s.sendWithRetryAndCircuitBreaker(requestID, status, message, sessionID)
+	err := s.sendWithRetryAndCircuitBreaker(t, requestID, status, message, sessionID, project)
 
 	// Record result
 	latency := time.Since(start)
 	if err != nil {
-		s.metrics.RecordFailure()
-		logging.Error("[%s] Webhook failed after retries: %v (latency: %v)", requestID, err, latency)
+		t.metrics.RecordFailure()
+		logging.Error("[%s] Webhook %q failed after retries: %v (latency: %v)", requestID, t.cfg.Name, err, latency)
 	} else {
-		s.metrics.RecordSuccess(status, latency)
-		logging.Info("[%s] Webhook sent successfully (latency: %v)", requestID, latency)
+		t.metrics.RecordSuccess(status, latency)
+		logging.Info("[%s] Webhook %q sent successfully (latency: %v)", requestID, t.cfg.Name, latency)
 	}
 
 	// Update circuit breaker state in metrics
-	if s.circuitBreaker != nil {
-		s.metrics.UpdateCircuitBreakerState(s.circuitBreaker.GetState())
+	if t.circuitBreaker != nil {
+		t.metrics.UpdateCircuitBreakerState(t.circuitBreaker.GetState())
 	}
 
 	return err
 }
 
 // sendWithRetryAndCircuitBreaker executes the webhook with retry and circuit breaker
-func (s *Sender) sendWithRetryAndCircuitBreaker(requestID string, status analyzer.Status, message, sessionID string) error {
-	webhookCfg := s.cfg.Notifications.Webhook
-
+func (s *Sender) sendWithRetryAndCircuitBreaker(t *target, requestID string, status analyzer.Status, message, sessionID string, project Project) error {
 	// Build payload
-	payload, contentType, err := s.buildPayload(status, message, sessionID)
+	req, err := s.buildRequest(t, status, message, sessionID, project)
 	if err != nil {
 		return fmt.Errorf("failed to build payload: %w", err)
 	}
+	if req.appendRequestID {
+		// Every retry reuses the ID, so the server can drop repeats
+		req.url += url.PathEscape(requestID)
+	}
 
 	// Validate URL
-	if err := validateURL(webhookCfg.URL); err != nil {
+	if err := ValidateURL(req.url); err != nil {
 		return fmt.Errorf("invalid webhook URL: %w", err)
 	}
 
 	// Create request function for retry
 	sendFn := func(ctx context.Context) error {
-		return s.sendHTTPRequest(ctx, requestID, webhookCfg.URL, payload, contentType, webhookCfg.Headers)
+		return s.sendHTTPRequest(ctx, requestID, req)
 	}
 
 	// Execute with circuit breaker and retry
 	var executeErr error
-	if s.circuitBreaker != nil {
+	if t.circuitBreaker != nil {
 		// Wrap with circuit breaker
-		executeErr = s.circuitBreaker.Execute(s.ctx, func() error {
+		executeErr = t.circuitBreaker.Execute(s.ctx, func() error {
 			// Execute with retry
-			return s.retry.Do(s.ctx, sendFn)
+			return t.retry.Do(s.ctx, sendFn)
 		})
 	} else {
 		// Just retry without circuit breaker
-		executeErr = s.retry.Do(s.ctx, sendFn)
+		executeErr = t.retry.Do(s.ctx, sendFn)
 	}
 
 	return executeErr
 }
 
-// buildPayload builds the webhook payload based on preset
-func (s *Sender) buildPayload(status analyzer.Status, message, sessionID string) ([]byte, string, error) {
-	webhookCfg := s.cfg.Notifications.Webhook
+// Project is the project a notification comes from
+type Project struct {
+	CWD    string
+	Branch string // "" outside git repos
+	Folder string
+}
+
+// projectFor looks up the git branch and folder name of cwd
+func projectFor(cwd string) Project {
+	if cwd == "" {
+		return Project{}
+	}
+	return Project{CWD: cwd, Branch: platform.GetGitBranch(cwd), Folder: filepath.Base(cwd)}
+}
+
+// Request is what a formatter returns instead of a bare payload when the API needs
+// another URL than the configured one, another method or extra headers
+type Request struct {
+	Method          string            // "" = POST
+	URL             string            // "" = the target's URL
+	AppendRequestID bool              // Append the X-Request-ID to the URL (transaction IDs)
+	Headers         map[string]string // Set after the target's headers
+	Payload         interface{}       // Sent as JSON
+}
+
+// request is a built webhook request
+type request struct {
+	method          string
+	url             string
+	appendRequestID bool
+	body            []byte
+	contentType     string
+	headers         map[string]string
+}
+
+// buildRequest builds the webhook request based on the target's preset
+func (s *Sender) buildRequest(t *target, status analyzer.Status, message, sessionID string, project Project) (*request, error) {
+	webhookCfg := t.cfg
 	statusInfo, _ := s.cfg.GetStatusInfo(string(status))
+	req := &request{method: http.MethodPost, url: webhookCfg.URL, headers: webhookCfg.Headers}
 
 	// Use formatter if available
-	if formatter, ok := s.formatters[webhookCfg.Preset]; ok {
-		payload, err := formatter.Format(status, message, sessionID, statusInfo)
+	if formatter, ok := t.formatters[webhookCfg.Preset]; ok {
+		payload, err := formatter.Format(status, message, sessionID, project, statusInfo)
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
-		data, err := json.Marshal(payload)
-		return data, "application/json", err
+		if r, ok := payload.(*Request); ok {
+			if r.Method != "" {
+				req.method = r.Method
+			}
+			if r.URL != "" {
+				req.url = r.URL
+			}
+			req.appendRequestID = r.AppendRequestID
+			req.headers = mergeHeaders(webhookCfg.Headers, r.Headers)
+			payload = r.Payload
+		}
+		req.body, err = json.Marshal(payload)
+		req.contentType = "application/json"
+		return req, err
 	}
 
 	// Fallback to custom format
-	return s.buildCustomPayload(status, message, sessionID, webhookCfg.Format, statusInfo)
+	var err error
+	req.body, req.contentType, err = s.buildCustomPayload(status, message, sessionID, webhookCfg.Format, statusInfo)
+	return req, err
+}
+
+// mergeHeaders returns base with extra added (extra wins)
+func mergeHeaders(base, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
 }
 
 // buildCustomPayload builds a custom webhook payload
@@ -212,19 +348,19 @@ func (s *Sender) buildCustomPayload(status analyzer.Status, message, sessionID,
 }
 
 // sendHTTPRequest sends the actual HTTP request
-func (s *Sender) sendHTTPRequest(ctx context.Context, requestID, url string, payload []byte, contentType string, headers map[string]string) error {
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+func (s *Sender) sendHTTPRequest(ctx context.Context, requestID string, r *request) error {
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, bytes.NewReader(r.body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Type", r.contentType)
 	req.Header.Set("User-Agent", "claude-notifications/1.0")
 	req.Header.Set("X-Request-ID", requestID)
 
 	// Set custom headers
-	for key, value := range headers {
+	for key, value := range r.headers {
 		req.Header.Set(key, value)
 	}
 
@@ -248,12 +384,17 @@ func (s *Sender) sendHTTPRequest(ctx context.Context, requestID, url string, pay
 
 // SendAsync sends a webhook asynchronously with graceful shutdown support
 func (s *Sender) SendAsync(status analyzer.Status, message, sessionID string) {
+	s.SendAsyncTo(nil, status, message, sessionID, "")
+}
+
+// SendAsyncTo sends to the named targets (nil = every target) asynchronously with graceful shutdown support
+func (s *Sender) SendAsyncTo(names []string, status analyzer.Status, message, sessionID, cwd string) {
 	s.wg.Add(1)
 	// Use SafeGo to protect against panics in async webhook sending
 	errorhandler.SafeGo(func() {
 		defer s.wg.Done()
 
-		if err := s.Send(status, message, sessionID); err != nil {
+		if err := s.SendTo(names, status, message, sessionID, cwd); err != nil {
 			errorhandler.HandleError(err, "Async webhook send failed")
 		}
 	})
@@ -287,9 +428,22 @@ func (s *Sender) Shutdown(timeout time.Duration) error {
 	}
 }
 
-// GetMetrics returns current metrics
+// GetMetrics returns current metrics, summed over all targets
 func (s *Sender) GetMetrics() Stats {
-	return s.metrics.GetStats()
+	stats := make([]Stats, 0, len(s.names))
+	for _, name := range s.names {
+		stats = append(stats, s.targets[name].metrics.GetStats())
+	}
+	return combineStats(stats)
+}
+
+// TargetMetrics returns current metrics per target name
+func (s *Sender) TargetMetrics() map[string]Stats {
+	stats := make(map[string]Stats, len(s.targets))
+	for name, t := range s.targets {
+		stats[name] = t.metrics.GetStats()
+	}
+	return stats
 }
 
 // Helper functions
@@ -315,8 +469,8 @@ func parseRetryConfig(cfg config.RetryConfig) RetryConfig {
 	}
 }
 
-// validateURL validates the webhook URL
-func validateURL(rawURL string) error {
+// ValidateURL checks that a webhook URL is an absolute http(s) URL
+func ValidateURL(rawURL string) error {
 	if rawURL == "" {
 		return fmt.Errorf("URL is empty")
 	}
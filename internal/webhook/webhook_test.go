@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -275,6 +276,37 @@ func TestSenderSendTelegramFormat(t *testing.T) {
 	}
 }
 
+func TestSenderSendTeamsFormat(t *testing.T) {
+	var receivedPayload map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &receivedPayload)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server.URL)
+	cfg.Notifications.Webhook.Preset = "teams"
+	sender := New(cfg)
+
+	cwd := t.TempDir()
+	if err := sender.SendTo(nil, analyzer.StatusTaskComplete, "Done", "session-789", cwd); err != nil {
+		t.Fatalf("SendTo failed: %v", err)
+	}
+
+	attachments, ok := receivedPayload["attachments"].([]interface{})
+	if receivedPayload["type"] != "message" || !ok || len(attachments) != 1 {
+		t.Fatalf("Expected a Teams message with one card, got %v", receivedPayload)
+	}
+	card := attachments[0].(map[string]interface{})["content"].(map[string]interface{})
+	facts := card["body"].([]interface{})[1].(map[string]interface{})["facts"].([]interface{})
+	last := facts[len(facts)-1].(map[string]interface{})
+	if last["title"] != "Folder" || last["value"] != teamsEscape(filepath.Base(cwd)) {
+		t.Errorf("Expected the folder of cwd as the last fact, got %v", facts)
+	}
+}
+
 func TestSenderSendCustomHeaders(t *testing.T) {
 	var receivedHeaders http.Header
 
@@ -458,9 +490,9 @@ func TestValidateURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateURL(tt.url)
+			err := ValidateURL(tt.url)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("validateURL() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ValidateURL() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -620,3 +652,105 @@ func TestWebhookShutdownWaitsForRequests(t *testing.T) {
 		t.Error("Request should have completed before Shutdown returned")
 	}
 }
+
+func TestSenderFansOutToTargets(t *testing.T) {
+	var legacyHits, teamHits atomic.Int32
+	legacy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		legacyHits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer legacy.Close()
+	team := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		teamHits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer team.Close()
+
+	cfg := newTestConfig(legacy.URL)
+	cfg.Notifications.Webhooks = config.WebhookTargets{
+		{Name: "team", Enabled: true, URL: team.URL, Format: "json", Statuses: []string{"question"}},
+		{Name: "off", Enabled: false, URL: team.URL, Format: "json"},
+	}
+	sender := New(cfg)
+
+	if err := sender.Send(analyzer.StatusQuestion, "Which one?", "session-1"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := sender.Send(analyzer.StatusTaskComplete, "Done", "session-1"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := sender.SendTo([]string{"team"}, analyzer.StatusQuestion, "Only team", "session-1", ""); err != nil {
+		t.Fatalf("SendTo: %v", err)
+	}
+
+	if got := legacyHits.Load(); got != 2 {
+		t.Errorf("default target got %d requests, want 2", got)
+	}
+	if got := teamHits.Load(); got != 2 {
+		t.Errorf("team target got %d requests, want 2 (questions only, disabled target skipped)", got)
+	}
+
+	perTarget := sender.TargetMetrics()
+	if len(perTarget) != 2 {
+		t.Fatalf("expected metrics for 2 enabled targets, got %v", perTarget)
+	}
+	if perTarget["default"].SuccessfulRequests != 2 || perTarget["team"].SuccessfulRequests != 2 {
+		t.Errorf("unexpected per-target metrics: %+v", perTarget)
+	}
+	if stats := sender.GetMetrics(); stats.SuccessfulRequests != 4 || stats.StatusCounts[analyzer.StatusQuestion] != 3 {
+		t.Errorf("unexpected combined metrics: %+v", stats)
+	}
+}
+
+func TestSenderTargetFailureIsolation(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	var delivered atomic.Int64
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered.Store(time.Now().UnixNano())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer fast.Close()
+
+	cfg := newTestConfig(slow.URL)
+	cfg.Notifications.Webhook.Retry.Enabled = false
+	cfg.Notifications.Webhooks = config.WebhookTargets{
+		{Name: "fast", Enabled: true, URL: fast.URL, Format: "json"},
+	}
+	sender := New(cfg)
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() { errCh <- sender.Send(analyzer.StatusTaskComplete, "Done", "session-1") }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for delivered.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if delivered.Load() == 0 {
+		t.Fatal("the fast target was held up by the slow one")
+	}
+	if elapsed := time.Unix(0, delivered.Load()).Sub(start); elapsed > time.Second {
+		t.Errorf("fast target delivered after %v", elapsed)
+	}
+
+	release <- struct{}{}
+	err := <-errCh
+	if err == nil || !strings.Contains(err.Error(), "default: ") {
+		t.Errorf("expected the default target's failure, got %v", err)
+	}
+	if strings.Contains(err.Error(), "fast") {
+		t.Errorf("the fast target must not fail: %v", err)
+	}
+
+	perTarget := sender.TargetMetrics()
+	if perTarget["fast"].SuccessfulRequests != 1 || perTarget["default"].FailedRequests != 1 {
+		t.Errorf("unexpected per-target metrics: %+v", perTarget)
+	}
+}